@@ -0,0 +1,66 @@
+// Package agent describes the CLI coding agents that run role sessions and
+// area discovery: the built-in opencode runtime and command-template runtimes
+// defined in the user settings.
+package agent
+
+import (
+	"strings"
+
+	"lattice/internal/config"
+)
+
+// Runtime runs one CLI coding agent.
+type Runtime interface {
+	// Name returns the runtime name, e.g. config.AgentOpencode.
+	Name() string
+	// AgentDir is the session-relative directory that holds agent definitions
+	// under agents/ and skills under skills/.
+	AgentDir() string
+	// ConfigFile returns the project config file written into each session; an
+	// empty name writes none.
+	ConfigFile() (name string, content []byte)
+	// RoleCommand returns the shell command that runs the named agent in a
+	// generated session directory with params.
+	RoleCommand(agentName string, params config.AgentParams) (string, error)
+	// PromptCommand returns the command line that answers prompt once and
+	// writes the answer to stdout.
+	PromptCommand(prompt string) ([]string, error)
+}
+
+// Resolve returns the runtime called name, looking custom names up in
+// runtimes; an empty name means opencode.
+func Resolve(name string, runtimes map[string]config.AgentRuntime) (Runtime, error) {
+	resolved, err := config.ResolveAgent(name, runtimes)
+	if err != nil {
+		return nil, err
+	}
+	if resolved == config.AgentOpencode {
+		return Opencode{}, nil
+	}
+
+	return NewTemplate(resolved, runtimes[resolved]), nil
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "'\"'\"'") + "'"
+}
+
+// shellQuoteSet quotes value unless it is empty, so unset parameters render as
+// nothing rather than as an empty argument.
+func shellQuoteSet(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return shellQuote(value)
+}
+
+// shellQuoteAll quotes each value and joins them with spaces.
+func shellQuoteAll(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, shellQuote(value))
+	}
+
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,125 @@
+package agent
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"lattice/internal/config"
+)
+
+func TestResolvePicksBuiltInOrTemplateRuntime(t *testing.T) {
+	t.Parallel()
+
+	runtimes := map[string]config.AgentRuntime{"claude": {Command: "claude -p {{.Prompt}}"}}
+
+	tests := []struct {
+		name     string
+		agent    string
+		wantName string
+		wantErr  string
+	}{
+		{name: "default", wantName: config.AgentOpencode},
+		{name: "opencode", agent: "opencode", wantName: config.AgentOpencode},
+		{name: "custom", agent: "claude", wantName: "claude"},
+		{name: "unknown", agent: "codex", wantErr: `unknown agent runtime "codex" (valid: opencode, claude)`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			runtime, err := Resolve(tt.agent, runtimes)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() returned error: %v", err)
+			}
+			if runtime.Name() != tt.wantName {
+				t.Fatalf("expected runtime %q, got %q", tt.wantName, runtime.Name())
+			}
+		})
+	}
+}
+
+func TestTemplateRendersRoleAndPromptCommands(t *testing.T) {
+	t.Parallel()
+
+	runtime := NewTemplate("codex", config.AgentRuntime{
+		Command:  "codex exec --instructions {{.AgentFile}} {{.Prompt}}",
+		AgentDir: ".codex/",
+	})
+
+	if got := runtime.AgentDir(); got != ".codex" {
+		t.Fatalf("AgentDir() = %q, want .codex", got)
+	}
+
+	command, err := runtime.RoleCommand("auditor", config.AgentParams{})
+	if err != nil {
+		t.Fatalf("RoleCommand() returned error: %v", err)
+	}
+	if want := "codex exec --instructions .codex/agents/auditor.md 'Follow the instructions in .codex/agents/auditor.md.'"; command != want {
+		t.Fatalf("RoleCommand() = %q, want %q", command, want)
+	}
+
+	args, err := runtime.PromptCommand("list the risky areas, don't guess")
+	if err != nil {
+		t.Fatalf("PromptCommand() returned error: %v", err)
+	}
+	want := []string{"sh", "-c", `codex exec --instructions  'list the risky areas, don'"'"'t guess'`}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("PromptCommand() = %#v, want %#v", args, want)
+	}
+
+	if _, err := NewTemplate("bad", config.AgentRuntime{Command: "bad {{.Effort}}"}).RoleCommand("auditor", config.AgentParams{}); err == nil || !strings.Contains(err.Error(), "render bad command") {
+		t.Fatalf("expected a render error for an unknown field, got %v", err)
+	}
+}
+
+func TestRoleCommandsPassAgentParams(t *testing.T) {
+	t.Parallel()
+
+	temperature := 0.4
+	params := config.AgentParams{Model: "anthropic/claude-haiku", Temperature: &temperature, Variant: "high", AgentFlags: []string{"--title", "perf alpha"}}
+
+	tests := []struct {
+		name    string
+		runtime Runtime
+		want    string
+	}{
+		{
+			name:    "opencode",
+			runtime: Opencode{},
+			want:    "opencode run --model 'anthropic/claude-haiku' --variant 'high' '--title' 'perf alpha' auditor",
+		},
+		{
+			name:    "template",
+			runtime: NewTemplate("claude", config.AgentRuntime{Command: "claude -p --model {{.Model}} --temperature={{.Temperature}} {{.Flags}} {{.Prompt}}"}),
+			want:    "claude -p --model 'anthropic/claude-haiku' --temperature=0.4 '--title' 'perf alpha' 'Follow the instructions in .claude/agents/auditor.md.'",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.runtime.RoleCommand("auditor", params)
+			if err != nil {
+				t.Fatalf("RoleCommand() returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("RoleCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got, _ := (Opencode{}).RoleCommand("auditor", config.AgentParams{}); got != "opencode run auditor" {
+		t.Fatalf("expected a bare command without params, got %q", got)
+	}
+}
@@ -0,0 +1,52 @@
+package agent
+
+import (
+	_ "embed"
+	"strings"
+
+	"lattice/internal/config"
+)
+
+//go:embed opencode.jsonc
+var opencodeConfig []byte
+
+// Opencode runs roles with `opencode run`, reading agents from .opencode/agents.
+type Opencode struct{}
+
+// Name returns config.AgentOpencode.
+func (Opencode) Name() string {
+	return config.AgentOpencode
+}
+
+// AgentDir returns .opencode.
+func (Opencode) AgentDir() string {
+	return ".opencode"
+}
+
+// ConfigFile returns opencode.jsonc, which loads the beads plugin.
+func (Opencode) ConfigFile() (string, []byte) {
+	return "opencode.jsonc", opencodeConfig
+}
+
+// RoleCommand runs the named agent with the model, variant and extra flags of
+// params. The temperature goes into the agent definition instead, since
+// opencode run has no flag for it.
+func (Opencode) RoleCommand(agentName string, params config.AgentParams) (string, error) {
+	args := []string{"opencode", "run"}
+	if params.Model != "" {
+		args = append(args, "--model", shellQuote(params.Model))
+	}
+	if params.Variant != "" {
+		args = append(args, "--variant", shellQuote(params.Variant))
+	}
+	if len(params.AgentFlags) > 0 {
+		args = append(args, shellQuoteAll(params.AgentFlags))
+	}
+
+	return strings.Join(append(args, agentName), " "), nil
+}
+
+// PromptCommand passes prompt to `opencode run`.
+func (Opencode) PromptCommand(prompt string) ([]string, error) {
+	return []string{"opencode", "run", prompt}, nil
+}
@@ -0,0 +1,98 @@
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	"lattice/internal/config"
+)
+
+// Template runs an agent through the command template of a runtime defined in
+// the user settings.
+type Template struct {
+	name       string
+	definition config.AgentRuntime
+}
+
+// templateData is rendered into a runtime's command template.
+type templateData struct {
+	// Agent names the agent definition, e.g. auditor; empty for discovery.
+	Agent string
+	// AgentFile is the agent definition path relative to the session.
+	AgentFile string
+	// Prompt is shell-quoted.
+	Prompt string
+	// Model, Variant, Temperature and Flags come from the role's agent params
+	// and are empty when unset. All but Temperature are shell-quoted.
+	Model       string
+	Variant     string
+	Temperature string
+	Flags       string
+}
+
+// NewTemplate returns the runtime called name with definition.
+func NewTemplate(name string, definition config.AgentRuntime) *Template {
+	return &Template{name: name, definition: definition}
+}
+
+// Name returns the runtime name from the user settings.
+func (t *Template) Name() string {
+	return t.name
+}
+
+// AgentDir returns the configured agent_dir, or .<name> when it is unset.
+func (t *Template) AgentDir() string {
+	if dir := strings.TrimSpace(t.definition.AgentDir); dir != "" {
+		return path.Clean(dir)
+	}
+
+	return "." + t.name
+}
+
+// ConfigFile returns the configured config_file and its content.
+func (t *Template) ConfigFile() (string, []byte) {
+	return strings.TrimSpace(t.definition.ConfigFile), []byte(t.definition.Config)
+}
+
+// RoleCommand renders the command for the named agent and params. Agents that
+// cannot load a definition by name can follow the prompt, which points at the
+// definition file.
+func (t *Template) RoleCommand(agentName string, params config.AgentParams) (string, error) {
+	agentFile := path.Join(t.AgentDir(), "agents", agentName+".md")
+	return t.render(templateData{
+		Agent:       agentName,
+		AgentFile:   agentFile,
+		Prompt:      shellQuote(fmt.Sprintf("Follow the instructions in %s.", agentFile)),
+		Model:       shellQuoteSet(params.Model),
+		Variant:     shellQuoteSet(params.Variant),
+		Temperature: params.TemperatureText(),
+		Flags:       shellQuoteAll(params.AgentFlags),
+	})
+}
+
+// PromptCommand renders the command for prompt and runs it with sh.
+func (t *Template) PromptCommand(prompt string) ([]string, error) {
+	command, err := t.render(templateData{Prompt: shellQuote(prompt)})
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{"sh", "-c", command}, nil
+}
+
+func (t *Template) render(data templateData) (string, error) {
+	tmpl, err := template.New(t.name).Option("missingkey=error").Parse(t.definition.Command)
+	if err != nil {
+		return "", fmt.Errorf("parse %s command: %w", t.name, err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("render %s command: %w", t.name, err)
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
@@ -0,0 +1,289 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"lattice/internal/config"
+	"lattice/internal/panelog"
+	"lattice/internal/tmux"
+	"lattice/internal/tui"
+)
+
+func runAudit(args []string, e env) int {
+	fs := newFlagSet("audit", e.stderr)
+	types := fs.String("types", "", "comma-separated audit type ids, e.g. perf,security")
+	agents := fs.Int("agents", 2, "investigators per audit type")
+	rigor := fs.String("rigor", "standard", "rigor level: light, standard or go-hard")
+	target := fs.String("target", "", "project-relative directory to audit (default: whole project)")
+	discover := fs.Bool("discover", false, "discover auditable areas in the target and pass them as focus areas")
+	fanOut := fs.Bool("fan-out", false, "plan one epic per discovered area and audit type (requires --discover)")
+	maxConcurrent := fs.Int("max-concurrent", 0, "maximum running role sessions (default: max_concurrent_roles from settings.toml; -1 for no limit)")
+	dependsOn := fs.String("depends-on", "", "comma-separated type:upstream pairs; a type's epics wait for the upstream type's epics, e.g. errors:maint")
+	maxAttempts := fs.Int("max-attempts", 0, "launches per role before it fails, including the first (default: each audit type's retry policy)")
+	retryBackoff := fs.String("retry-backoff", "", "delay before relaunching a failed role, e.g. 30s or 5m")
+	retryResume := fs.Bool("retry-resume", false, "resume retried roles from the failed attempt's current loop instead of starting fresh")
+	stallTimeout := fs.String("stall-timeout", "", "mark a running role stalled after this long without activity, e.g. 20m (default: stall timeout from settings.toml)")
+	stallAction := fs.String("stall-action", "", "what to do with a stalled role: notify, restart or fail (default: notify)")
+	executorKind := fs.String("executor", "", "how roles run: tmux or process (default: executor from settings.toml, otherwise tmux)")
+	model := fs.String("model", "", "model every role runs with, e.g. anthropic/claude-sonnet-4 (default: each role's model)")
+	temperature := fs.String("temperature", "", "sampling temperature every role runs with, from 0 to 2 (default: each role's temperature)")
+	variant := fs.String("variant", "", "model variant every role runs with, e.g. high (default: each role's variant)")
+	agentFlags := fs.String("agent-flags", "", "space-separated extra flags for every role's agent command, replacing each role's agent_flags")
+	agentRuntime := fs.String("agent", "", "agent runtime every epic runs with: opencode or a name from [agents] in settings.toml (default: each audit type's agent, then agent from settings.toml, otherwise opencode)")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(e.stderr, "unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		return 1
+	}
+	if *maxAttempts == 0 && (*retryBackoff != "" || *retryResume) {
+		fmt.Fprintln(e.stderr, "--retry-backoff and --retry-resume require --max-attempts")
+		return 1
+	}
+	epicDeps, err := parseDependsOn(*dependsOn)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "%v\n", err)
+		return 1
+	}
+	agentParams := config.AgentParams{Model: strings.TrimSpace(*model), Variant: strings.TrimSpace(*variant), AgentFlags: strings.Fields(*agentFlags)}
+	if value := strings.TrimSpace(*temperature); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			fmt.Fprintf(e.stderr, "invalid --temperature %q (want a number from 0 to 2)\n", value)
+			return 1
+		}
+		agentParams.Temperature = &parsed
+	}
+
+	launch, err := e.launchAudit(e.cwd, tui.AuditOptions{
+		AuditTypes:         splitList(*types),
+		AgentCount:         *agents,
+		Rigor:              *rigor,
+		Target:             *target,
+		Discover:           *discover,
+		FanOut:             *fanOut,
+		DependsOn:          epicDeps,
+		MaxConcurrentRoles: *maxConcurrent,
+		Retry:              config.RetryPolicy{MaxAttempts: *maxAttempts, Backoff: *retryBackoff, Resume: *retryResume},
+		Stall:              config.StallPolicy{Timeout: *stallTimeout, Action: *stallAction},
+		Executor:           *executorKind,
+		Agent:              *agentRuntime,
+		AgentParams:        agentParams,
+	})
+	if err != nil {
+		fmt.Fprintf(e.stderr, "launch audit: %v\n", err)
+		return 1
+	}
+
+	if launch.Executor == config.ExecutorProcess {
+		fmt.Fprintf(e.stdout, "Launched run %s as role processes (%d epics, %d roles)\n", launch.RunID, launch.Epics, launch.Roles)
+	} else {
+		fmt.Fprintf(e.stdout, "Launched run %s in tmux session %s (%d epics, %d roles)\n", launch.RunID, launch.SessionName, launch.Epics, launch.Roles)
+	}
+	fmt.Fprintln(e.stdout, `Run "lattice run --headless" or open the TUI to advance roles.`)
+	return 0
+}
+
+func runStatus(args []string, e env) int {
+	fs := newFlagSet("status", e.stderr)
+	asJSON := fs.Bool("json", false, "print the status as a JSON document")
+	watch := fs.Bool("watch", false, "with --json, print one JSON document per line until every role finishes")
+	interval := fs.Duration("interval", 3*time.Second, "time between --watch snapshots")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if *watch && !*asJSON {
+		fmt.Fprintln(e.stderr, "--watch requires --json")
+		return 1
+	}
+	if *interval <= 0 {
+		fmt.Fprintln(e.stderr, "--interval must be positive")
+		return 1
+	}
+
+	if *watch {
+		return watchStatus(e, *interval)
+	}
+
+	status, err := e.loadStatus(e.cwd)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "status: %v\n", err)
+		return 1
+	}
+	if *asJSON {
+		return writeStatusJSON(e, status)
+	}
+
+	return writeStatusText(e, status)
+}
+
+// watchStatus streams NDJSON snapshots until all roles are terminal or the context ends.
+func watchStatus(e env, interval time.Duration) int {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := e.loadStatus(e.cwd)
+		if err != nil {
+			fmt.Fprintf(e.stderr, "status: %v\n", err)
+			return 1
+		}
+		if code := writeStatusJSON(e, status); code != 0 {
+			return code
+		}
+		if status.AllDone {
+			return 0
+		}
+
+		select {
+		case <-e.ctx.Done():
+			return 0
+		case <-ticker.C:
+		}
+	}
+}
+
+func writeStatusJSON(e env, status tui.Status) int {
+	if err := json.NewEncoder(e.stdout).Encode(status); err != nil {
+		fmt.Fprintf(e.stderr, "write status: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func writeStatusText(e env, status tui.Status) int {
+	if len(status.Epics) == 0 {
+		fmt.Fprintln(e.stdout, "No audit epics found; launch an audit first.")
+		return 0
+	}
+
+	fmt.Fprintf(e.stdout, "Run:     %s\n", status.RunID)
+	fmt.Fprintf(e.stdout, "Session: %s\n", status.SessionName)
+
+	w := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', 0)
+	for _, epic := range status.Epics {
+		fmt.Fprintf(w, "\n%s (%s)\t%s\t%d/%d done\t%s\t\n", epic.AuditName, epic.BeadID, epic.Status, epic.RolesComplete, epic.RolesTotal, epic.Target)
+		for _, role := range epic.Roles {
+			roleStatus := role.Status
+			switch {
+			case role.Paused && role.Status == "pending":
+				roleStatus = "paused"
+			case role.QueuePosition > 0:
+				roleStatus = fmt.Sprintf("queued #%d", role.QueuePosition)
+			case role.RetryAt != "":
+				roleStatus = fmt.Sprintf("retry #%d at %s", role.Attempt+1, role.RetryAt)
+			}
+			fmt.Fprintf(w, "  %s\t%s\tloop %d/%d\t%s\t\n", role.CodeName, roleStatus, role.CurrentLoop, role.Intensity, role.Title)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(e.stderr, "write status: %v\n", err)
+		return 1
+	}
+	if status.AllDone {
+		fmt.Fprintln(e.stdout, "\nAll roles reached a terminal state.")
+	}
+
+	return 0
+}
+
+func runAttach(args []string, e env) int {
+	session, ok := activeSession("attach", args, e)
+	if !ok {
+		return 1
+	}
+
+	if err := e.attachSession(session.Name, session.TmuxSocket); err != nil {
+		fmt.Fprintf(e.stderr, "attach: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func runStop(args []string, e env) int {
+	fs := newFlagSet("stop", e.stderr)
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	result, err := e.stopRun(e.cwd)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "stop: %v\n", err)
+		return 1
+	}
+	if result.SessionErr != nil {
+		fmt.Fprintf(e.stderr, "warning: %v\n", result.SessionErr)
+	}
+
+	fmt.Fprintf(e.stdout, "Stopped session %s; marked %d unfinished role(s) cancelled.\n", result.SessionName, len(result.Stopped))
+	return 0
+}
+
+func activeSession(name string, args []string, e env) (config.SessionMetadata, bool) {
+	fs := newFlagSet(name, e.stderr)
+	if err := fs.Parse(args); err != nil {
+		return config.SessionMetadata{}, false
+	}
+
+	cfg, err := config.Load(e.cwd)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "%s: load config: %v\n", name, err)
+		return config.SessionMetadata{}, false
+	}
+	if strings.TrimSpace(cfg.Session.Name) == "" {
+		fmt.Fprintf(e.stderr, "%s: no active tmux session found\n", name)
+		return config.SessionMetadata{}, false
+	}
+	if cfg.Session.Executor == config.ExecutorProcess {
+		fmt.Fprintf(e.stderr, "%s: run %s has no tmux session; its roles run as processes logging to %s\n", name, cfg.Session.RunID, panelog.Dir(e.cwd, cfg.Session.RunID))
+		return config.SessionMetadata{}, false
+	}
+
+	return cfg.Session, true
+}
+
+func attachTmuxSession(name, tmuxSocket string) error {
+	manager, err := tmux.NewManager(tmux.ParseSocket(tmuxSocket))
+	if err != nil {
+		return err
+	}
+
+	return manager.AttachSession(name)
+}
+
+// parseDependsOn reads "type:upstream" pairs into a map from audit type ID to upstream IDs.
+func parseDependsOn(value string) (map[string][]string, error) {
+	var deps map[string][]string
+	for _, pair := range splitList(value) {
+		auditType, upstream, ok := strings.Cut(pair, ":")
+		auditType, upstream = strings.TrimSpace(auditType), strings.TrimSpace(upstream)
+		if !ok || auditType == "" || upstream == "" {
+			return nil, fmt.Errorf("invalid --depends-on entry %q (want type:upstream)", pair)
+		}
+		if deps == nil {
+			deps = map[string][]string{}
+		}
+		deps[auditType] = append(deps[auditType], upstream)
+	}
+
+	return deps, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
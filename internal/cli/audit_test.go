@@ -0,0 +1,311 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"lattice/internal/config"
+	"lattice/internal/tui"
+)
+
+func TestAuditPassesFlagsToLauncher(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	var gotCwd string
+	var gotOpts tui.AuditOptions
+	e.launchAudit = func(cwd string, opts tui.AuditOptions) (tui.AuditLaunch, error) {
+		gotCwd = cwd
+		gotOpts = opts
+		return tui.AuditLaunch{RunID: "20260102-090000", SessionName: "lattice-20260102-090000", Epics: 2, Roles: 4}, nil
+	}
+
+	temperature := 0.3
+	code := run([]string{"audit", "--types", "perf, security", "--agents", "2", "--rigor", "standard", "--target", "./internal", "--discover", "--max-concurrent", "3", "--executor", "process", "--agent", "claude", "--model", "anthropic/claude-haiku", "--temperature", "0.3", "--agent-flags", "--max-turns 40"}, e)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr.String())
+	}
+
+	want := tui.AuditOptions{AuditTypes: []string{"perf", "security"}, AgentCount: 2, Rigor: "standard", Target: "./internal", Discover: true, MaxConcurrentRoles: 3, Executor: "process", Agent: "claude", AgentParams: config.AgentParams{Model: "anthropic/claude-haiku", Temperature: &temperature, AgentFlags: []string{"--max-turns", "40"}}}
+	if gotCwd != "/tmp/project" || !reflect.DeepEqual(gotOpts, want) {
+		t.Fatalf("unexpected launch call: cwd=%q opts=%+v", gotCwd, gotOpts)
+	}
+	if !strings.Contains(stdout.String(), "Launched run 20260102-090000 in tmux session lattice-20260102-090000 (2 epics, 4 roles)") {
+		t.Fatalf("unexpected stdout: %q", stdout.String())
+	}
+}
+
+func TestAuditParsesEpicDependencies(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	var gotOpts tui.AuditOptions
+	e.launchAudit = func(_ string, opts tui.AuditOptions) (tui.AuditLaunch, error) {
+		gotOpts = opts
+		return tui.AuditLaunch{}, nil
+	}
+
+	if code := run([]string{"audit", "--types", "perf,security,maint", "--depends-on", "security:perf, maint:perf,maint:security"}, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr.String())
+	}
+	want := map[string][]string{"security": {"perf"}, "maint": {"perf", "security"}}
+	if !reflect.DeepEqual(gotOpts.DependsOn, want) {
+		t.Fatalf("unexpected dependencies: %#v", gotOpts.DependsOn)
+	}
+
+	stderr.Reset()
+	if code := run([]string{"audit", "--types", "perf", "--depends-on", "perf"}, e); code != 1 {
+		t.Fatalf("expected exit code 1 for a malformed entry, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), `invalid --depends-on entry "perf"`) {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestAuditRejectsNonNumericTemperature(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	if code := run([]string{"audit", "--types", "perf", "--temperature", "warm"}, e); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), `invalid --temperature "warm"`) {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestAuditPassesRetryAndStallPolicies(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	var gotOpts tui.AuditOptions
+	e.launchAudit = func(_ string, opts tui.AuditOptions) (tui.AuditLaunch, error) {
+		gotOpts = opts
+		return tui.AuditLaunch{}, nil
+	}
+
+	if code := run([]string{"audit", "--types", "perf", "--max-attempts", "3", "--retry-backoff", "5m", "--retry-resume", "--stall-timeout", "20m", "--stall-action", "restart"}, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr.String())
+	}
+	want := config.RetryPolicy{MaxAttempts: 3, Backoff: "5m", Resume: true}
+	if gotOpts.Retry != want {
+		t.Fatalf("unexpected retry policy: %+v", gotOpts.Retry)
+	}
+	if wantStall := (config.StallPolicy{Timeout: "20m", Action: "restart"}); gotOpts.Stall != wantStall {
+		t.Fatalf("unexpected stall policy: %+v", gotOpts.Stall)
+	}
+
+	if code := run([]string{"audit", "--types", "perf", "--retry-backoff", "5m"}, e); code != 1 {
+		t.Fatalf("expected exit code 1 for a backoff without attempts, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "require --max-attempts") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestAuditReportsLaunchErrors(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.launchAudit = func(string, tui.AuditOptions) (tui.AuditLaunch, error) {
+		return tui.AuditLaunch{}, errors.New("agent count must be at least 1")
+	}
+
+	if code := run([]string{"audit", "--types", "perf", "--agents", "9"}, e); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "launch audit: agent count must be at least 1") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestStatusPrintsEpicsAndRoles(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.loadStatus = func(string) (tui.Status, error) {
+		return tui.Status{
+			RunID:       "20260102-090000",
+			SessionName: "lattice-20260102-090000",
+			Epics: []tui.EpicStatus{{
+				BeadID: "audit-plan-001", AuditName: "Performance Audit", Status: "running", RolesTotal: 2, RolesComplete: 1,
+				Roles: []tui.RoleStatus{{CodeName: "alpha", Status: "complete", CurrentLoop: 3, Intensity: 3}},
+			}},
+		}, nil
+	}
+
+	if code := run([]string{"status"}, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr.String())
+	}
+	for _, fragment := range []string{"Run:     20260102-090000", "Performance Audit (audit-plan-001)", "1/2 done", "loop 3/3"} {
+		if !strings.Contains(stdout.String(), fragment) {
+			t.Fatalf("expected status output to include %q, got %q", fragment, stdout.String())
+		}
+	}
+}
+
+func TestAttachUsesActiveSession(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.Name = "lattice-20260102-090000"
+	cfg.Session.TmuxSocket = "lattice"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.cwd = workDir
+	var attached, socket string
+	e.attachSession = func(name, tmuxSocket string) error {
+		attached, socket = name, tmuxSocket
+		return nil
+	}
+
+	if code := run([]string{"attach"}, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr.String())
+	}
+	if attached != "lattice-20260102-090000" || socket != "lattice" {
+		t.Fatalf("unexpected attached session: %q on socket %q", attached, socket)
+	}
+}
+
+func TestAttachRejectsProcessRuns(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.Name = "lattice-20260102-090000"
+	cfg.Session.RunID = "20260102-090000"
+	cfg.Session.Executor = config.ExecutorProcess
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.cwd = workDir
+	e.attachSession = func(name, _ string) error {
+		t.Fatalf("unexpected attach to %q", name)
+		return nil
+	}
+
+	if code := run([]string{"attach"}, e); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "its roles run as processes") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestStopWarnsWhenSessionAlreadyGone(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.stopRun = func(string) (tui.StopResult, error) {
+		return tui.StopResult{SessionName: "lattice-x", SessionErr: errors.New("session not found"), Stopped: []string{"r1", "r2"}}, nil
+	}
+
+	if code := run([]string{"stop"}, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "warning: session not found") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "marked 2 unfinished role(s) cancelled") {
+		t.Fatalf("unexpected stdout: %q", stdout.String())
+	}
+}
+
+func TestStatusJSONPrintsStableDocument(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.loadStatus = func(string) (tui.Status, error) {
+		return tui.Status{
+			Version:     tui.StatusVersion,
+			RunID:       "20260102-090000",
+			SessionName: "lattice-20260102-090000",
+			Epics: []tui.EpicStatus{{
+				BeadID: "audit-plan-001", Status: "running",
+				Roles: []tui.RoleStatus{{BeadID: "audit-plan-002", Status: "running", CurrentLoop: 1, Intensity: 3, BeadPrefix: "perf-alpha", TmuxWindow: "lattice-20260102-090000:audit-plan-001-alpha"}},
+			}},
+		}, nil
+	}
+
+	if code := run([]string{"status", "--json"}, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr.String())
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &doc); err != nil {
+		t.Fatalf("status output is not JSON: %v (%q)", err, stdout.String())
+	}
+	if doc["version"] != float64(1) || doc["run_id"] != "20260102-090000" || doc["session_name"] != "lattice-20260102-090000" {
+		t.Fatalf("unexpected document: %v", doc)
+	}
+	role := doc["epics"].([]any)[0].(map[string]any)["roles"].([]any)[0].(map[string]any)
+	for field, want := range map[string]any{"current_loop": float64(1), "intensity": float64(3), "bead_prefix": "perf-alpha", "tmux_window": "lattice-20260102-090000:audit-plan-001-alpha"} {
+		if role[field] != want {
+			t.Fatalf("role %s = %v, want %v", field, role[field], want)
+		}
+	}
+}
+
+func TestStatusWatchStreamsNDJSONUntilAllDone(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	calls := 0
+	e.loadStatus = func(string) (tui.Status, error) {
+		calls++
+		return tui.Status{RunID: "r", AllDone: calls == 3}, nil
+	}
+
+	if code := run([]string{"status", "--json", "--watch", "--interval", "1ms"}, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 NDJSON lines, got %d: %q", len(lines), stdout.String())
+	}
+	for _, line := range lines {
+		if !json.Valid([]byte(line)) {
+			t.Fatalf("invalid NDJSON line: %q", line)
+		}
+	}
+}
+
+func TestStatusWatchRequiresJSON(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"status", "--watch"}, testEnv(&stdout, &stderr)); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "--watch requires --json") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
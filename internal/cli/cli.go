@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"lattice/internal/tmux"
+	"lattice/internal/tui"
+)
+
+const usageText = `Usage:
+  lattice                         Start the interactive TUI
+  lattice audit [flags]           Launch an audit without the TUI
+  lattice status [flags]          Show epic and role progress for the active run
+  lattice attach                  Attach to the active run's tmux session
+  lattice stop                    Stop the active run's roles and cancel unfinished ones
+  lattice sessions                List lattice tmux sessions from every project
+  lattice run --headless [flags]  Advance roles without the TUI
+  lattice daemon [flags]          Alias for "run --headless"
+  lattice runs list               List recorded runs, newest first
+  lattice runs show <id>          Show epics, roles and reports for one run
+  lattice report [flags]          Write a consolidated Markdown and HTML report
+
+Audit flags:
+  --types ids     Comma-separated audit type ids (required), e.g. perf,security
+  --agents n      Investigators per audit type (default 2)
+  --rigor level   light, standard or go-hard (default standard)
+  --target dir    Project-relative directory to audit (default: whole project)
+  --discover      Discover auditable areas in the target and use them as focus areas
+  --fan-out       With --discover, plan one epic per area and audit type
+  --depends-on    Comma-separated type:upstream pairs, e.g. errors:maint; a type's
+                  epics start only after the upstream type's epics complete
+  --max-concurrent n
+                  Maximum running role sessions (default: max_concurrent_roles in
+                  the user settings.toml, otherwise no limit; -1 for no limit)
+  --max-attempts n
+                  Launches per role before it fails, including the first (default:
+                  the audit type's [retry] policy, otherwise 1)
+  --retry-backoff duration
+                  With --max-attempts, wait this long before relaunching, e.g. 5m
+  --retry-resume  With --max-attempts, continue from the failed attempt's loop
+  --stall-timeout duration
+                  Mark a running role stalled when its heartbeat, .team file and
+                  pane output have not changed for this long (default: [stall]
+                  timeout in the user settings.toml, otherwise off)
+  --stall-action action
+                  notify, restart or fail (default: [stall] action, otherwise notify);
+                  restart fails the role once it has used its max attempts, or 3
+                  launches without a retry policy
+  --executor name
+                  tmux or process (default: executor in the user settings.toml,
+                  otherwise tmux); see Executors
+  --agent name    Agent runtime for every epic: opencode or a runtime from the
+                  user settings.toml (default: the audit type's agent, then agent
+                  in the user settings.toml, otherwise opencode); see Agent runtimes
+  --model name    Model for every role, e.g. anthropic/claude-sonnet-4 (default:
+                  the role's model, otherwise the agent's)
+  --temperature t Sampling temperature for every role, from 0 to 2
+  --variant name  Model variant for every role, e.g. high
+  --agent-flags flags
+                  Space-separated extra flags for every role's agent command,
+                  replacing the role's agent_flags
+
+Status flags:
+  --json                Print a JSON document (session, epics, roles, loops, windows, timestamps)
+  --watch               With --json, print one document per line until every role finishes
+  --interval duration   Time between --watch snapshots (default 3s)
+
+Headless flags:
+  --interval duration   Time between scheduler passes (default 3s)
+  --log-file path       Append transition logs to this file as well as stdout
+
+Report flags:
+  --run id     Report on an archived run instead of the active run
+  --out dir    Write report.md and report.html here instead of the run directory
+
+Hooks:
+  Commands in the [hooks] table of the user settings.toml run through sh in the
+  project directory when the scheduler reports a transition:
+    on_role_launch, on_role_complete, on_role_fail   a role launched, completed or failed
+    on_epic_complete                                 every role of an epic finished,
+                                                     whether the epic completed, failed
+                                                     or was cancelled (LATTICE_EPIC_STATUS)
+    on_run_complete                                  every role of the run finished
+  Each event takes a list of commands, e.g. on_role_fail = ["./notify.sh"].
+  Hooks read LATTICE_* variables (LATTICE_EVENT, LATTICE_RUN_ID, LATTICE_EPIC_ID,
+  LATTICE_ROLE_ID, LATTICE_ROLE_STATUS, ...) and the same data as JSON on stdin.
+  Each command stops after timeout (default 30s); output goes to .lattice/logs/hooks.log.
+
+Journal:
+  Launches, scheduler transitions, dashboard actions, attaches, stops, hook results
+  and errors are appended to .lattice/events.jsonl, one JSON object per line. The
+  dashboard's Activity pane (v to toggle) shows the latest events of the run.
+
+Transcripts:
+  Each role's output streams to .lattice/logs/<run>/<window>.raw.log. An
+  ANSI-stripped copy, <window>.log, is written when the role finishes, when the
+  run is stopped and when a report is generated; reports link to it.
+
+Executors:
+  tmux     Each role runs in a window of the run's tmux session (lattice attach),
+           named lattice-<project>-<run>. Set tmux_socket in the user
+           settings.toml to keep sessions on a dedicated server: a name as with
+           tmux -L, e.g. "lattice", or a socket path as with tmux -S.
+  process  Each role runs as a subprocess in its own process group, with stdout
+           and stderr appended to its raw log, for hosts without tmux such as
+           containers and CI runners. The role's PID is kept in config.toml so
+           "lattice run --headless" and "lattice stop" find it again.
+
+Agent runtimes:
+  Roles and discovery run opencode unless a runtime is picked with --agent, the
+  agent key of an audit type file or agent in the user settings.toml. Other CLI
+  agents are defined under [agents.<name>] in the user settings.toml:
+    command      shell command template; {{.Agent}} is the agent name (auditor),
+                 {{.AgentFile}} its definition file and {{.Prompt}} a quoted
+                 prompt, e.g. "claude -p --agent {{.Agent}} {{.Prompt}}";
+                 {{.Model}}, {{.Variant}}, {{.Temperature}} and {{.Flags}} carry
+                 the role's agent parameters and are empty when unset
+    agent_dir    where agent definitions and skills go (default .<name>)
+    config_file  a file written into each role session, with config as content
+  The run records its runtimes in config.toml, so roles launched later use the
+  same commands.
+  Roles in an audit type file may set model, temperature, variant and
+  agent_flags. The model and temperature go into the frontmatter of the
+  session's agent definitions; opencode also gets --model, --variant and the
+  flags on its command line. --model, --temperature, --variant and --agent-flags
+  override them for a run; the wizard's confirm step cycles through the models
+  listed in models in the user settings.toml.
+
+Exit codes:
+  0  all roles completed
+  1  lattice could not run (invalid flags, missing config, scheduler error)
+  2  the run finished with failed or blocked roles
+`
+
+type runHeadlessFunc func(ctx context.Context, cwd string, opts tui.HeadlessOptions) (tui.HeadlessResult, error)
+
+type env struct {
+	ctx         context.Context
+	cwd         string
+	stdout      io.Writer
+	stderr      io.Writer
+	runTUI      func(cwd string) error
+	runHeadless runHeadlessFunc
+
+	launchAudit   func(cwd string, opts tui.AuditOptions) (tui.AuditLaunch, error)
+	loadStatus    func(cwd string) (tui.Status, error)
+	attachSession func(name, tmuxSocket string) error
+	listSessions  func() ([]tmux.SessionInfo, error)
+	stopRun       func(cwd string) (tui.StopResult, error)
+}
+
+// Run dispatches lattice subcommands and returns the process exit code.
+func Run(ctx context.Context, args []string, cwd string, stdout, stderr io.Writer) int {
+	return run(args, env{
+		ctx:         ctx,
+		cwd:         cwd,
+		stdout:      stdout,
+		stderr:      stderr,
+		runTUI:      runTUI,
+		runHeadless: tui.RunHeadless,
+
+		launchAudit:   tui.LaunchAudit,
+		loadStatus:    tui.LoadStatus,
+		attachSession: attachTmuxSession,
+		listSessions:  listTmuxSessions,
+		stopRun:       tui.StopRun,
+	})
+}
+
+func run(args []string, e env) int {
+	if len(args) == 0 {
+		if err := e.runTUI(e.cwd); err != nil {
+			fmt.Fprintf(e.stderr, "error running app: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	switch args[0] {
+	case "audit":
+		return runAudit(args[1:], e)
+	case "status":
+		return runStatus(args[1:], e)
+	case "attach":
+		return runAttach(args[1:], e)
+	case "stop":
+		return runStop(args[1:], e)
+	case "run":
+		return runScheduler(args[1:], e, false)
+	case "daemon":
+		return runScheduler(args[1:], e, true)
+	case "sessions":
+		return runSessions(args[1:], e)
+	case "runs":
+		return runRuns(args[1:], e)
+	case "report":
+		return runReport(args[1:], e)
+	case "help", "-h", "--help":
+		fmt.Fprint(e.stdout, usageText)
+		return 0
+	default:
+		fmt.Fprintf(e.stderr, "unknown command %q\n\n%s", args[0], usageText)
+		return 1
+	}
+}
+
+func runScheduler(args []string, e env, headless bool) int {
+	fs := newFlagSet("run", e.stderr)
+	fs.BoolVar(&headless, "headless", headless, "advance roles without the TUI")
+	interval := fs.Duration("interval", 3*time.Second, "time between scheduler passes")
+	logFile := fs.String("log-file", "", "append transition logs to this file")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if !headless {
+		fmt.Fprintln(e.stderr, "lattice run requires --headless; run lattice without arguments for the TUI")
+		return 1
+	}
+	if *interval <= 0 {
+		fmt.Fprintln(e.stderr, "--interval must be positive")
+		return 1
+	}
+
+	logOut := e.stdout
+	if strings.TrimSpace(*logFile) != "" {
+		file, err := os.OpenFile(*logFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			fmt.Fprintf(e.stderr, "open log file: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		logOut = io.MultiWriter(e.stdout, file)
+	}
+
+	result, err := e.runHeadless(e.ctx, e.cwd, tui.HeadlessOptions{Interval: *interval, Log: logOut})
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return 1
+		}
+		fmt.Fprintf(e.stderr, "headless scheduler: %v\n", err)
+		return 1
+	}
+
+	return result.ExitCode()
+}
+
+func newFlagSet(name string, output io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(output)
+	return fs
+}
+
+func runTUI(cwd string) error {
+	p := tea.NewProgram(tui.NewApp(cwd), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"lattice/internal/tui"
+)
+
+func testEnv(stdout, stderr *bytes.Buffer) env {
+	return env{
+		ctx:    context.Background(),
+		cwd:    "/tmp/project",
+		stdout: stdout,
+		stderr: stderr,
+		runTUI: func(string) error { return nil },
+		runHeadless: func(context.Context, string, tui.HeadlessOptions) (tui.HeadlessResult, error) {
+			return tui.HeadlessResult{AllDone: true}, nil
+		},
+	}
+}
+
+func TestRunWithoutArgsStartsTUI(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	var started string
+	e.runTUI = func(cwd string) error {
+		started = cwd
+		return nil
+	}
+
+	if code := run(nil, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if started != "/tmp/project" {
+		t.Fatalf("expected TUI to start in cwd, got %q", started)
+	}
+}
+
+func TestRunHeadlessPassesOptionsAndMapsExitCode(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	var gotInterval time.Duration
+	e.runHeadless = func(_ context.Context, cwd string, opts tui.HeadlessOptions) (tui.HeadlessResult, error) {
+		gotInterval = opts.Interval
+		return tui.HeadlessResult{AllDone: true, Failed: []string{"r1"}}, nil
+	}
+
+	if code := run([]string{"run", "--headless", "--interval", "10s"}, e); code != 2 {
+		t.Fatalf("expected exit code 2 for failed roles, got %d", code)
+	}
+	if gotInterval != 10*time.Second {
+		t.Fatalf("expected interval 10s, got %v", gotInterval)
+	}
+
+	if code := run([]string{"daemon"}, e); code != 2 {
+		t.Fatalf("expected daemon alias to run headless, got %d", code)
+	}
+}
+
+func TestRunRequiresHeadlessFlag(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"run"}, testEnv(&stdout, &stderr)); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "requires --headless") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestRunReportsSchedulerErrors(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.runHeadless = func(context.Context, string, tui.HeadlessOptions) (tui.HeadlessResult, error) {
+		return tui.HeadlessResult{}, errors.New("boom")
+	}
+
+	if code := run([]string{"run", "--headless"}, e); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "boom") {
+		t.Fatalf("expected scheduler error on stderr, got %q", stderr.String())
+	}
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"bogus"}, testEnv(&stdout, &stderr)); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), `unknown command "bogus"`) {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"lattice/internal/config"
+	"lattice/internal/report"
+)
+
+func runReport(args []string, e env) int {
+	fs := newFlagSet("report", e.stderr)
+	runID := fs.String("run", "", "report on this run id instead of the active run")
+	outDir := fs.String("out", "", "write the report into this directory instead of the run directory")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(e.stderr, "unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		return 1
+	}
+
+	cfg, err := loadReportConfig(e.cwd, strings.TrimSpace(*runID))
+	if err != nil {
+		fmt.Fprintf(e.stderr, "load config: %v\n", err)
+		return 1
+	}
+
+	now := time.Now()
+	var paths report.Paths
+	if strings.TrimSpace(*outDir) != "" {
+		doc, collectErr := report.Collect(e.cwd, cfg, now)
+		if collectErr != nil {
+			fmt.Fprintf(e.stderr, "collect report: %v\n", collectErr)
+			return 1
+		}
+		paths, err = report.WriteTo(*outDir, doc)
+	} else {
+		paths, err = report.Write(e.cwd, cfg, now)
+	}
+	if err != nil {
+		fmt.Fprintf(e.stderr, "write report: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(e.stdout, "Markdown: %s\n", paths.Markdown)
+	fmt.Fprintf(e.stdout, "HTML:     %s\n", paths.HTML)
+	return 0
+}
+
+func loadReportConfig(cwd, runID string) (*config.Config, error) {
+	if runID == "" {
+		return config.Load(cwd)
+	}
+
+	cfg, err := config.LoadRun(cwd, runID)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("run %q not found", runID)
+		}
+		return nil, err
+	}
+	if strings.TrimSpace(cfg.Session.RunID) == "" {
+		cfg.Session.RunID = runID
+	}
+
+	return cfg, nil
+}
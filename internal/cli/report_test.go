@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lattice/internal/config"
+	"lattice/internal/report"
+)
+
+func TestReportWritesIntoRunDirectory(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.RunID = "20260102-090000"
+	cfg.Epics["audit-plan-001"] = config.EpicState{BeadID: "audit-plan-001", AuditType: "perf", AuditName: "Performance", Status: "complete"}
+	cfg.Roles["audit-plan-002"] = config.RoleState{EpicBeadID: "audit-plan-001", CodeName: "alpha", Status: "complete"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.cwd = workDir
+
+	if code := run([]string{"report"}, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr.String())
+	}
+	markdownPath := filepath.Join(config.RunDir(workDir, cfg.Session.RunID), report.MarkdownFileName)
+	if !strings.Contains(stdout.String(), markdownPath) {
+		t.Fatalf("expected output to name %q, got %q", markdownPath, stdout.String())
+	}
+	content, err := os.ReadFile(markdownPath)
+	if err != nil {
+		t.Fatalf("ReadFile() returned error: %v", err)
+	}
+	if !strings.Contains(string(content), "Performance (audit-plan-001)") {
+		t.Fatalf("unexpected report content: %q", content)
+	}
+
+	outDir := filepath.Join(workDir, "out")
+	stdout.Reset()
+	if code := run([]string{"report", "--run", "20260102-090000", "--out", outDir}, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(outDir, report.HTMLFileName)); err != nil {
+		t.Fatalf("expected html report in --out directory: %v", err)
+	}
+}
+
+func TestReportUnknownRunFails(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.cwd = t.TempDir()
+
+	if code := run([]string{"report", "--run", "missing"}, e); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), `run "missing" not found`) {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
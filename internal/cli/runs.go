@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"lattice/internal/runs"
+)
+
+const runsUsageText = `Usage:
+  lattice runs list        List recorded runs, newest first
+  lattice runs show <id>   Show epics, roles and reports for one run
+`
+
+func runRuns(args []string, e env) int {
+	if len(args) == 0 {
+		fmt.Fprint(e.stderr, runsUsageText)
+		return 1
+	}
+
+	switch args[0] {
+	case "list":
+		return listRuns(e)
+	case "show":
+		if len(args) != 2 {
+			fmt.Fprint(e.stderr, runsUsageText)
+			return 1
+		}
+		return showRun(args[1], e)
+	default:
+		fmt.Fprintf(e.stderr, "unknown runs command %q\n\n%s", args[0], runsUsageText)
+		return 1
+	}
+}
+
+func listRuns(e env) int {
+	summaries, err := runs.List(e.cwd)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "list runs: %v\n", err)
+		return 1
+	}
+	if len(summaries) == 0 {
+		fmt.Fprintln(e.stdout, "No runs recorded yet.")
+		return 0
+	}
+
+	w := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RUN\tSTATUS\tEPICS\tROLES\tFAILED\tCREATED\t")
+	for _, summary := range summaries {
+		id := summary.ID
+		if summary.Active {
+			id += " *"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d/%d\t%d\t%s\t\n", id, summary.Status, summary.Epics, summary.Complete, summary.Roles, summary.Failed, summary.CreatedAt)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(e.stderr, "write runs: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func showRun(runID string, e env) int {
+	detail, err := runs.Show(e.cwd, runID)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "show run: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(e.stdout, "Run:     %s\n", detail.ID)
+	fmt.Fprintf(e.stdout, "Status:  %s\n", detail.Status)
+	fmt.Fprintf(e.stdout, "Session: %s\n", detail.SessionName)
+	fmt.Fprintf(e.stdout, "Created: %s\n", detail.CreatedAt)
+
+	w := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', 0)
+	for _, epic := range detail.EpicDetails {
+		fmt.Fprintf(w, "\n%s (%s)\t%s\t\n", epic.AuditName, epic.BeadID, epic.Status)
+		for _, role := range epic.Roles {
+			report := role.ReportPath
+			if report == "" {
+				report = "-"
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t\n", role.CodeName, role.Status, report)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(e.stderr, "write run: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
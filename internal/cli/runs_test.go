@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"lattice/internal/config"
+)
+
+func TestRunsListAndShow(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.RunID = "20260102-090000"
+	cfg.Session.Name = "lattice-20260102-090000"
+	cfg.Epics["perf"] = config.EpicState{BeadID: "audit-plan-001", AuditType: "perf", AuditName: "Performance", Status: "running"}
+	cfg.Roles["audit-plan-002"] = config.RoleState{EpicBeadID: "audit-plan-001", CodeName: "alpha", Status: "complete"}
+	cfg.Roles["audit-plan-003"] = config.RoleState{EpicBeadID: "audit-plan-001", CodeName: "bravo", Status: "running", Order: 2}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.cwd = workDir
+
+	if code := run([]string{"runs", "list"}, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "20260102-090000 *") || !strings.Contains(stdout.String(), "1/2") {
+		t.Fatalf("unexpected runs list output: %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"runs", "show", "20260102-090000"}, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr.String())
+	}
+	for _, fragment := range []string{"Run:     20260102-090000", "Performance (audit-plan-001)", "alpha", "bravo"} {
+		if !strings.Contains(stdout.String(), fragment) {
+			t.Fatalf("expected show output to include %q, got %q", fragment, stdout.String())
+		}
+	}
+}
+
+func TestRunsShowUnknownRunFails(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.cwd = t.TempDir()
+
+	if code := run([]string{"runs", "show", "missing"}, e); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), `run "missing" not found`) {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"lattice/internal/config"
+	"lattice/internal/tmux"
+)
+
+func runSessions(args []string, e env) int {
+	fs := newFlagSet("sessions", e.stderr)
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	sessions, err := e.listSessions()
+	if err != nil {
+		fmt.Fprintf(e.stderr, "sessions: %v\n", err)
+		return 1
+	}
+	if len(sessions) == 0 {
+		fmt.Fprintln(e.stdout, "No lattice tmux sessions running.")
+		return 0
+	}
+
+	w := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SESSION\tWINDOWS\tATTACHED\tDIRECTORY\t")
+	for _, session := range sessions {
+		attached := "no"
+		if session.Attached {
+			attached = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t\n", session.Name, session.Windows, attached, session.WorkingDir)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(e.stderr, "write sessions: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// listTmuxSessions lists the lattice sessions on the tmux server from the user
+// settings.
+func listTmuxSessions() ([]tmux.SessionInfo, error) {
+	settings, err := config.LoadUserSettings()
+	if err != nil {
+		return nil, fmt.Errorf("load user settings: %w", err)
+	}
+
+	manager, err := tmux.NewManager(tmux.ParseSocket(settings.TmuxSocket))
+	if err != nil {
+		return nil, err
+	}
+
+	return manager.ListSessions()
+}
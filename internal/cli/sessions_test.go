@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"lattice/internal/tmux"
+)
+
+func TestSessionsListsLatticeSessions(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.listSessions = func() ([]tmux.SessionInfo, error) {
+		return []tmux.SessionInfo{
+			{Name: "lattice-api-20260102-090000", WorkingDir: "/src/api", Windows: 3, Attached: true},
+			{Name: "lattice-web-20260103-100000", WorkingDir: "/src/web", Windows: 2},
+		}, nil
+	}
+
+	if code := run([]string{"sessions"}, e); code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[1], "lattice-api-20260102-090000") || !strings.Contains(lines[1], "yes") || !strings.Contains(lines[2], "/src/web") {
+		t.Fatalf("unexpected sessions output: %q", stdout.String())
+	}
+}
+
+func TestSessionsReportsListErrors(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	e := testEnv(&stdout, &stderr)
+	e.listSessions = func() ([]tmux.SessionInfo, error) {
+		return nil, errors.New("tmux is not available")
+	}
+
+	if code := run([]string{"sessions"}, e); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "sessions: tmux is not available") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
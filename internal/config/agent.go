@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// AgentOpencode names the built-in opencode runtime, the default for role sessions.
+const AgentOpencode = "opencode"
+
+// AgentRuntime defines a CLI coding agent that role sessions and discovery run
+// through a command template.
+type AgentRuntime struct {
+	// Command is a text/template for the shell command that runs the agent
+	// non-interactively. It may use {{.Agent}}, the agent name such as auditor,
+	// {{.AgentFile}}, the agent definition path, and {{.Prompt}}, the prompt,
+	// already shell-quoted. Role commands also get the role's {{.Model}},
+	// {{.Variant}}, {{.Temperature}} and {{.Flags}}, each empty when unset and
+	// all but the temperature shell-quoted. Discovery leaves everything but
+	// Prompt empty.
+	Command string `toml:"command"`
+	// AgentDir replaces .opencode in generated sessions and defaults to
+	// .<name>, e.g. .claude. It holds agent definitions under agents/ and
+	// skills under skills/.
+	AgentDir string `toml:"agent_dir,omitempty"`
+	// ConfigFile names a file written into each session with Config as its
+	// content, e.g. an MCP or permissions file the agent reads on start.
+	ConfigFile string `toml:"config_file,omitempty"`
+	Config     string `toml:"config,omitempty"`
+}
+
+// Validate rejects runtimes without a parseable command or with paths that
+// leave the session directory.
+func (r AgentRuntime) Validate() error {
+	if strings.TrimSpace(r.Command) == "" {
+		return fmt.Errorf("command must not be empty")
+	}
+	if _, err := template.New("command").Parse(r.Command); err != nil {
+		return fmt.Errorf("parse command: %w", err)
+	}
+	for _, path := range []struct{ field, value string }{{"agent_dir", r.AgentDir}, {"config_file", r.ConfigFile}} {
+		if path.value != "" && !filepath.IsLocal(path.value) {
+			return fmt.Errorf("%s %q must be a relative path inside the session", path.field, path.value)
+		}
+	}
+	if r.Config != "" && r.ConfigFile == "" {
+		return fmt.Errorf("config needs a config_file to write it to")
+	}
+
+	return nil
+}
+
+// ValidateAgentRuntimes checks every named runtime. The opencode name is
+// reserved for the built-in runtime.
+func ValidateAgentRuntimes(runtimes map[string]AgentRuntime) error {
+	for _, name := range sortedAgentNames(runtimes) {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("agent runtime name must not be empty")
+		}
+		if name == AgentOpencode {
+			return fmt.Errorf("agent runtime %q is built in and cannot be redefined", name)
+		}
+		if err := runtimes[name].Validate(); err != nil {
+			return fmt.Errorf("agent runtime %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ResolveAgent normalizes an agent runtime name against the built-in runtime
+// and runtimes; an empty name means opencode.
+func ResolveAgent(name string, runtimes map[string]AgentRuntime) (string, error) {
+	resolved := strings.TrimSpace(name)
+	if resolved == "" || resolved == AgentOpencode {
+		return AgentOpencode, nil
+	}
+	if _, ok := runtimes[resolved]; ok {
+		return resolved, nil
+	}
+
+	valid := append([]string{AgentOpencode}, sortedAgentNames(runtimes)...)
+	return "", fmt.Errorf("unknown agent runtime %q (valid: %s)", name, strings.Join(valid, ", "))
+}
+
+// AgentParams tunes the model and agent a role runs with. The zero value keeps
+// the agent's own defaults.
+type AgentParams struct {
+	// Model selects the model, e.g. "anthropic/claude-sonnet-4".
+	Model string `toml:"model,omitempty"`
+	// Temperature is the sampling temperature, from 0 to 2; nil keeps the default.
+	Temperature *float64 `toml:"temperature,omitempty"`
+	// Variant selects a model variant such as a reasoning effort, e.g. "high".
+	Variant string `toml:"variant,omitempty"`
+	// AgentFlags are extra arguments passed to the agent command.
+	AgentFlags []string `toml:"agent_flags,omitempty"`
+}
+
+// IsZero reports whether p leaves every agent default in place.
+func (p AgentParams) IsZero() bool {
+	return p.Model == "" && p.Temperature == nil && p.Variant == "" && len(p.AgentFlags) == 0
+}
+
+// Validate checks the temperature range and that flags are not blank.
+func (p AgentParams) Validate() error {
+	if t := p.Temperature; t != nil && (math.IsNaN(*t) || math.IsInf(*t, 0) || *t < 0 || *t > 2) {
+		return fmt.Errorf("temperature %s must be between 0 and 2", p.TemperatureText())
+	}
+	for _, flag := range p.AgentFlags {
+		if strings.TrimSpace(flag) == "" {
+			return fmt.Errorf("agent_flags must not contain blank flags")
+		}
+	}
+
+	return nil
+}
+
+// Override returns p with every field that override sets replaced. Flags from
+// override replace p's flags rather than adding to them.
+func (p AgentParams) Override(override AgentParams) AgentParams {
+	if model := strings.TrimSpace(override.Model); model != "" {
+		p.Model = model
+	}
+	if override.Temperature != nil {
+		temperature := *override.Temperature
+		p.Temperature = &temperature
+	}
+	if variant := strings.TrimSpace(override.Variant); variant != "" {
+		p.Variant = variant
+	}
+	if len(override.AgentFlags) > 0 {
+		p.AgentFlags = append([]string(nil), override.AgentFlags...)
+	}
+
+	return p
+}
+
+// TemperatureText formats Temperature, or returns "" when it is unset.
+func (p AgentParams) TemperatureText() string {
+	if p.Temperature == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(*p.Temperature, 'f', -1, 64)
+}
+
+func sortedAgentNames(runtimes map[string]AgentRuntime) []string {
+	names := make([]string, 0, len(runtimes))
+	for name := range runtimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
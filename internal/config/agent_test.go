@@ -0,0 +1,65 @@
+package config
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAgentParamsOverrideReplacesSetFields(t *testing.T) {
+	t.Parallel()
+
+	low, high := 0.2, 0.8
+	role := AgentParams{Model: "anthropic/claude-haiku", Temperature: &low, AgentFlags: []string{"--max-turns", "20"}}
+
+	if got := role.Override(AgentParams{}); !reflect.DeepEqual(got, role) {
+		t.Fatalf("expected an empty override to keep the role params, got %+v", got)
+	}
+
+	got := role.Override(AgentParams{Temperature: &high, Variant: "high", AgentFlags: []string{"--verbose"}})
+	want := AgentParams{Model: "anthropic/claude-haiku", Temperature: &high, Variant: "high", AgentFlags: []string{"--verbose"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	high = 1.5
+	if got.TemperatureText() != "0.8" {
+		t.Fatalf("expected the override temperature to be copied, got %s", got.TemperatureText())
+	}
+}
+
+func TestAgentParamsValidate(t *testing.T) {
+	t.Parallel()
+
+	negative, hot, nan, inf := -0.1, 2.5, math.NaN(), math.Inf(1)
+	tests := []struct {
+		name    string
+		params  AgentParams
+		wantErr string
+	}{
+		{name: "zero"},
+		{name: "negative temperature", params: AgentParams{Temperature: &negative}, wantErr: "temperature -0.1 must be between 0 and 2"},
+		{name: "hot temperature", params: AgentParams{Temperature: &hot}, wantErr: "temperature 2.5 must be between 0 and 2"},
+		{name: "NaN temperature", params: AgentParams{Temperature: &nan}, wantErr: "temperature NaN must be between 0 and 2"},
+		{name: "infinite temperature", params: AgentParams{Temperature: &inf}, wantErr: "temperature +Inf must be between 0 and 2"},
+		{name: "blank flag", params: AgentParams{AgentFlags: []string{"--verbose", " "}}, wantErr: "blank flags"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.params.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() returned error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
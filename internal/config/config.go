@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/BurntSushi/toml"
@@ -14,6 +15,9 @@ import (
 const (
 	DirName        = ".lattice"
 	ConfigFileName = "config.toml"
+	RunsDirName    = "runs"
+	TeamsDirName   = "teams"
+	LogsDirName    = "logs"
 )
 
 var now = time.Now
@@ -21,8 +25,25 @@ var now = time.Now
 // SessionMetadata captures run-level data for the current lattice session.
 type SessionMetadata struct {
 	Name       string `toml:"name"`
+	RunID      string `toml:"run_id"`
 	CreatedAt  string `toml:"created_at"`
 	WorkingDir string `toml:"working_dir"`
+	// MaxConcurrentRoles caps running role sessions in this run; 0 means no limit.
+	MaxConcurrentRoles int `toml:"max_concurrent_roles,omitempty"`
+	// Stall decides when a running role counts as stalled and what happens then.
+	Stall StallPolicy `toml:"stall,omitempty"`
+	// Hooks run shell commands on scheduler transitions in this run.
+	Hooks HookConfig `toml:"hooks,omitempty"`
+	// Executor starts the run's roles: tmux or process. Empty means tmux.
+	Executor string `toml:"executor,omitempty"`
+	// TmuxSocket is the tmux server the run's session lives on, as in the
+	// user settings. Empty means the default server.
+	TmuxSocket string `toml:"tmux_socket,omitempty"`
+	// Agents snapshots the custom agent runtimes the run's epics use, so roles
+	// launched later run the same commands even if the user settings change.
+	Agents map[string]AgentRuntime `toml:"agents,omitempty"`
+	// CompletedAt is set once every role of the run is finished.
+	CompletedAt string `toml:"completed_at,omitempty"`
 }
 
 // TeamState tracks mutable launch and runtime status for one team.
@@ -37,13 +58,26 @@ type TeamState struct {
 }
 
 // EpicState tracks mutable launch and runtime status for one epic.
+// Epics are keyed by BeadID so one run can hold several epics of the same audit type.
 type EpicState struct {
-	BeadID     string `toml:"bead_id"`
-	AuditType  string `toml:"audit_type"`
-	AuditName  string `toml:"audit_name"`
-	AgentCount int    `toml:"agent_count"`
-	Intensity  int    `toml:"intensity"`
-	Status     string `toml:"status"`
+	BeadID     string   `toml:"bead_id"`
+	AuditType  string   `toml:"audit_type"`
+	AuditName  string   `toml:"audit_name"`
+	Target     string   `toml:"target,omitempty"`
+	FocusAreas []string `toml:"focus_areas,omitempty"`
+	// DependsOn lists the bead IDs of epics that must complete before this epic starts.
+	DependsOn  []string `toml:"depends_on,omitempty"`
+	AgentCount int      `toml:"agent_count"`
+	Intensity  int      `toml:"intensity"`
+	Status     string   `toml:"status"`
+	// Retry decides whether failed roles in this epic are relaunched.
+	Retry RetryPolicy `toml:"retry,omitempty"`
+	// Paused stops the scheduler from launching any more roles in this epic.
+	Paused bool `toml:"paused,omitempty"`
+	// Agent names the runtime the epic's roles run with; empty means opencode.
+	Agent string `toml:"agent,omitempty"`
+	// Timing spans from the epic's first role launch until the epic finished.
+	Timing
 }
 
 // RoleState tracks mutable launch and runtime status for one role.
@@ -55,9 +89,53 @@ type RoleState struct {
 	Guidance   string `toml:"guidance"`
 	BeadPrefix string `toml:"bead_prefix"`
 	Order      int    `toml:"order"`
-	Status     string `toml:"status"`
+	// After lists the code names of roles in the same epic that must complete first.
+	After      []string `toml:"after,omitempty"`
+	Status     string   `toml:"status"`
+	TmuxWindow string   `toml:"tmux_window"`
+	Intensity  int      `toml:"intensity"`
+	ExitCode   *int     `toml:"exit_code,omitempty"`
+	ExitedAt   string   `toml:"exited_at,omitempty"`
+	// QueuePosition is the role's place in the launch queue while it waits for a free slot.
+	QueuePosition int `toml:"queue_position,omitempty"`
+	// Attempt numbers the role's current or latest launch, starting at 1.
+	Attempt int `toml:"attempt,omitempty"`
+	// RetryAt is when a failed role waiting for another attempt may relaunch.
+	RetryAt string `toml:"retry_at,omitempty"`
+	// Attempts records earlier failed attempts, oldest first.
+	Attempts []RoleAttempt `toml:"attempts,omitempty"`
+	// ActivityMark fingerprints the role's heartbeat, .team mtime and pane content;
+	// LastActivityAt is when the fingerprint last changed.
+	ActivityMark   string `toml:"activity_mark,omitempty"`
+	LastActivityAt string `toml:"last_activity_at,omitempty"`
+	// Paused keeps a pending role from launching until it is resumed.
+	Paused bool `toml:"paused,omitempty"`
+	// AgentParams holds the model and agent flags the role launches with.
+	AgentParams
+	// PID is the process group leader of the latest attempt under the process
+	// executor; tmux runs leave it 0.
+	PID int `toml:"pid,omitempty"`
+	// Log names the latest attempt's transcript in .lattice/logs/<run>, without
+	// the .log or .raw.log suffix.
+	Log string `toml:"log,omitempty"`
+	// StartLoop is the current_loop the latest attempt resumed from; 0 when it
+	// started fresh.
+	StartLoop int `toml:"start_loop,omitempty"`
+	// Timing covers the role's current or latest attempt.
+	Timing
+}
+
+// RoleAttempt records one failed attempt of a role that was retried.
+type RoleAttempt struct {
+	Attempt    int    `toml:"attempt"`
 	TmuxWindow string `toml:"tmux_window"`
-	Intensity  int    `toml:"intensity"`
+	// SessionDir names the team directory the attempt's files were moved to.
+	SessionDir string `toml:"session_dir,omitempty"`
+	ExitCode   *int   `toml:"exit_code,omitempty"`
+	ExitedAt   string `toml:"exited_at,omitempty"`
+	// Reason is "stalled" for attempts stopped by stall detection, "rerun" for
+	// attempts requeued from the dashboard and empty otherwise.
+	Reason string `toml:"reason,omitempty"`
 }
 
 // Config is persisted to .lattice/config.toml.
@@ -98,6 +176,16 @@ func Init(cwd string) (*Config, error) {
 // Load reads and decodes .lattice/config.toml.
 func Load(cwd string) (*Config, error) {
 	configPath := filepath.Join(cwd, DirName, ConfigFileName)
+	cfg, err := decodeFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.filePath = configPath
+	return cfg, nil
+}
+
+func decodeFile(configPath string) (*Config, error) {
 	file, err := os.Open(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("open config file: %w", err)
@@ -118,12 +206,62 @@ func Load(cwd string) (*Config, error) {
 	if cfg.Roles == nil {
 		cfg.Roles = map[string]RoleState{}
 	}
+	cfg.Epics = keyEpicsByBeadID(cfg.Epics)
 
-	cfg.filePath = configPath
 	return &cfg, nil
 }
 
+// keyEpicsByBeadID re-keys epics saved by older versions under their audit type id.
+func keyEpicsByBeadID(epics map[string]EpicState) map[string]EpicState {
+	keyed := make(map[string]EpicState, len(epics))
+	for key, epic := range epics {
+		if beadID := strings.TrimSpace(epic.BeadID); beadID != "" {
+			key = beadID
+		}
+		keyed[key] = epic
+	}
+
+	return keyed
+}
+
+// RunDir returns .lattice/runs/{runID} for one archived or active run.
+func RunDir(cwd, runID string) string {
+	return filepath.Join(cwd, DirName, RunsDirName, runID)
+}
+
+// TeamsDir returns the directory holding role session folders for a run.
+// Configs created before run history existed keep using .lattice/teams.
+func TeamsDir(cwd, runID string) string {
+	if strings.TrimSpace(runID) == "" {
+		return filepath.Join(cwd, DirName, TeamsDirName)
+	}
+
+	return filepath.Join(RunDir(cwd, runID), TeamsDirName)
+}
+
+// LogsDir returns .lattice/logs, which holds logs shared by every run.
+func LogsDir(cwd string) string {
+	return filepath.Join(cwd, DirName, LogsDirName)
+}
+
+// LoadRun reads the config snapshot stored in a run directory.
+// The returned config is read-only: Save fails because it has no file path.
+func LoadRun(cwd, runID string) (*Config, error) {
+	if strings.TrimSpace(runID) == "" {
+		return nil, errors.New("run id must not be empty")
+	}
+
+	cfg, err := decodeFile(filepath.Join(RunDir(cwd, runID), ConfigFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
 // Save encodes and writes the config to .lattice/config.toml.
+// When the session has a run id, the same content is mirrored to
+// .lattice/runs/{run_id}/config.toml so the run keeps its own snapshot.
 func (c *Config) Save() error {
 	if c.filePath == "" {
 		return errors.New("config file path is not set")
@@ -148,12 +286,30 @@ func (c *Config) Save() error {
 		return fmt.Errorf("encode config file: %w", err)
 	}
 
-	tmpPath := c.filePath + ".tmp"
-	if err := os.WriteFile(tmpPath, buf.Bytes(), 0o644); err != nil {
+	if err := writeFileAtomic(c.filePath, buf.Bytes()); err != nil {
+		return err
+	}
+
+	if runID := strings.TrimSpace(c.Session.RunID); runID != "" {
+		snapshotPath := filepath.Join(filepath.Dir(c.filePath), RunsDirName, runID, ConfigFileName)
+		if err := os.MkdirAll(filepath.Dir(snapshotPath), 0o755); err != nil {
+			return fmt.Errorf("create run directory: %w", err)
+		}
+		if err := writeFileAtomic(snapshotPath, buf.Bytes()); err != nil {
+			return fmt.Errorf("write run snapshot: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func writeFileAtomic(path string, content []byte) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, content, 0o644); err != nil {
 		return fmt.Errorf("write temp config file: %w", err)
 	}
 
-	if err := os.Rename(tmpPath, c.filePath); err != nil {
+	if err := os.Rename(tmpPath, path); err != nil {
 		_ = os.Remove(tmpPath)
 		return fmt.Errorf("replace config file: %w", err)
 	}
@@ -131,10 +131,16 @@ func TestLoadSaveRoundTripWithEpicsAndRoles(t *testing.T) {
 		BeadID:     "ai-nl5",
 		AuditType:  "nlp",
 		AuditName:  "narrative-audit",
+		Target:     "internal/nlp",
+		FocusAreas: []string{"tokenizer"},
 		AgentCount: 3,
 		Intensity:  2,
 		Status:     "in_progress",
+		Retry:      RetryPolicy{MaxAttempts: 3, Backoff: "5m", Resume: true},
+		Timing:     Timing{LaunchedAt: "2026-02-12T22:00:00Z"},
 	}
+	exitCode := 3
+	firstExit := 1
 	cfg.Roles["scribe"] = RoleState{
 		BeadID:     "ai-nl6",
 		EpicBeadID: "ai-nl5",
@@ -146,6 +152,13 @@ func TestLoadSaveRoundTripWithEpicsAndRoles(t *testing.T) {
 		Status:     "ready",
 		TmuxWindow: "nl5-scribe",
 		Intensity:  1,
+		ExitCode:   &exitCode,
+		ExitedAt:   "2026-02-13T00:00:00Z",
+		Attempt:    2,
+		Attempts: []RoleAttempt{
+			{Attempt: 1, TmuxWindow: "nl5-scribe", SessionDir: "ai-nl5-scribe.attempt-1", ExitCode: &firstExit, ExitedAt: "2026-02-12T23:00:00Z"},
+		},
+		Timing: Timing{LaunchedAt: "2026-02-12T23:30:00Z", FailedAt: "2026-02-13T00:00:00Z", Duration: "30m0s"},
 	}
 
 	if err := cfg.Save(); err != nil {
@@ -163,6 +176,14 @@ func TestLoadSaveRoundTripWithEpicsAndRoles(t *testing.T) {
 	if !reflect.DeepEqual(loaded.Roles, cfg.Roles) {
 		t.Fatalf("role state mismatch after round trip: got %+v want %+v", loaded.Roles, cfg.Roles)
 	}
+
+	data, err := os.ReadFile(filepath.Join(tmp, DirName, ConfigFileName))
+	if err != nil {
+		t.Fatalf("ReadFile() returned error: %v", err)
+	}
+	if !strings.Contains(string(data), `failed_at = "2026-02-13T00:00:00Z"`) {
+		t.Fatalf("expected role timing stored inline, got:\n%s", data)
+	}
 }
 
 func TestLoadBackwardCompatibilityWithoutEpicsAndRoles(t *testing.T) {
@@ -215,6 +236,39 @@ tmux_window = "alpha"
 	}
 }
 
+func TestLoadKeysLegacyEpicsByBeadID(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	configDir := filepath.Join(tmp, DirName)
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll() returned error: %v", err)
+	}
+
+	legacyConfig := `bead_counter = 3
+
+[epics.perf]
+bead_id = "audit-plan-001"
+audit_type = "perf"
+audit_name = "Performance"
+status = "running"
+`
+	if err := os.WriteFile(filepath.Join(configDir, ConfigFileName), []byte(legacyConfig), 0o644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	loaded, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if _, ok := loaded.Epics["perf"]; ok {
+		t.Fatal("expected legacy audit type key to be replaced")
+	}
+	if got := loaded.Epics["audit-plan-001"].AuditType; got != "perf" {
+		t.Fatalf("expected epic keyed by bead id, got %+v", loaded.Epics)
+	}
+}
+
 func TestSaveAtomicWriteCleansTempFile(t *testing.T) {
 	t.Parallel()
 
@@ -290,3 +344,38 @@ func TestSaveWithoutPathFails(t *testing.T) {
 		t.Fatalf("expected file path error, got: %v", err)
 	}
 }
+
+func TestSaveMirrorsRunSnapshot(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	cfg, err := Init(tmp)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+
+	cfg.Session.RunID = "20260211-143201"
+	cfg.Roles["audit-plan-002"] = RoleState{BeadID: "audit-plan-002", Status: "running"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	snapshot, err := LoadRun(tmp, "20260211-143201")
+	if err != nil {
+		t.Fatalf("LoadRun() returned error: %v", err)
+	}
+	if got := snapshot.Roles["audit-plan-002"].Status; got != "running" {
+		t.Fatalf("expected snapshot to mirror role status, got %q", got)
+	}
+	if err := snapshot.Save(); err == nil {
+		t.Fatal("expected run snapshot to be read-only")
+	}
+
+	wantTeams := filepath.Join(tmp, DirName, RunsDirName, "20260211-143201", TeamsDirName)
+	if got := TeamsDir(tmp, cfg.Session.RunID); got != wantTeams {
+		t.Fatalf("unexpected run teams dir: got %q want %q", got, wantTeams)
+	}
+	if got := TeamsDir(tmp, ""); got != filepath.Join(tmp, DirName, TeamsDirName) {
+		t.Fatalf("unexpected legacy teams dir: %q", got)
+	}
+}
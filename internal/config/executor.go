@@ -0,0 +1,27 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Executors a run can start its roles with.
+const (
+	// ExecutorTmux runs each role in a window of the run's tmux session.
+	ExecutorTmux = "tmux"
+	// ExecutorProcess runs each role as a plain subprocess in its own process
+	// group, for hosts without tmux such as containers and CI runners.
+	ExecutorProcess = "process"
+)
+
+// ResolveExecutor normalizes an executor name; an empty name means tmux.
+func ResolveExecutor(name string) (string, error) {
+	switch resolved := strings.ToLower(strings.TrimSpace(name)); resolved {
+	case "":
+		return ExecutorTmux, nil
+	case ExecutorTmux, ExecutorProcess:
+		return resolved, nil
+	default:
+		return "", fmt.Errorf("unknown executor %q (valid: tmux, process)", name)
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Hook events fired by the scheduler when roles, epics and the run change state.
+const (
+	HookRoleLaunch   = "on_role_launch"
+	HookRoleComplete = "on_role_complete"
+	// HookRoleFail fires when a role fails for good, after any retries.
+	HookRoleFail = "on_role_fail"
+	// HookEpicComplete fires once when every role of an epic is finished,
+	// whatever the epic's final status.
+	HookEpicComplete = "on_epic_complete"
+	// HookRunComplete fires once when every role of the run is finished.
+	HookRunComplete = "on_run_complete"
+)
+
+// DefaultHookTimeout bounds each hook command when HookConfig.Timeout is empty.
+const DefaultHookTimeout = 30 * time.Second
+
+// HookConfig lists shell commands to run on scheduler transitions. Each event
+// may run several commands, in order.
+type HookConfig struct {
+	OnRoleLaunch   []string `toml:"on_role_launch,omitempty"`
+	OnRoleComplete []string `toml:"on_role_complete,omitempty"`
+	OnRoleFail     []string `toml:"on_role_fail,omitempty"`
+	OnEpicComplete []string `toml:"on_epic_complete,omitempty"`
+	OnRunComplete  []string `toml:"on_run_complete,omitempty"`
+	// Timeout bounds each command, e.g. "2m"; an empty value means DefaultHookTimeout.
+	Timeout string `toml:"timeout,omitempty"`
+}
+
+// Commands returns the non-empty commands configured for event.
+func (h HookConfig) Commands(event string) []string {
+	var commands []string
+	switch event {
+	case HookRoleLaunch:
+		commands = h.OnRoleLaunch
+	case HookRoleComplete:
+		commands = h.OnRoleComplete
+	case HookRoleFail:
+		commands = h.OnRoleFail
+	case HookEpicComplete:
+		commands = h.OnEpicComplete
+	case HookRunComplete:
+		commands = h.OnRunComplete
+	}
+
+	var trimmed []string
+	for _, command := range commands {
+		if command = strings.TrimSpace(command); command != "" {
+			trimmed = append(trimmed, command)
+		}
+	}
+
+	return trimmed
+}
+
+// TimeoutDuration parses Timeout, falling back to DefaultHookTimeout.
+func (h HookConfig) TimeoutDuration() (time.Duration, error) {
+	value := strings.TrimSpace(h.Timeout)
+	if value == "" {
+		return DefaultHookTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parse hook timeout %q: %w", h.Timeout, err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("hook timeout %q must be positive", h.Timeout)
+	}
+
+	return timeout, nil
+}
+
+// Validate checks that the hooks can be run.
+func (h HookConfig) Validate() error {
+	_, err := h.TimeoutDuration()
+	return err
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHookConfigCommandsAndTimeout(t *testing.T) {
+	t.Parallel()
+
+	hooks := HookConfig{
+		OnRoleFail:    []string{" ./notify.sh ", "", "echo failed"},
+		OnRunComplete: []string{"bd sync"},
+		Timeout:       "2m",
+	}
+
+	if got, want := hooks.Commands(HookRoleFail), []string{"./notify.sh", "echo failed"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Commands(%s) = %#v, want %#v", HookRoleFail, got, want)
+	}
+	if got := hooks.Commands(HookRoleLaunch); got != nil {
+		t.Fatalf("expected no launch hooks, got %#v", got)
+	}
+	if got, _ := hooks.TimeoutDuration(); got != 2*time.Minute {
+		t.Fatalf("expected 2m timeout, got %v", got)
+	}
+	if got, _ := (HookConfig{}).TimeoutDuration(); got != DefaultHookTimeout {
+		t.Fatalf("expected default timeout, got %v", got)
+	}
+
+	for _, timeout := range []string{"soon", "0s"} {
+		err := HookConfig{Timeout: timeout}.Validate()
+		if err == nil || !strings.Contains(err.Error(), "hook timeout") {
+			t.Fatalf("expected hook timeout error for %q, got %v", timeout, err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls whether a failed role is relaunched.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of launches per role, including the first.
+	MaxAttempts int `toml:"max_attempts,omitempty"`
+	// Backoff is how long to wait before each retry, e.g. "30s" or "5m".
+	Backoff string `toml:"backoff,omitempty"`
+	// Resume continues from the failed attempt's current_loop instead of loop 0.
+	Resume bool `toml:"resume,omitempty"`
+}
+
+// Enabled reports whether the policy allows more than one attempt.
+func (p RetryPolicy) Enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+// BackoffDuration parses Backoff; an empty value means no delay.
+func (p RetryPolicy) BackoffDuration() (time.Duration, error) {
+	value := strings.TrimSpace(p.Backoff)
+	if value == "" {
+		return 0, nil
+	}
+
+	backoff, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parse retry backoff %q: %w", p.Backoff, err)
+	}
+	if backoff < 0 {
+		return 0, fmt.Errorf("retry backoff %q must not be negative", p.Backoff)
+	}
+
+	return backoff, nil
+}
+
+// Validate checks that the policy can be applied.
+func (p RetryPolicy) Validate() error {
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("retry max_attempts must not be negative")
+	}
+	if _, err := p.BackoffDuration(); err != nil {
+		return err
+	}
+
+	return nil
+}
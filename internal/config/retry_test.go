@@ -0,0 +1,45 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		policy      RetryPolicy
+		wantBackoff time.Duration
+		wantErr     string
+	}{
+		{name: "disabled"},
+		{name: "backoff", policy: RetryPolicy{MaxAttempts: 3, Backoff: "90s"}, wantBackoff: 90 * time.Second},
+		{name: "negative attempts", policy: RetryPolicy{MaxAttempts: -1}, wantErr: "max_attempts must not be negative"},
+		{name: "bad backoff", policy: RetryPolicy{MaxAttempts: 2, Backoff: "soon"}, wantErr: `parse retry backoff "soon"`},
+		{name: "negative backoff", policy: RetryPolicy{MaxAttempts: 2, Backoff: "-5s"}, wantErr: "must not be negative"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.policy.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() returned error: %v", err)
+			}
+			if got, _ := tt.policy.BackoffDuration(); got != tt.wantBackoff {
+				t.Fatalf("expected backoff %v, got %v", tt.wantBackoff, got)
+			}
+		})
+	}
+}
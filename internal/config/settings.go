@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// SettingsFileName holds per-user defaults under the user config dir.
+const SettingsFileName = "settings.toml"
+
+// UserSettings holds per-user defaults shared by every project.
+type UserSettings struct {
+	// MaxConcurrentRoles caps running role sessions across all epics; 0 means no limit.
+	MaxConcurrentRoles int `toml:"max_concurrent_roles"`
+	// Stall is the default stall policy for new runs.
+	Stall StallPolicy `toml:"stall"`
+	// Hooks run shell commands when roles, epics and runs change state.
+	Hooks HookConfig `toml:"hooks"`
+	// Executor is the default executor for new runs: tmux or process.
+	Executor string `toml:"executor"`
+	// TmuxSocket runs new runs on a dedicated tmux server: a name as with
+	// tmux -L, or a socket path as with tmux -S. Empty means the default server.
+	TmuxSocket string `toml:"tmux_socket"`
+	// Agent is the default agent runtime for audit types that do not name one.
+	Agent string `toml:"agent"`
+	// Agents defines command-template agent runtimes by name, next to the
+	// built-in opencode runtime.
+	Agents map[string]AgentRuntime `toml:"agents"`
+	// Models lists the models the wizard offers as a run-level override.
+	Models []string `toml:"models"`
+}
+
+// UserSettingsPath returns the user settings file, e.g. ~/.config/lattice/settings.toml.
+func UserSettingsPath() (string, error) {
+	userDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate user config dir: %w", err)
+	}
+
+	return filepath.Join(userDir, "lattice", SettingsFileName), nil
+}
+
+// LoadUserSettings reads the user settings file. A missing file yields the defaults.
+func LoadUserSettings() (UserSettings, error) {
+	path, err := UserSettingsPath()
+	if err != nil {
+		return UserSettings{}, err
+	}
+
+	return loadUserSettings(path)
+}
+
+func loadUserSettings(path string) (UserSettings, error) {
+	var settings UserSettings
+	meta, err := toml.DecodeFile(path, &settings)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return UserSettings{}, nil
+		}
+		return UserSettings{}, fmt.Errorf("decode %s: %w", path, err)
+	}
+
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return UserSettings{}, fmt.Errorf("decode %s: unknown field %q", path, undecoded[0].String())
+	}
+	if settings.MaxConcurrentRoles < 0 {
+		return UserSettings{}, fmt.Errorf("%s: max_concurrent_roles must not be negative", path)
+	}
+	if err := settings.Stall.Validate(); err != nil {
+		return UserSettings{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := settings.Hooks.Validate(); err != nil {
+		return UserSettings{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if _, err := ResolveExecutor(settings.Executor); err != nil {
+		return UserSettings{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := ValidateAgentRuntimes(settings.Agents); err != nil {
+		return UserSettings{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if _, err := ResolveAgent(settings.Agent, settings.Agents); err != nil {
+		return UserSettings{}, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, model := range settings.Models {
+		if strings.TrimSpace(model) == "" {
+			return UserSettings{}, fmt.Errorf("%s: models must not contain blank names", path)
+		}
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadUserSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr string
+	}{
+		{name: "missing file"},
+		{name: "limit", content: "max_concurrent_roles = 3\n", want: 3},
+		{name: "negative limit", content: "max_concurrent_roles = -1\n", wantErr: "must not be negative"},
+		{name: "unknown field", content: "max_roles = 3\n", wantErr: `unknown field "max_roles"`},
+		{name: "stall policy", content: "[stall]\ntimeout = \"20m\"\naction = \"restart\"\n"},
+		{name: "unknown stall action", content: "[stall]\ntimeout = \"20m\"\naction = \"page\"\n", wantErr: `unknown stall action "page"`},
+		{name: "hooks", content: "[hooks]\non_role_fail = [\"./notify.sh\"]\ntimeout = \"1m\"\n"},
+		{name: "bad hook timeout", content: "[hooks]\ntimeout = \"soon\"\n", wantErr: `parse hook timeout "soon"`},
+		{name: "process executor", content: "executor = \"process\"\n"},
+		{name: "tmux socket", content: "tmux_socket = \"lattice\"\n"},
+		{name: "unknown executor", content: "executor = \"docker\"\n", wantErr: `unknown executor "docker"`},
+		{name: "custom agent", content: "agent = \"claude\"\n[agents.claude]\ncommand = \"claude -p {{.Prompt}}\"\nagent_dir = \".claude\"\n"},
+		{name: "unknown agent", content: "agent = \"claude\"\n", wantErr: `unknown agent runtime "claude" (valid: opencode)`},
+		{name: "agent without command", content: "[agents.claude]\nagent_dir = \".claude\"\n", wantErr: `agent runtime "claude": command must not be empty`},
+		{name: "agent dir outside session", content: "[agents.claude]\ncommand = \"claude\"\nagent_dir = \"../claude\"\n", wantErr: `agent_dir "../claude" must be a relative path`},
+		{name: "models", content: "models = [\"anthropic/claude-haiku\", \"anthropic/claude-opus\"]\n"},
+		{name: "blank model", content: "models = [\"\"]\n", wantErr: "models must not contain blank names"},
+		{name: "redefined opencode", content: "[agents.opencode]\ncommand = \"opencode run\"\n", wantErr: `agent runtime "opencode" is built in`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), SettingsFileName)
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+					t.Fatalf("WriteFile() returned error: %v", err)
+				}
+			}
+
+			settings, err := loadUserSettings(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadUserSettings() returned error: %v", err)
+			}
+			if settings.MaxConcurrentRoles != tt.want {
+				t.Fatalf("expected max_concurrent_roles %d, got %d", tt.want, settings.MaxConcurrentRoles)
+			}
+		})
+	}
+}
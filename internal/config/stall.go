@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Stall actions applied to a role whose session shows no activity for the stall timeout.
+const (
+	// StallActionNotify marks the role stalled and leaves its session running.
+	StallActionNotify = "notify"
+	// StallActionRestart kills the session and relaunches the role from its current loop.
+	StallActionRestart = "restart"
+	// StallActionFail kills the session and handles the role like any other failure.
+	StallActionFail = "fail"
+)
+
+// DefaultStallRestartAttempts caps the launches of a role that keeps stalling
+// under the restart action when its epic sets no retry max_attempts.
+const DefaultStallRestartAttempts = 3
+
+// StallPolicy controls how long a running role may go without activity and what
+// happens when it does. The zero value disables stall detection.
+type StallPolicy struct {
+	// Timeout is how long a role may go without a heartbeat, .team change or new
+	// pane output, e.g. "20m". An empty value disables stall detection.
+	Timeout string `toml:"timeout,omitempty"`
+	// Action is notify, restart or fail; an empty value means notify.
+	Action string `toml:"action,omitempty"`
+}
+
+// TimeoutDuration parses Timeout; 0 means stall detection is disabled.
+func (p StallPolicy) TimeoutDuration() (time.Duration, error) {
+	value := strings.TrimSpace(p.Timeout)
+	if value == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("parse stall timeout %q: %w", p.Timeout, err)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("stall timeout %q must not be negative", p.Timeout)
+	}
+
+	return timeout, nil
+}
+
+// ResolvedAction returns the configured action, defaulting to notify.
+func (p StallPolicy) ResolvedAction() string {
+	action := strings.ToLower(strings.TrimSpace(p.Action))
+	if action == "" {
+		return StallActionNotify
+	}
+
+	return action
+}
+
+// Validate checks that the policy can be applied.
+func (p StallPolicy) Validate() error {
+	if _, err := p.TimeoutDuration(); err != nil {
+		return err
+	}
+
+	switch p.ResolvedAction() {
+	case StallActionNotify, StallActionRestart, StallActionFail:
+		return nil
+	default:
+		return fmt.Errorf("unknown stall action %q (valid: notify, restart, fail)", p.Action)
+	}
+}
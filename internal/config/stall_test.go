@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStallPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		policy      StallPolicy
+		wantTimeout time.Duration
+		wantAction  string
+		wantErr     string
+	}{
+		{name: "disabled", wantAction: StallActionNotify},
+		{name: "restart", policy: StallPolicy{Timeout: "20m", Action: "Restart"}, wantTimeout: 20 * time.Minute, wantAction: StallActionRestart},
+		{name: "bad timeout", policy: StallPolicy{Timeout: "a while"}, wantErr: `parse stall timeout "a while"`},
+		{name: "unknown action", policy: StallPolicy{Timeout: "5m", Action: "page"}, wantErr: `unknown stall action "page"`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.policy.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() returned error: %v", err)
+			}
+			if got, _ := tt.policy.TimeoutDuration(); got != tt.wantTimeout {
+				t.Fatalf("expected timeout %v, got %v", tt.wantTimeout, got)
+			}
+			if got := tt.policy.ResolvedAction(); got != tt.wantAction {
+				t.Fatalf("expected action %q, got %q", tt.wantAction, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package config
+
+import "time"
+
+// Timing records when a role or epic ran. Timestamps are RFC3339.
+type Timing struct {
+	// LaunchedAt is when the role's current attempt, or the epic's first role, started.
+	LaunchedAt  string `toml:"launched_at,omitempty"`
+	CompletedAt string `toml:"completed_at,omitempty"`
+	FailedAt    string `toml:"failed_at,omitempty"`
+	// Duration is how long the attempt or epic ran, e.g. "42m10s", set once it stopped.
+	Duration string `toml:"duration,omitempty"`
+}
+
+// MarkLaunched starts timing a new run at.
+func (t *Timing) MarkLaunched(at time.Time) {
+	*t = Timing{LaunchedAt: at.UTC().Format(time.RFC3339)}
+}
+
+// MarkStopped records how long the run lasted when it stopped at.
+func (t *Timing) MarkStopped(at time.Time) {
+	launched, err := time.Parse(time.RFC3339, t.LaunchedAt)
+	if err != nil {
+		return
+	}
+
+	t.Duration = at.Sub(launched).Round(time.Second).String()
+}
+
+// MarkCompleted stops the run at and records it as completed.
+func (t *Timing) MarkCompleted(at time.Time) {
+	t.MarkStopped(at)
+	t.CompletedAt = at.UTC().Format(time.RFC3339)
+}
+
+// MarkFailed stops the run at and records it as failed.
+func (t *Timing) MarkFailed(at time.Time) {
+	t.MarkStopped(at)
+	t.FailedAt = at.UTC().Format(time.RFC3339)
+}
+
+// Elapsed returns Duration once the run stopped, the time since LaunchedAt while
+// it is still going and 0 when it never launched.
+func (t Timing) Elapsed(now time.Time) time.Duration {
+	if duration, err := time.ParseDuration(t.Duration); err == nil {
+		return duration
+	}
+
+	launched, err := time.Parse(time.RFC3339, t.LaunchedAt)
+	if err != nil {
+		return 0
+	}
+	if elapsed := now.Sub(launched); elapsed > 0 {
+		return elapsed
+	}
+
+	return 0
+}
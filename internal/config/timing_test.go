@@ -0,0 +1,35 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimingMarksAndElapsed(t *testing.T) {
+	t.Parallel()
+
+	launched := time.Date(2026, time.March, 1, 22, 0, 0, 0, time.UTC)
+	var timing Timing
+	if got := timing.Elapsed(launched); got != 0 {
+		t.Fatalf("expected no elapsed time before launch, got %v", got)
+	}
+
+	timing.MarkLaunched(launched)
+	if got := timing.Elapsed(launched.Add(90 * time.Second)); got != 90*time.Second {
+		t.Fatalf("expected 90s while running, got %v", got)
+	}
+
+	timing.MarkFailed(launched.Add(10*time.Minute + 400*time.Millisecond))
+	if timing.FailedAt != "2026-03-01T22:10:00Z" || timing.Duration != "10m0s" || timing.CompletedAt != "" {
+		t.Fatalf("unexpected failed timing: %+v", timing)
+	}
+	if got := timing.Elapsed(launched.Add(time.Hour)); got != 10*time.Minute {
+		t.Fatalf("expected elapsed to stop at the recorded duration, got %v", got)
+	}
+
+	timing.MarkLaunched(launched.Add(time.Hour))
+	timing.MarkCompleted(launched.Add(2 * time.Hour))
+	if timing.FailedAt != "" || timing.CompletedAt != "2026-03-02T00:00:00Z" || timing.Duration != "1h0m0s" {
+		t.Fatalf("expected relaunch to reset timing, got %+v", timing)
+	}
+}
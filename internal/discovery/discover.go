@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"lattice/internal/agent"
 )
 
 const discoveryPrompt = `Analyze this codebase and identify 3-10 auditable areas for engineering review.
@@ -39,14 +41,21 @@ type Result struct {
 	RawOutput    string
 }
 
-type runOpencodeFunc func(projectDir, prompt string) (string, error)
+type runPromptFunc func(projectDir, prompt string) (string, error)
 
 // Discover runs opencode against projectDir and extracts auditable areas.
 func Discover(projectDir string) (Result, error) {
-	return discoverWithRunner(projectDir, runOpencode)
+	return DiscoverWith(projectDir, agent.Opencode{})
+}
+
+// DiscoverWith runs the discovery prompt through runtime.
+func DiscoverWith(projectDir string, runtime agent.Runtime) (Result, error) {
+	return discoverWithRunner(projectDir, func(projectDir, prompt string) (string, error) {
+		return runPrompt(runtime, projectDir, prompt)
+	})
 }
 
-func discoverWithRunner(projectDir string, runner runOpencodeFunc) (Result, error) {
+func discoverWithRunner(projectDir string, runner runPromptFunc) (Result, error) {
 	if strings.TrimSpace(projectDir) == "" {
 		return Result{}, fmt.Errorf("project directory must not be empty")
 	}
@@ -73,12 +82,17 @@ func discoverWithRunner(projectDir string, runner runOpencodeFunc) (Result, erro
 	return Result{Areas: areas, RawOutput: trimmedOutput}, nil
 }
 
-func runOpencode(projectDir, prompt string) (string, error) {
-	cmd := exec.Command("opencode", "run", prompt)
+func runPrompt(runtime agent.Runtime, projectDir, prompt string) (string, error) {
+	args, err := runtime.PromptCommand(prompt)
+	if err != nil {
+		return "", fmt.Errorf("build %s discovery command: %w", runtime.Name(), err)
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
 	cmd.Dir = projectDir
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return string(output), fmt.Errorf("run opencode discovery: %w", err)
+		return string(output), fmt.Errorf("run %s discovery: %w", runtime.Name(), err)
 	}
 
 	return string(output), nil
@@ -2,8 +2,13 @@ package discovery
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"lattice/internal/agent"
+	"lattice/internal/config"
 )
 
 func TestParseAreasFromOutputWithPreambleAndCodeFence(t *testing.T) {
@@ -76,3 +81,24 @@ func TestDiscoverFallsBackWhenJSONCannotBeParsed(t *testing.T) {
 		t.Fatalf("expected raw output to include source text, got %q", result.RawOutput)
 	}
 }
+
+func TestDiscoverWithRunsTemplateRuntimeInProjectDir(t *testing.T) {
+	t.Parallel()
+
+	projectDir := t.TempDir()
+	areas := `[{"name":"A","path":"a","description":"aa"},{"name":"B","path":"b","description":"bb"},{"name":"C","path":"c","description":"cc"}]`
+	if err := os.WriteFile(filepath.Join(projectDir, "areas.json"), []byte(areas), 0o644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	result, err := DiscoverWith(projectDir, agent.NewTemplate("cat", config.AgentRuntime{Command: "test -n {{.Prompt}} && cat areas.json"}))
+	if err != nil {
+		t.Fatalf("DiscoverWith() returned error: %v", err)
+	}
+	if result.UsedFallback {
+		t.Fatalf("expected the runtime's areas, got fallback with output %q", result.RawOutput)
+	}
+	if len(result.Areas) != 3 || result.Areas[2].Path != "c" {
+		t.Fatalf("unexpected areas: %#v", result.Areas)
+	}
+}
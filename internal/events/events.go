@@ -0,0 +1,199 @@
+// Package events keeps an append-only journal of orchestration actions in
+// .lattice/events.jsonl, one JSON event per line.
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"lattice/internal/config"
+)
+
+// FileName is the journal file under the .lattice directory.
+const FileName = "events.jsonl"
+
+// tailChunkSize is how much of the journal's end Read scans first for a
+// limited filter; the window doubles until it holds enough events.
+const tailChunkSize = 64 * 1024
+
+// Event kinds.
+const (
+	// KindLaunch records a launched run.
+	KindLaunch = "launch"
+	// KindTransition records a role or epic status change made by the scheduler.
+	KindTransition = "transition"
+	// KindAction records a manual control such as pause, skip or stop.
+	KindAction = "action"
+	// KindAttach records a user attaching to the run's tmux session.
+	KindAttach = "attach"
+	// KindHook records the result of one hook command.
+	KindHook = "hook"
+	// KindError records a failed launch, scheduler pass or action.
+	KindError = "error"
+)
+
+// Event is one journal entry.
+type Event struct {
+	Time time.Time `json:"time"`
+	Kind string    `json:"kind"`
+	// Source names the part of lattice that recorded the event, e.g. "scheduler" or "dashboard".
+	Source string `json:"source,omitempty"`
+	// User is the OS user lattice ran as.
+	User   string `json:"user,omitempty"`
+	RunID  string `json:"run_id,omitempty"`
+	EpicID string `json:"epic_id,omitempty"`
+	RoleID string `json:"role_id,omitempty"`
+	// From and To are the previous and new status of a transition.
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+	// Action names the control of an action event or the hook of a hook event.
+	Action  string `json:"action,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Filter selects events when reading the journal.
+type Filter struct {
+	// RunID keeps only events of one run when set.
+	RunID string
+	// Limit keeps only the newest Limit events when positive.
+	Limit int
+}
+
+// Path returns .lattice/events.jsonl for a project.
+func Path(cwd string) string {
+	return filepath.Join(cwd, config.DirName, FileName)
+}
+
+// Append writes events to the journal in one write. Events without a time or
+// user get the current time and OS user.
+func Append(cwd string, events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	userName := currentUser()
+	var buf bytes.Buffer
+	for _, event := range events {
+		if event.Time.IsZero() {
+			event.Time = now
+		}
+		if event.User == "" {
+			event.User = userName
+		}
+		line, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("encode event: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	path := Path(cwd)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create lattice directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open event journal: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write event journal: %w", err)
+	}
+
+	return nil
+}
+
+// Read returns the journal's events that match filter, oldest first. A missing
+// journal yields no events. Lines that do not parse, such as a line cut short
+// by a crash, are skipped. With a limit only the end of the journal is read.
+func Read(cwd string, filter Filter) ([]Event, error) {
+	file, err := os.Open(Path(cwd))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open event journal: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat event journal: %w", err)
+	}
+	size := info.Size()
+
+	window := int64(tailChunkSize)
+	if filter.Limit <= 0 {
+		window = size
+	}
+	for {
+		offset := size - window
+		if offset < 0 {
+			offset = 0
+		}
+
+		data := make([]byte, size-offset)
+		if _, err := file.ReadAt(data, offset); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("read event journal: %w", err)
+		}
+		if offset > 0 {
+			// The window starts mid-line unless it follows a newline.
+			if idx := bytes.IndexByte(data, '\n'); idx >= 0 {
+				data = data[idx+1:]
+			} else {
+				data = nil
+			}
+		}
+
+		events := parseEvents(data, filter.RunID)
+		if offset == 0 || len(events) >= filter.Limit {
+			if filter.Limit > 0 && len(events) > filter.Limit {
+				events = events[len(events)-filter.Limit:]
+			}
+			return events, nil
+		}
+		window *= 2
+	}
+}
+
+// parseEvents decodes the journal lines in data that belong to runID, or to
+// any run when runID is empty, skipping lines that do not parse.
+func parseEvents(data []byte, runID string) []Event {
+	var events []Event
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			continue
+		}
+		if runID != "" && event.RunID != runID {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func currentUser() string {
+	if current, err := user.Current(); err == nil && strings.TrimSpace(current.Username) != "" {
+		return current.Username
+	}
+
+	return os.Getenv("USER")
+}
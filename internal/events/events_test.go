@@ -0,0 +1,115 @@
+package events
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAppendAndReadFilterByRunAndLimit(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	if events, err := Read(cwd, Filter{}); err != nil || events != nil {
+		t.Fatalf("expected no events without a journal, got %#v, %v", events, err)
+	}
+
+	at := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+	if err := Append(cwd,
+		Event{Time: at, Kind: KindLaunch, RunID: "run-1"},
+		Event{Time: at.Add(time.Minute), Kind: KindTransition, RunID: "run-1", RoleID: "r1", From: "running", To: "complete"},
+	); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := Append(cwd, Event{Kind: KindLaunch, RunID: "run-2", User: "alice"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	all, err := Read(cwd, Filter{})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("expected 3 events, got %#v", all)
+	}
+	if all[2].Time.IsZero() || all[2].User != "alice" {
+		t.Fatalf("expected stamped time and kept user, got %#v", all[2])
+	}
+
+	run, err := Read(cwd, Filter{RunID: "run-1", Limit: 1})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(run) != 1 || run[0].RoleID != "r1" || run[0].To != "complete" || !run[0].Time.Equal(at.Add(time.Minute)) {
+		t.Fatalf("expected newest run-1 event, got %#v", run)
+	}
+}
+
+func TestReadSkipsMalformedLines(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	if err := Append(cwd, Event{Kind: KindLaunch, RunID: "run-1"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	file, err := os.OpenFile(Path(cwd), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open journal: %v", err)
+	}
+	if _, err := file.WriteString("{not json\n"); err != nil {
+		t.Fatalf("write journal: %v", err)
+	}
+	file.Close()
+	if err := Append(cwd, Event{Kind: KindAction, RunID: "run-1", Action: "pause"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	file, err = os.OpenFile(Path(cwd), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open journal: %v", err)
+	}
+	if _, err := file.WriteString(`{"kind":"transi`); err != nil {
+		t.Fatalf("write journal: %v", err)
+	}
+	file.Close()
+
+	got, err := Read(cwd, Filter{RunID: "run-1"})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Kind != KindLaunch || got[1].Action != "pause" {
+		t.Fatalf("expected the two well-formed events, got %#v", got)
+	}
+}
+
+func TestReadWithLimitScansBeyondFirstWindow(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	if err := Append(cwd, Event{Kind: KindLaunch, RunID: "run-1"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	noise := make([]Event, 0, 2000)
+	for i := 0; i < cap(noise); i++ {
+		noise = append(noise, Event{Kind: KindTransition, RunID: "run-2", RoleID: fmt.Sprintf("r%d", i), To: "running"})
+	}
+	if err := Append(cwd, noise...); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	got, err := Read(cwd, Filter{RunID: "run-1", Limit: 5})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Kind != KindLaunch {
+		t.Fatalf("expected the run-1 launch from the start of the journal, got %#v", got)
+	}
+
+	latest, err := Read(cwd, Filter{Limit: 2})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(latest) != 2 || latest[1].RoleID != "r1999" || latest[0].RoleID != "r1998" {
+		t.Fatalf("expected the two newest events, got %#v", latest)
+	}
+}
@@ -0,0 +1,72 @@
+// Package executor starts the agents of a run's roles and reports on them, in
+// tmux windows or as plain subprocesses.
+package executor
+
+import (
+	"fmt"
+
+	"lattice/internal/config"
+	"lattice/internal/teams"
+	"lattice/internal/tmux"
+)
+
+// Target identifies one started role attempt.
+type Target struct {
+	// Session is the run's session name.
+	Session string
+	// Name identifies the attempt within the session, e.g. "e1-alpha-2". The
+	// tmux executor names the attempt's window after it.
+	Name string
+	// PID is the attempt's process group leader under the process executor.
+	PID int
+	// LogPath receives the attempt's raw output.
+	LogPath string
+}
+
+// Executor starts role agents and answers the scheduler's questions about them.
+type Executor interface {
+	// Kind returns config.ExecutorTmux or config.ExecutorProcess.
+	Kind() string
+	// StartSession prepares the session a run's roles start in.
+	StartSession(session string) error
+	// StartRole runs the role wrapper in dir for target, streaming its output to
+	// target.LogPath when set. It returns the process ID when the executor tracks one.
+	StartRole(target Target, dir string) (pid int, err error)
+	// Alive reports whether target is still running or holds its tmux window.
+	Alive(target Target) bool
+	// ExitStatus reports whether the agent of a live target has exited and with
+	// which code.
+	ExitStatus(target Target) (code int, exited bool)
+	// Output returns target's recent output; ok is false when it cannot be read.
+	Output(target Target) (content string, ok bool)
+	// Stop ends target, e.g. before a stalled role restarts.
+	Stop(target Target) error
+	// Interrupt sends target Ctrl-C, e.g. when a role is cancelled.
+	Interrupt(target Target) error
+	// StopSession ends a run; running lists the targets still running in it.
+	StopSession(session string, running []Target) error
+}
+
+// New returns the executor called kind; an empty kind means tmux. The tmux
+// executor runs on the server tmuxSocket selects, see tmux.ParseSocket.
+func New(kind, tmuxSocket string) (Executor, error) {
+	resolved, err := config.ResolveExecutor(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolved == config.ExecutorProcess {
+		return NewProcess(), nil
+	}
+
+	manager, err := tmux.NewManager(tmux.ParseSocket(tmuxSocket))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTmux(manager, tmux.TranslateToWSLPath), nil
+}
+
+func roleCommand(dir string) string {
+	return fmt.Sprintf("cd %s && exec sh ./%s", shellQuote(dir), teams.RoleRunScript)
+}
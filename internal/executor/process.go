@@ -0,0 +1,145 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"lattice/internal/config"
+	"lattice/internal/teams"
+)
+
+// processOutputLimit caps how much of a role's log Output returns.
+const processOutputLimit = 4096
+
+// Process runs each role as a subprocess in its own process group, with stdout
+// and stderr appended to the role's raw log. Roles keep running when lattice
+// exits; later lattice processes find them again by their PID.
+type Process struct{}
+
+// NewProcess returns a subprocess executor.
+func NewProcess() *Process {
+	return &Process{}
+}
+
+// Kind returns config.ExecutorProcess.
+func (e *Process) Kind() string {
+	return config.ExecutorProcess
+}
+
+// StartSession does nothing: process runs have no session to create.
+func (e *Process) StartSession(string) error {
+	return nil
+}
+
+// StartRole runs the role wrapper in dir as the leader of a new process group.
+func (e *Process) StartRole(target Target, dir string) (int, error) {
+	cmd := exec.Command("sh", "./"+teams.RoleRunScript)
+	cmd.Dir = dir
+	setProcessGroup(cmd)
+
+	if target.LogPath != "" {
+		if err := os.MkdirAll(filepath.Dir(target.LogPath), 0o755); err != nil {
+			return 0, fmt.Errorf("create log directory: %w", err)
+		}
+		logFile, err := os.OpenFile(target.LogPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return 0, fmt.Errorf("open role log: %w", err)
+		}
+		// The child keeps its own copy of the descriptor.
+		defer logFile.Close()
+		cmd.Stdout = logFile
+		cmd.Stderr = logFile
+	}
+
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("start role process: %w", err)
+	}
+	// Reap the process once it exits so Alive stops reporting it. After lattice
+	// exits, init reaps it instead.
+	go func() { _ = cmd.Wait() }()
+
+	return cmd.Process.Pid, nil
+}
+
+// Alive reports whether target's process still exists.
+func (e *Process) Alive(target Target) bool {
+	return target.PID > 0 && processAlive(target.PID)
+}
+
+// ExitStatus always reports a live process as running: a process that exited
+// is no longer Alive, and the role wrapper's .exit file records its code.
+func (e *Process) ExitStatus(Target) (int, bool) {
+	return 0, false
+}
+
+// Output returns the tail of target's log.
+func (e *Process) Output(target Target) (string, bool) {
+	if target.LogPath == "" {
+		return "", false
+	}
+
+	file, err := os.Open(target.LogPath)
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", false
+	}
+	if offset := info.Size() - processOutputLimit; offset > 0 {
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			return "", false
+		}
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return "", false
+	}
+
+	return string(content), true
+}
+
+// Stop terminates target's process group. A group that already exited is not
+// an error.
+func (e *Process) Stop(target Target) error {
+	if target.PID <= 0 {
+		return nil
+	}
+
+	if err := signalProcessGroup(target.PID, false); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("stop process %d: %w", target.PID, err)
+	}
+
+	return nil
+}
+
+// Interrupt sends SIGINT to target's process group.
+func (e *Process) Interrupt(target Target) error {
+	if target.PID <= 0 {
+		return fmt.Errorf("no process recorded for %s", target.Name)
+	}
+
+	if err := signalProcessGroup(target.PID, true); err != nil {
+		return fmt.Errorf("interrupt process %d: %w", target.PID, err)
+	}
+
+	return nil
+}
+
+// StopSession stops every running target.
+func (e *Process) StopSession(_ string, running []Target) error {
+	var errs []error
+	for _, target := range running {
+		if err := e.Stop(target); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
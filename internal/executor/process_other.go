@@ -0,0 +1,37 @@
+//go:build !unix
+
+package executor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// setProcessGroup does nothing: without process groups, Stop ends only the
+// role wrapper itself.
+func setProcessGroup(*exec.Cmd) {}
+
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = process.Release()
+
+	return true
+}
+
+func signalProcessGroup(pid int, interrupt bool) error {
+	if interrupt {
+		return fmt.Errorf("interrupting role processes is not supported on %s", runtime.GOOS)
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return os.ErrProcessDone
+	}
+
+	return process.Kill()
+}
@@ -0,0 +1,89 @@
+//go:build unix
+
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"lattice/internal/teams"
+)
+
+func TestProcessStartRoleLogsOutputAndStopsGroup(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	script := "echo started\necho warning >&2\nsleep 30 &\necho $! > child.pid\nwait\n"
+	if err := os.WriteFile(filepath.Join(dir, teams.RoleRunScript), []byte(script), 0o755); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	e := NewProcess()
+	target := Target{Session: "sess", Name: "e1-alpha", LogPath: filepath.Join(dir, "logs", "e1-alpha.raw.log")}
+	pid, err := e.StartRole(target, dir)
+	if err != nil {
+		t.Fatalf("StartRole() returned error: %v", err)
+	}
+	target.PID = pid
+	if !e.Alive(target) {
+		t.Fatalf("expected process %d to be alive", pid)
+	}
+
+	var child int
+	waitFor(t, "child PID and output", func() bool {
+		content, _ := os.ReadFile(filepath.Join(dir, "child.pid"))
+		child, _ = strconv.Atoi(strings.TrimSpace(string(content)))
+		output, ok := e.Output(target)
+		return child > 0 && ok && output == "started\nwarning\n"
+	})
+
+	if err := e.Stop(target); err != nil {
+		t.Fatalf("Stop() returned error: %v", err)
+	}
+	waitFor(t, "process group to exit", func() bool {
+		return !e.Alive(target) && !processAlive(child)
+	})
+	if err := e.Stop(target); err != nil {
+		t.Fatalf("expected stopping an exited group to succeed, got %v", err)
+	}
+}
+
+func TestProcessInterruptEndsRole(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, teams.RoleRunScript), []byte("exec sleep 30\n"), 0o755); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	e := NewProcess()
+	pid, err := e.StartRole(Target{Session: "sess", Name: "e1-alpha"}, dir)
+	if err != nil {
+		t.Fatalf("StartRole() returned error: %v", err)
+	}
+	target := Target{Session: "sess", Name: "e1-alpha", PID: pid}
+
+	if err := e.Interrupt(target); err != nil {
+		t.Fatalf("Interrupt() returned error: %v", err)
+	}
+	waitFor(t, "interrupted process to exit", func() bool { return !e.Alive(target) })
+	if err := e.Interrupt(Target{Name: "e1-bravo"}); err == nil {
+		t.Fatal("expected an error interrupting a role without a PID")
+	}
+}
+
+func waitFor(t *testing.T, what string, done func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,56 @@
+//go:build unix
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// processAlive reports whether pid exists and has not exited. Zombies count as
+// exited: a role orphaned by an earlier lattice process is reaped by init, which
+// may take a while or, when lattice itself is PID 1 in a container, never happen.
+func processAlive(pid int) bool {
+	if err := syscall.Kill(pid, 0); err != nil && !errors.Is(err, syscall.EPERM) {
+		return false
+	}
+
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		// Without procfs the process is taken to be running.
+		return true
+	}
+	// The state follows the command name, which is in parentheses and may contain them.
+	fields := string(stat)
+	if idx := strings.LastIndexByte(fields, ')'); idx >= 0 {
+		fields = fields[idx+1:]
+	}
+
+	return !strings.HasPrefix(strings.TrimSpace(fields), "Z")
+}
+
+// signalProcessGroup sends SIGINT, or SIGTERM when interrupt is false, to the
+// process group led by pid.
+func signalProcessGroup(pid int, interrupt bool) error {
+	signal := syscall.SIGTERM
+	if interrupt {
+		signal = syscall.SIGINT
+	}
+
+	if err := syscall.Kill(-pid, signal); err != nil {
+		if errors.Is(err, syscall.ESRCH) {
+			return os.ErrProcessDone
+		}
+		return err
+	}
+
+	return nil
+}
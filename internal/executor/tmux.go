@@ -0,0 +1,189 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"lattice/internal/config"
+	"lattice/internal/panelog"
+	"lattice/internal/tmux"
+)
+
+// TmuxManager is the part of tmux.Manager every tmux executor needs. The
+// executor uses the manager's other methods when it has them.
+type TmuxManager interface {
+	CreateSession(name string) error
+	CreateWindow(session, name string) error
+	SendKeys(session, window, command string) error
+}
+
+type remainOnExitSetter interface {
+	SetRemainOnExit(session, window string) error
+}
+
+type panePiper interface {
+	PipePane(session, window, command string) error
+}
+
+// Tmux runs each role in a window of the run's tmux session.
+type Tmux struct {
+	manager       TmuxManager
+	translatePath func(path string) (string, error)
+}
+
+// NewTmux returns a tmux executor. translatePath maps host paths to the paths
+// tmux sees, e.g. tmux.TranslateToWSLPath.
+func NewTmux(manager TmuxManager, translatePath func(path string) (string, error)) *Tmux {
+	return &Tmux{manager: manager, translatePath: translatePath}
+}
+
+// Kind returns config.ExecutorTmux.
+func (e *Tmux) Kind() string {
+	return config.ExecutorTmux
+}
+
+// StartSession creates the run's tmux session.
+func (e *Tmux) StartSession(session string) error {
+	if err := e.manager.CreateSession(session); err != nil {
+		return fmt.Errorf("create tmux session: %w", err)
+	}
+
+	return nil
+}
+
+// StartRole opens a window for target, streams its pane to target.LogPath and
+// runs the role wrapper in it. The window is kept open after the agent exits so
+// its exit status stays readable.
+func (e *Tmux) StartRole(target Target, dir string) (int, error) {
+	if err := e.manager.CreateWindow(target.Session, target.Name); err != nil {
+		return 0, fmt.Errorf("create tmux window: %w", err)
+	}
+
+	if setter, ok := e.manager.(remainOnExitSetter); ok {
+		if err := setter.SetRemainOnExit(target.Session, target.Name); err != nil {
+			return 0, fmt.Errorf("keep tmux window open: %w", err)
+		}
+	}
+
+	if piper, ok := e.manager.(panePiper); ok && target.LogPath != "" {
+		if err := os.MkdirAll(filepath.Dir(target.LogPath), 0o755); err != nil {
+			return 0, fmt.Errorf("create log directory: %w", err)
+		}
+		logPath, err := e.translatePath(target.LogPath)
+		if err != nil {
+			return 0, fmt.Errorf("translate log path: %w", err)
+		}
+		if err := piper.PipePane(target.Session, target.Name, panelog.PipeCommand(logPath)); err != nil {
+			return 0, fmt.Errorf("stream output: %w", err)
+		}
+	}
+
+	roleDir, err := e.translatePath(dir)
+	if err != nil {
+		return 0, fmt.Errorf("translate role session path: %w", err)
+	}
+	if err := e.manager.SendKeys(target.Session, target.Name, roleCommand(roleDir)); err != nil {
+		return 0, fmt.Errorf("launch auditor: %w", err)
+	}
+
+	return 0, nil
+}
+
+// Alive reports whether target's window still exists.
+func (e *Tmux) Alive(target Target) bool {
+	lister, ok := e.manager.(interface {
+		ListWindows(session string) ([]tmux.WindowInfo, error)
+	})
+	if !ok {
+		return false
+	}
+
+	windows, err := lister.ListWindows(target.Session)
+	if err != nil {
+		return false
+	}
+	for _, window := range windows {
+		if window.Name == target.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExitStatus reports whether the pane of target's window is dead.
+func (e *Tmux) ExitStatus(target Target) (int, bool) {
+	checker, ok := e.manager.(interface {
+		PaneStatus(session, window string) (tmux.PaneStatus, error)
+	})
+	if !ok {
+		return 0, false
+	}
+
+	status, err := checker.PaneStatus(target.Session, target.Name)
+	if err != nil || !status.Dead {
+		return 0, false
+	}
+
+	return status.ExitStatus, true
+}
+
+// Output returns the visible content of target's pane.
+func (e *Tmux) Output(target Target) (string, bool) {
+	capturer, ok := e.manager.(interface {
+		CapturePane(session, window string) (string, error)
+	})
+	if !ok {
+		return "", false
+	}
+
+	content, err := capturer.CapturePane(target.Session, target.Name)
+	if err != nil {
+		return "", false
+	}
+
+	return content, true
+}
+
+// Stop kills target's window.
+func (e *Tmux) Stop(target Target) error {
+	killer, ok := e.manager.(interface {
+		KillWindow(session, window string) error
+	})
+	if !ok {
+		return nil
+	}
+
+	return killer.KillWindow(target.Session, target.Name)
+}
+
+// Interrupt sends Ctrl-C to target's window.
+func (e *Tmux) Interrupt(target Target) error {
+	interrupter, ok := e.manager.(interface {
+		SendInterrupt(session, window string) error
+	})
+	if !ok {
+		return errors.New("tmux manager cannot send interrupts")
+	}
+
+	return interrupter.SendInterrupt(target.Session, target.Name)
+}
+
+// StopSession kills the run's tmux session, which ends every window in it.
+func (e *Tmux) StopSession(session string, _ []Target) error {
+	killer, ok := e.manager.(interface {
+		KillSession(name string) error
+	})
+	if !ok {
+		return errors.New("tmux manager cannot kill sessions")
+	}
+
+	return killer.KillSession(session)
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "'\"'\"'") + "'"
+}
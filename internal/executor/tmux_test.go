@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"lattice/internal/panelog"
+	"lattice/internal/tmux"
+)
+
+type fakeTmuxManager struct {
+	calls   []string
+	windows []tmux.WindowInfo
+	pane    tmux.PaneStatus
+}
+
+func (m *fakeTmuxManager) CreateSession(name string) error {
+	m.calls = append(m.calls, "new-session "+name)
+	return nil
+}
+
+func (m *fakeTmuxManager) CreateWindow(session, name string) error {
+	m.calls = append(m.calls, "new-window "+session+":"+name)
+	return nil
+}
+
+func (m *fakeTmuxManager) SendKeys(session, window, command string) error {
+	m.calls = append(m.calls, "send-keys "+session+":"+window+" "+command)
+	return nil
+}
+
+func (m *fakeTmuxManager) SetRemainOnExit(session, window string) error {
+	m.calls = append(m.calls, "remain-on-exit "+session+":"+window)
+	return nil
+}
+
+func (m *fakeTmuxManager) PipePane(session, window, command string) error {
+	m.calls = append(m.calls, "pipe-pane "+session+":"+window+" "+command)
+	return nil
+}
+
+func (m *fakeTmuxManager) ListWindows(string) ([]tmux.WindowInfo, error) {
+	return m.windows, nil
+}
+
+func (m *fakeTmuxManager) PaneStatus(string, string) (tmux.PaneStatus, error) {
+	return m.pane, nil
+}
+
+func TestTmuxStartRoleOpensWindowAndRunsWrapper(t *testing.T) {
+	t.Parallel()
+
+	manager := &fakeTmuxManager{}
+	logPath := filepath.Join(t.TempDir(), "logs", "e1-alpha.raw.log")
+	e := NewTmux(manager, func(path string) (string, error) { return "/mnt" + path, nil })
+
+	pid, err := e.StartRole(Target{Session: "sess", Name: "e1-alpha", LogPath: logPath}, "/work/o'neil")
+	if err != nil {
+		t.Fatalf("StartRole() returned error: %v", err)
+	}
+	if pid != 0 {
+		t.Fatalf("expected no PID from tmux, got %d", pid)
+	}
+
+	want := []string{
+		"new-window sess:e1-alpha",
+		"remain-on-exit sess:e1-alpha",
+		"pipe-pane sess:e1-alpha " + panelog.PipeCommand("/mnt"+logPath),
+		`send-keys sess:e1-alpha cd '/mnt/work/o'"'"'neil' && exec sh ./run-agent.sh`,
+	}
+	if !reflect.DeepEqual(manager.calls, want) {
+		t.Fatalf("unexpected tmux calls:\ngot  %#v\nwant %#v", manager.calls, want)
+	}
+}
+
+func TestTmuxReportsWindowsAndDeadPanes(t *testing.T) {
+	t.Parallel()
+
+	manager := &fakeTmuxManager{
+		windows: []tmux.WindowInfo{{Index: 0, Name: "dashboard"}, {Index: 1, Name: "e1-alpha"}},
+		pane:    tmux.PaneStatus{Dead: true, ExitStatus: 3},
+	}
+	e := NewTmux(manager, nil)
+
+	if !e.Alive(Target{Session: "sess", Name: "e1-alpha"}) || e.Alive(Target{Session: "sess", Name: "e1-bravo"}) {
+		t.Fatal("expected only the listed window to be alive")
+	}
+	if code, exited := e.ExitStatus(Target{Session: "sess", Name: "e1-alpha"}); !exited || code != 3 {
+		t.Fatalf("ExitStatus() = %d, %t, want 3, true", code, exited)
+	}
+	if _, ok := e.Output(Target{Session: "sess", Name: "e1-alpha"}); ok {
+		t.Fatal("expected no output from a manager that cannot capture panes")
+	}
+	if err := e.StopSession("sess", nil); err == nil {
+		t.Fatal("expected an error from a manager that cannot kill sessions")
+	}
+}
@@ -0,0 +1,210 @@
+// Package hooks runs user-configured shell commands on scheduler transitions.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// LogFileName is the hook log under the lattice logs directory.
+const LogFileName = "hooks.log"
+
+// Payload describes one transition. It is written to the hook's stdin as JSON
+// and mirrored in LATTICE_* environment variables.
+type Payload struct {
+	Event       string    `json:"event"`
+	Time        time.Time `json:"time"`
+	RunID       string    `json:"run_id"`
+	SessionName string    `json:"session_name"`
+	ProjectDir  string    `json:"project_dir"`
+	Epic        *Epic     `json:"epic,omitempty"`
+	Role        *Role     `json:"role,omitempty"`
+}
+
+// Epic is the epic a transition belongs to.
+type Epic struct {
+	BeadID    string `json:"bead_id"`
+	AuditType string `json:"audit_type"`
+	AuditName string `json:"audit_name"`
+	Target    string `json:"target,omitempty"`
+	Status    string `json:"status"`
+}
+
+// Role is the role a transition belongs to.
+type Role struct {
+	BeadID     string `json:"bead_id"`
+	CodeName   string `json:"code_name"`
+	Title      string `json:"title"`
+	Status     string `json:"status"`
+	Attempt    int    `json:"attempt,omitempty"`
+	TmuxWindow string `json:"tmux_window,omitempty"`
+	SessionDir string `json:"session_dir,omitempty"`
+	ExitCode   *int   `json:"exit_code,omitempty"`
+}
+
+// Env returns the payload as LATTICE_* environment variables.
+func (p Payload) Env() []string {
+	env := []string{
+		"LATTICE_EVENT=" + p.Event,
+		"LATTICE_RUN_ID=" + p.RunID,
+		"LATTICE_SESSION=" + p.SessionName,
+		"LATTICE_PROJECT_DIR=" + p.ProjectDir,
+	}
+	if epic := p.Epic; epic != nil {
+		env = append(env,
+			"LATTICE_EPIC_ID="+epic.BeadID,
+			"LATTICE_EPIC_AUDIT_TYPE="+epic.AuditType,
+			"LATTICE_EPIC_NAME="+epic.AuditName,
+			"LATTICE_EPIC_TARGET="+epic.Target,
+			"LATTICE_EPIC_STATUS="+epic.Status,
+		)
+	}
+	if role := p.Role; role != nil {
+		env = append(env,
+			"LATTICE_ROLE_ID="+role.BeadID,
+			"LATTICE_ROLE_CODE_NAME="+role.CodeName,
+			"LATTICE_ROLE_TITLE="+role.Title,
+			"LATTICE_ROLE_STATUS="+role.Status,
+			"LATTICE_ROLE_ATTEMPT="+strconv.Itoa(role.Attempt),
+			"LATTICE_ROLE_WINDOW="+role.TmuxWindow,
+			"LATTICE_ROLE_DIR="+role.SessionDir,
+		)
+		if role.ExitCode != nil {
+			env = append(env, "LATTICE_ROLE_EXIT_CODE="+strconv.Itoa(*role.ExitCode))
+		}
+	}
+
+	return env
+}
+
+// Runner runs hook commands in the project directory and appends their output
+// to a log file.
+type Runner struct {
+	LogPath string
+	Timeout time.Duration
+	Now     func() time.Time
+
+	runCommand func(ctx context.Context, dir, command string, env []string, stdin []byte) ([]byte, error)
+}
+
+// NewRunner returns a runner that logs to logPath and stops each command after timeout.
+func NewRunner(logPath string, timeout time.Duration) Runner {
+	return Runner{LogPath: logPath, Timeout: timeout, Now: time.Now, runCommand: runShellCommand}
+}
+
+// Run executes command for payload and logs its combined output. A command that
+// fails or times out returns an error after its output is logged.
+func (r Runner) Run(command string, payload Payload) error {
+	stdin, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode hook payload: %w", err)
+	}
+
+	ctx := context.Background()
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	run := r.runCommand
+	if run == nil {
+		run = runShellCommand
+	}
+
+	start := r.now()
+	output, runErr := run(ctx, payload.ProjectDir, command, payload.Env(), stdin)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		runErr = fmt.Errorf("timed out after %s", r.Timeout)
+	}
+
+	if err := r.log(start, command, payload, output, runErr); err != nil {
+		return err
+	}
+	if runErr != nil {
+		return fmt.Errorf("hook %s %q: %w", payload.Event, command, runErr)
+	}
+
+	return nil
+}
+
+func (r Runner) log(start time.Time, command string, payload Payload, output []byte, runErr error) error {
+	if r.LogPath == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(r.LogPath), 0o755); err != nil {
+		return fmt.Errorf("create hook log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(r.LogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open hook log: %w", err)
+	}
+	defer file.Close()
+
+	subject := payload.RunID
+	switch {
+	case payload.Role != nil:
+		subject = payload.Role.BeadID
+	case payload.Epic != nil:
+		subject = payload.Epic.BeadID
+	}
+	outcome := "ok"
+	if runErr != nil {
+		outcome = runErr.Error()
+	}
+
+	var entry bytes.Buffer
+	fmt.Fprintf(&entry, "%s %s %s: %s (%s, %s)\n", start.UTC().Format(time.RFC3339), payload.Event, subject, command, outcome, r.now().Sub(start).Round(time.Millisecond))
+	if len(output) > 0 {
+		entry.Write(output)
+		if !bytes.HasSuffix(output, []byte("\n")) {
+			entry.WriteByte('\n')
+		}
+	}
+	if _, err := file.Write(entry.Bytes()); err != nil {
+		return fmt.Errorf("write hook log: %w", err)
+	}
+
+	return nil
+}
+
+func (r Runner) now() time.Time {
+	if r.Now == nil {
+		return time.Now()
+	}
+
+	return r.Now()
+}
+
+// waitDelay bounds how long a cancelled hook's output is read after the
+// hook is killed.
+const waitDelay = time.Second
+
+// runShellCommand runs command through the platform shell with env added to
+// the current environment. When ctx ends, the shell and every process it
+// started are killed.
+func runShellCommand(ctx context.Context, dir, command string, env []string, stdin []byte) ([]byte, error) {
+	name, args := "sh", []string{"-c", command}
+	if runtime.GOOS == "windows" {
+		name, args = "cmd", []string{"/C", command}
+	}
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	killProcessGroupOnCancel(cmd)
+	cmd.WaitDelay = waitDelay
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = bytes.NewReader(stdin)
+
+	return cmd.CombinedOutput()
+}
@@ -0,0 +1,95 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunnerPassesPayloadAndLogsOutput(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands run through sh")
+	}
+
+	projectDir := t.TempDir()
+	logPath := filepath.Join(projectDir, ".lattice", "logs", LogFileName)
+	exitCode := 3
+	payload := Payload{
+		Event:      "on_role_fail",
+		RunID:      "20260213-010203",
+		ProjectDir: projectDir,
+		Epic:       &Epic{BeadID: "e1", AuditType: "perf", Status: "blocked"},
+		Role:       &Role{BeadID: "r1", CodeName: "alpha", Status: "failed", Attempt: 2, ExitCode: &exitCode},
+	}
+
+	runner := NewRunner(logPath, time.Minute)
+	if err := runner.Run(`echo "$LATTICE_ROLE_ID $LATTICE_ROLE_EXIT_CODE $LATTICE_EPIC_STATUS"; cat`, payload); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("ReadFile() returned error: %v", err)
+	}
+	log := string(content)
+	for _, fragment := range []string{"on_role_fail r1:", "(ok,", "r1 3 blocked", `"event":"on_role_fail"`, `"code_name":"alpha"`} {
+		if !strings.Contains(log, fragment) {
+			t.Fatalf("expected hook log to contain %q, got %q", fragment, log)
+		}
+	}
+
+	if err := runner.Run("exit 4", payload); err == nil || !strings.Contains(err.Error(), `hook on_role_fail "exit 4"`) {
+		t.Fatalf("expected failing hook error, got %v", err)
+	}
+}
+
+func TestRunnerStopsCommandAfterTimeout(t *testing.T) {
+	t.Parallel()
+
+	logPath := filepath.Join(t.TempDir(), LogFileName)
+	runner := Runner{
+		LogPath: logPath,
+		Timeout: 10 * time.Millisecond,
+		runCommand: func(ctx context.Context, _, _ string, _ []string, _ []byte) ([]byte, error) {
+			<-ctx.Done()
+			return []byte("partial output"), ctx.Err()
+		},
+	}
+
+	err := runner.Run("sleep 60", Payload{Event: "on_run_complete", RunID: "20260213-010203"})
+	if err == nil || !strings.Contains(err.Error(), "timed out after 10ms") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("ReadFile() returned error: %v", err)
+	}
+	if log := string(content); !strings.Contains(log, "on_run_complete 20260213-010203: sleep 60 (timed out") || !strings.Contains(log, "partial output\n") {
+		t.Fatalf("unexpected hook log %q", log)
+	}
+}
+
+func TestRunnerTimeoutKillsChildProcesses(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks run through cmd on Windows")
+	}
+
+	runner := NewRunner(filepath.Join(t.TempDir(), LogFileName), 200*time.Millisecond)
+	start := time.Now()
+	err := runner.Run("sleep 5; echo late", Payload{Event: "on_run_complete", ProjectDir: t.TempDir()})
+	if err == nil || !strings.Contains(err.Error(), "timed out after 200ms") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the hook to stop near its timeout, took %s", elapsed)
+	}
+}
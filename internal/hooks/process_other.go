@@ -0,0 +1,9 @@
+//go:build !unix
+
+package hooks
+
+import "os/exec"
+
+// killProcessGroupOnCancel does nothing: without process groups a timeout ends
+// only the shell, and WaitDelay stops waiting for its children's output.
+func killProcessGroupOnCancel(*exec.Cmd) {}
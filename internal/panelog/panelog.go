@@ -0,0 +1,118 @@
+// Package panelog keeps per-role transcripts of tmux pane output under
+// .lattice/logs/<run>: the raw pane stream and an ANSI-stripped copy that can be
+// searched and bundled with reports.
+package panelog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"lattice/internal/config"
+)
+
+const (
+	// LogSuffix ends the stripped transcript.
+	LogSuffix = ".log"
+	// RawSuffix ends the raw pane stream, escape sequences included.
+	RawSuffix = ".raw.log"
+)
+
+// Dir returns .lattice/logs/<run> for a project.
+func Dir(cwd, runID string) string {
+	return filepath.Join(config.LogsDir(cwd), runID)
+}
+
+// Paths returns the stripped and raw transcript paths of the log called name.
+func Paths(cwd, runID, name string) (logPath, rawPath string) {
+	dir := Dir(cwd, runID)
+	return filepath.Join(dir, name+LogSuffix), filepath.Join(dir, name+RawSuffix)
+}
+
+// PipeCommand returns the shell command tmux pipes pane output to so it is
+// appended to rawPath.
+func PipeCommand(rawPath string) string {
+	return "cat >> '" + strings.ReplaceAll(rawPath, "'", `'"'"'`) + "'"
+}
+
+// WriteStripped rewrites logPath with the content of rawPath minus terminal
+// escape sequences. A missing raw stream is not an error.
+func WriteStripped(rawPath, logPath string) error {
+	raw, err := os.ReadFile(rawPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("read pane log: %w", err)
+	}
+
+	tmpPath := logPath + ".tmp"
+	if err := os.WriteFile(tmpPath, Strip(raw), 0o644); err != nil {
+		return fmt.Errorf("write pane log: %w", err)
+	}
+	if err := os.Rename(tmpPath, logPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("replace pane log: %w", err)
+	}
+
+	return nil
+}
+
+// Strip removes ANSI escape sequences and control characters from terminal
+// output. CRLF becomes LF and a lone CR, used to redraw a line, starts a new one.
+func Strip(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		b := data[i]
+		switch {
+		case b == 0x1b:
+			i = escapeEnd(data, i)
+		case b == '\r':
+			if i+1 < len(data) && data[i+1] == '\n' {
+				continue
+			}
+			out = append(out, '\n')
+		case b == '\n' || b == '\t' || (b >= 0x20 && b != 0x7f):
+			out = append(out, b)
+		}
+	}
+
+	return out
+}
+
+// escapeEnd returns the index of the last byte of the escape sequence that
+// starts at data[start].
+func escapeEnd(data []byte, start int) int {
+	if start+1 >= len(data) {
+		return start
+	}
+
+	switch data[start+1] {
+	case '[':
+		// CSI: parameters and intermediates up to a final byte in @..~.
+		for i := start + 2; i < len(data); i++ {
+			if data[i] >= 0x40 && data[i] <= 0x7e {
+				return i
+			}
+		}
+	case ']', 'P', '_', '^':
+		// OSC, DCS, APC and PM strings end at BEL or ESC \.
+		for i := start + 2; i < len(data); i++ {
+			if data[i] == 0x07 {
+				return i
+			}
+			if data[i] == 0x1b && i+1 < len(data) && data[i+1] == '\\' {
+				return i + 1
+			}
+		}
+	case '(', ')', '*', '+', '#', '%':
+		// Character set and line attribute selections take one more byte.
+		return min(start+2, len(data)-1)
+	default:
+		return start + 1
+	}
+
+	return len(data) - 1
+}
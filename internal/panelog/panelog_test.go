@@ -0,0 +1,74 @@
+package panelog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStripRemovesEscapeSequences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "colors", input: "\x1b[1;32mok\x1b[0m done\r\n", want: "ok done\n"},
+		{name: "cursor movement and private modes", input: "\x1b[?25lloop 2\x1b[2K\x1b[1G", want: "loop 2"},
+		{name: "window title", input: "\x1b]0;claude\x07ready\x1b]2;x\x1b\\", want: "ready"},
+		{name: "charset selection", input: "\x1b(Bplain", want: "plain"},
+		{name: "carriage return redraw", input: "10%\r50%\n", want: "10%\n50%\n"},
+		{name: "control characters and utf-8", input: "a\bb\tc — ✓\x00", want: "ab\tc — ✓"},
+		{name: "truncated sequence", input: "tail\x1b[3", want: "tail"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := string(Strip([]byte(tt.input))); got != tt.want {
+				t.Fatalf("Strip(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteStrippedCopiesRawLog(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	logPath, rawPath := Paths(cwd, "20260301-220000", "e1-alpha")
+	if want := filepath.Join(cwd, ".lattice", "logs", "20260301-220000", "e1-alpha.raw.log"); rawPath != want {
+		t.Fatalf("unexpected raw path %q, want %q", rawPath, want)
+	}
+
+	if err := WriteStripped(rawPath, logPath); err != nil {
+		t.Fatalf("expected a missing raw log to be ignored, got %v", err)
+	}
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Fatalf("expected no stripped log without a raw log, got %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(rawPath), 0o755); err != nil {
+		t.Fatalf("MkdirAll() returned error: %v", err)
+	}
+	if err := os.WriteFile(rawPath, []byte("\x1b[31mfailed\x1b[0m\r\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+	if err := WriteStripped(rawPath, logPath); err != nil {
+		t.Fatalf("WriteStripped() returned error: %v", err)
+	}
+	if got, _ := os.ReadFile(logPath); string(got) != "failed\n" {
+		t.Fatalf("unexpected stripped log %q", got)
+	}
+}
+
+func TestPipeCommandQuotesPath(t *testing.T) {
+	t.Parallel()
+
+	if got, want := PipeCommand("/tmp/o'neil/a.raw.log"), `cat >> '/tmp/o'"'"'neil/a.raw.log'`; got != want {
+		t.Fatalf("PipeCommand() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,330 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// reportHeadingOffset nests role report headings below the role heading (###).
+const reportHeadingOffset = 3
+
+// RenderMarkdown renders the consolidated report as Markdown.
+func RenderMarkdown(doc Document) string {
+	var b strings.Builder
+
+	b.WriteString("# Audit Report\n\n")
+	fmt.Fprintf(&b, "- Run: %s\n", fallback(doc.RunID, "-"))
+	fmt.Fprintf(&b, "- Session: %s\n", fallback(doc.SessionName, "-"))
+	if doc.WorkingDir != "" {
+		fmt.Fprintf(&b, "- Project: %s\n", doc.WorkingDir)
+	}
+	fmt.Fprintf(&b, "- Generated: %s\n", doc.GeneratedAt.UTC().Format(time.RFC3339))
+
+	b.WriteString("\n## Summary\n\n")
+	if len(doc.Epics) == 0 {
+		b.WriteString("No epics recorded for this run.\n")
+		return b.String()
+	}
+
+	b.WriteString("| Epic | Target | Status | Roles complete | Loops completed |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, epic := range doc.Epics {
+		fmt.Fprintf(&b, "| %s | %s | %s | %d/%d | %d |\n",
+			tableCell(epicLabel(epic)), tableCell(fallback(epic.Target, "-")), tableCell(epic.Status),
+			epic.RolesComplete(), len(epic.Roles), epic.LoopsCompleted())
+	}
+
+	for _, epic := range doc.Epics {
+		fmt.Fprintf(&b, "\n## %s\n\n", epicLabel(epic))
+		if epic.Target != "" {
+			fmt.Fprintf(&b, "Target: `%s`\n\n", epic.Target)
+		}
+
+		b.WriteString("| Role | Title | Status | Loops | Duration | Bead prefix |\n")
+		b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, role := range epic.Roles {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+				tableCell(fallback(role.CodeName, role.BeadID)), tableCell(fallback(role.Title, "-")), tableCell(role.Status),
+				loopProgress(role), tableCell(fallback(role.Duration, "-")), tableCell(fallback(role.BeadPrefix, "-")))
+		}
+
+		for _, role := range epic.Roles {
+			fmt.Fprintf(&b, "\n### %s\n\n", roleLabel(role))
+			if role.Transcript != "" {
+				fmt.Fprintf(&b, "Transcript: `%s`\n\n", role.Transcript)
+			}
+			if role.Report == "" {
+				b.WriteString("_No report was written._\n")
+				continue
+			}
+			b.WriteString(shiftHeadings(role.Report, reportHeadingOffset))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+type htmlEpic struct {
+	Epic
+	Label string
+}
+
+type htmlRole struct {
+	Role
+	Label  string
+	Loops  string
+	Report template.HTML
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"roles": func(epic htmlEpic) []htmlRole {
+		roles := make([]htmlRole, 0, len(epic.Roles))
+		for _, role := range epic.Roles {
+			roles = append(roles, htmlRole{
+				Role:   role,
+				Label:  roleLabel(role),
+				Loops:  loopProgress(role),
+				Report: markdownToHTML(role.Report, reportHeadingOffset),
+			})
+		}
+		return roles
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Audit Report {{ .RunID }}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem auto; max-width: 960px; padding: 0 1rem; color: #1f2328; line-height: 1.5; }
+table { border-collapse: collapse; margin: 1rem 0; width: 100%; }
+th, td { border: 1px solid #d0d7de; padding: 0.4rem 0.6rem; text-align: left; }
+th { background: #f6f8fa; }
+code, pre { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; background: #f6f8fa; }
+pre { padding: 0.75rem; overflow-x: auto; }
+.status-complete { color: #1a7f37; }
+.status-failed, .status-blocked { color: #cf222e; }
+.meta { color: #59636e; }
+.report { border-left: 3px solid #d0d7de; padding-left: 1rem; }
+</style>
+</head>
+<body>
+<h1>Audit Report</h1>
+<p class="meta">Run {{ or .RunID "-" }} · Session {{ or .SessionName "-" }}{{ if .WorkingDir }} · {{ .WorkingDir }}{{ end }} · Generated {{ .Generated }}</p>
+<h2>Summary</h2>
+{{- if not .Epics }}
+<p>No epics recorded for this run.</p>
+{{- else }}
+<table>
+<tr><th>Epic</th><th>Target</th><th>Status</th><th>Roles complete</th><th>Loops completed</th></tr>
+{{- range .Epics }}
+<tr><td><a href="#{{ .BeadID }}">{{ .Label }}</a></td><td>{{ or .Target "-" }}</td><td class="status-{{ .Status }}">{{ .Status }}</td><td>{{ .RolesComplete }}/{{ len .Roles }}</td><td>{{ .LoopsCompleted }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- range .Epics }}
+<h2 id="{{ .BeadID }}">{{ .Label }}</h2>
+{{- if .Target }}
+<p>Target: <code>{{ .Target }}</code></p>
+{{- end }}
+<table>
+<tr><th>Role</th><th>Title</th><th>Status</th><th>Loops</th><th>Duration</th><th>Bead prefix</th></tr>
+{{- range roles . }}
+<tr><td>{{ or .CodeName .BeadID }}</td><td>{{ or .Title "-" }}</td><td class="status-{{ .Status }}">{{ .Status }}</td><td>{{ .Loops }}</td><td>{{ or .Duration "-" }}</td><td>{{ or .BeadPrefix "-" }}</td></tr>
+{{- end }}
+</table>
+{{- range roles . }}
+<h3>{{ .Label }}</h3>
+{{- if .Transcript }}
+<p class="meta">Transcript: <code>{{ .Transcript }}</code></p>
+{{- end }}
+{{- if .Report }}
+<div class="report">
+{{ .Report }}
+</div>
+{{- else }}
+<p><em>No report was written.</em></p>
+{{- end }}
+{{- end }}
+{{- end }}
+</body>
+</html>
+`))
+
+// RenderHTML renders the consolidated report as a self-contained HTML page.
+func RenderHTML(doc Document) (string, error) {
+	epics := make([]htmlEpic, 0, len(doc.Epics))
+	for _, epic := range doc.Epics {
+		epics = append(epics, htmlEpic{Epic: epic, Label: epicLabel(epic)})
+	}
+
+	var buf bytes.Buffer
+	err := htmlTemplate.Execute(&buf, struct {
+		RunID       string
+		SessionName string
+		WorkingDir  string
+		Generated   string
+		Epics       []htmlEpic
+	}{
+		RunID:       doc.RunID,
+		SessionName: doc.SessionName,
+		WorkingDir:  doc.WorkingDir,
+		Generated:   doc.GeneratedAt.UTC().Format(time.RFC3339),
+		Epics:       epics,
+	})
+	if err != nil {
+		return "", fmt.Errorf("render html report: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func epicLabel(epic Epic) string {
+	return fmt.Sprintf("%s (%s)", fallback(epic.AuditName, epic.AuditType), epic.BeadID)
+}
+
+func roleLabel(role Role) string {
+	label := fallback(role.CodeName, role.BeadID)
+	if role.Title != "" {
+		label += " — " + role.Title
+	}
+
+	return label
+}
+
+func loopProgress(role Role) string {
+	if role.Intensity <= 0 {
+		return fmt.Sprintf("%d", role.CurrentLoop)
+	}
+
+	return fmt.Sprintf("%d/%d", role.CurrentLoop, role.Intensity)
+}
+
+func tableCell(value string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(value, "|", `\|`), "\n", " ")
+}
+
+var headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+
+// shiftHeadings demotes Markdown headings outside code fences by offset levels, capped at h6.
+func shiftHeadings(markdown string, offset int) string {
+	lines := strings.Split(markdown, "\n")
+	inFence := false
+	for idx, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if match := headingPattern.FindStringSubmatch(line); match != nil {
+			level := len(match[1]) + offset
+			if level > 6 {
+				level = 6
+			}
+			lines[idx] = strings.Repeat("#", level) + " " + match[2]
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+var (
+	inlineCodePattern = regexp.MustCompile("`([^`]+)`")
+	boldPattern       = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	orderedItem       = regexp.MustCompile(`^\d+\.\s+(.*)$`)
+)
+
+// markdownToHTML converts the subset of Markdown scribes write (headings, lists,
+// paragraphs, fenced code, inline code and bold) into escaped HTML.
+func markdownToHTML(markdown string, headingOffset int) template.HTML {
+	var b strings.Builder
+	var paragraph []string
+	listTag := ""
+	inFence := false
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + inlineHTML(strings.Join(paragraph, " ")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if listTag != "" {
+			b.WriteString("</" + listTag + ">\n")
+			listTag = ""
+		}
+	}
+	openList := func(tag string) {
+		if listTag != tag {
+			closeList()
+			b.WriteString("<" + tag + ">\n")
+			listTag = tag
+		}
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			flushParagraph()
+			closeList()
+			if inFence {
+				b.WriteString("</code></pre>\n")
+			} else {
+				b.WriteString("<pre><code>")
+			}
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			b.WriteString(html.EscapeString(line) + "\n")
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flushParagraph()
+			closeList()
+		case headingPattern.MatchString(trimmed):
+			flushParagraph()
+			closeList()
+			match := headingPattern.FindStringSubmatch(trimmed)
+			level := len(match[1]) + headingOffset
+			if level > 6 {
+				level = 6
+			}
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", level, inlineHTML(match[2]), level)
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* "):
+			flushParagraph()
+			openList("ul")
+			b.WriteString("<li>" + inlineHTML(strings.TrimSpace(trimmed[2:])) + "</li>\n")
+		case orderedItem.MatchString(trimmed):
+			flushParagraph()
+			openList("ol")
+			b.WriteString("<li>" + inlineHTML(orderedItem.FindStringSubmatch(trimmed)[1]) + "</li>\n")
+		default:
+			closeList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+
+	flushParagraph()
+	closeList()
+	if inFence {
+		b.WriteString("</code></pre>\n")
+	}
+
+	return template.HTML(b.String())
+}
+
+func inlineHTML(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = inlineCodePattern.ReplaceAllString(escaped, "<code>$1</code>")
+	return boldPattern.ReplaceAllString(escaped, "<strong>$1</strong>")
+}
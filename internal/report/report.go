@@ -0,0 +1,264 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"lattice/internal/config"
+	"lattice/internal/panelog"
+	"lattice/internal/teams"
+)
+
+const (
+	// MarkdownFileName is the consolidated Markdown report written for a run.
+	MarkdownFileName = "report.md"
+	// HTMLFileName is the consolidated self-contained HTML report written for a run.
+	HTMLFileName = "report.html"
+)
+
+// Document is the consolidated report for one run.
+type Document struct {
+	RunID       string
+	SessionName string
+	WorkingDir  string
+	GeneratedAt time.Time
+	Epics       []Epic
+}
+
+// Epic groups role reports for one audit epic.
+type Epic struct {
+	BeadID    string
+	AuditType string
+	AuditName string
+	Target    string
+	Status    string
+	Roles     []Role
+}
+
+// Role is one role's report and session metadata.
+type Role struct {
+	BeadID      string
+	CodeName    string
+	Title       string
+	BeadPrefix  string
+	Status      string
+	CurrentLoop int
+	Intensity   int
+	// Duration is how long the role's latest attempt ran, e.g. "42m10s".
+	Duration string
+	// Transcript is the project-relative path of the role's stripped pane log.
+	Transcript string
+	Report     string
+}
+
+// Paths lists the files written by Write.
+type Paths struct {
+	Markdown string
+	HTML     string
+}
+
+// RolesComplete counts roles that finished successfully.
+func (e Epic) RolesComplete() int {
+	count := 0
+	for _, role := range e.Roles {
+		if role.Status == "complete" {
+			count++
+		}
+	}
+
+	return count
+}
+
+// LoopsCompleted sums the loops every role in the epic has finished.
+func (e Epic) LoopsCompleted() int {
+	total := 0
+	for _, role := range e.Roles {
+		total += role.CurrentLoop
+	}
+
+	return total
+}
+
+// Collect gathers every role in cfg with its .team metadata and REPORT.md, grouped by epic.
+func Collect(cwd string, cfg *config.Config, now time.Time) (Document, error) {
+	if cfg == nil {
+		return Document{}, errors.New("config must not be nil")
+	}
+
+	doc := Document{
+		RunID:       cfg.Session.RunID,
+		SessionName: cfg.Session.Name,
+		WorkingDir:  cfg.Session.WorkingDir,
+		GeneratedAt: now,
+	}
+
+	teamsDir := config.TeamsDir(cwd, cfg.Session.RunID)
+	rolesByEpic := make(map[string][]Role)
+	orders := make(map[string]int)
+	for roleKey, roleState := range cfg.Roles {
+		role, err := collectRole(teamsDir, roleState, roleKey)
+		if err != nil {
+			return Document{}, err
+		}
+		role.Transcript, err = collectTranscript(cwd, cfg.Session.RunID, roleState)
+		if err != nil {
+			return Document{}, err
+		}
+		rolesByEpic[roleState.EpicBeadID] = append(rolesByEpic[roleState.EpicBeadID], role)
+		orders[role.BeadID] = roleState.Order
+	}
+
+	epicKeys := make([]string, 0, len(cfg.Epics))
+	for key := range cfg.Epics {
+		epicKeys = append(epicKeys, key)
+	}
+	sort.Strings(epicKeys)
+
+	for _, key := range epicKeys {
+		epicState := cfg.Epics[key]
+		epicID := fallback(epicState.BeadID, key)
+		roles := rolesByEpic[epicID]
+		sort.Slice(roles, func(i, j int) bool {
+			if orders[roles[i].BeadID] != orders[roles[j].BeadID] {
+				return orders[roles[i].BeadID] < orders[roles[j].BeadID]
+			}
+			return roles[i].BeadID < roles[j].BeadID
+		})
+
+		doc.Epics = append(doc.Epics, Epic{
+			BeadID:    epicID,
+			AuditType: epicState.AuditType,
+			AuditName: fallback(epicState.AuditName, epicState.AuditType),
+			Target:    epicState.Target,
+			Status:    fallback(epicState.Status, "unknown"),
+			Roles:     roles,
+		})
+	}
+
+	return doc, nil
+}
+
+// Write collects the report for cfg and writes Markdown and HTML files into the run directory.
+// Configs without a run id write into .lattice/.
+func Write(cwd string, cfg *config.Config, now time.Time) (Paths, error) {
+	doc, err := Collect(cwd, cfg, now)
+	if err != nil {
+		return Paths{}, err
+	}
+
+	outDir := filepath.Join(cwd, config.DirName)
+	if runID := strings.TrimSpace(cfg.Session.RunID); runID != "" {
+		outDir = config.RunDir(cwd, runID)
+	}
+
+	return WriteTo(outDir, doc)
+}
+
+// WriteTo renders doc and writes both report formats into outDir.
+func WriteTo(outDir string, doc Document) (Paths, error) {
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return Paths{}, fmt.Errorf("create report directory: %w", err)
+	}
+
+	htmlContent, err := RenderHTML(doc)
+	if err != nil {
+		return Paths{}, err
+	}
+
+	paths := Paths{
+		Markdown: filepath.Join(outDir, MarkdownFileName),
+		HTML:     filepath.Join(outDir, HTMLFileName),
+	}
+	if err := os.WriteFile(paths.Markdown, []byte(RenderMarkdown(doc)), 0o644); err != nil {
+		return Paths{}, fmt.Errorf("write markdown report: %w", err)
+	}
+	if err := os.WriteFile(paths.HTML, []byte(htmlContent), 0o644); err != nil {
+		return Paths{}, fmt.Errorf("write html report: %w", err)
+	}
+
+	return paths, nil
+}
+
+func collectRole(teamsDir string, roleState config.RoleState, roleKey string) (Role, error) {
+	role := Role{
+		BeadID:     fallback(roleState.BeadID, roleKey),
+		CodeName:   roleState.CodeName,
+		Title:      roleState.Title,
+		BeadPrefix: roleState.BeadPrefix,
+		Status:     fallback(strings.ToLower(strings.TrimSpace(roleState.Status)), "unknown"),
+		Intensity:  roleState.Intensity,
+		Duration:   roleState.Duration,
+	}
+
+	for _, dir := range teams.RoleDirNames(roleState, roleKey) {
+		roleDir := filepath.Join(teamsDir, dir)
+		teamData, err := teams.ReadTeamFile(filepath.Join(roleDir, ".team"))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return Role{}, fmt.Errorf("read role status for %s: %w", roleKey, err)
+		}
+
+		role.CurrentLoop = parseInt(teamData["current_loop"], 0)
+		role.Intensity = parseInt(teamData["intensity"], role.Intensity)
+
+		content, err := os.ReadFile(filepath.Join(roleDir, filepath.FromSlash(teams.RoleReportPath)))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return Role{}, fmt.Errorf("read report for %s: %w", roleKey, err)
+		}
+		role.Report = strings.TrimSpace(string(content))
+		break
+	}
+
+	return role, nil
+}
+
+// collectTranscript refreshes the stripped copy of a role's pane log and returns
+// its path relative to cwd, or "" when the role has no transcript.
+func collectTranscript(cwd, runID string, roleState config.RoleState) (string, error) {
+	if roleState.Log == "" {
+		return "", nil
+	}
+
+	logPath, rawPath := panelog.Paths(cwd, runID, roleState.Log)
+	if err := panelog.WriteStripped(rawPath, logPath); err != nil {
+		return "", fmt.Errorf("refresh transcript for %s: %w", roleState.Log, err)
+	}
+	if _, err := os.Stat(logPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("stat transcript for %s: %w", roleState.Log, err)
+	}
+
+	rel, err := filepath.Rel(cwd, logPath)
+	if err != nil {
+		return "", fmt.Errorf("resolve transcript path for %s: %w", roleState.Log, err)
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+func parseInt(value string, fallbackValue int) int {
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return fallbackValue
+	}
+
+	return parsed
+}
+
+func fallback(value, fallbackValue string) string {
+	if trimmed := strings.TrimSpace(value); trimmed != "" {
+		return trimmed
+	}
+
+	return fallbackValue
+}
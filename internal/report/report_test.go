@@ -0,0 +1,156 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"lattice/internal/config"
+	"lattice/internal/panelog"
+)
+
+func TestWriteAggregatesRoleReportsByEpic(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.RunID = "20260102-090000"
+	cfg.Session.Name = "lattice-20260102-090000"
+	cfg.Epics["audit-plan-001"] = config.EpicState{BeadID: "audit-plan-001", AuditType: "perf", AuditName: "Performance", Target: "internal/api", Status: "running"}
+	cfg.Roles["audit-plan-002"] = config.RoleState{BeadID: "audit-plan-002", EpicBeadID: "audit-plan-001", CodeName: "alpha", Title: "Perf Lead", BeadPrefix: "perf-alpha", Status: "complete", Order: 1, Intensity: 3, Log: "audit-plan-001-alpha", Timing: config.Timing{Duration: "42m10s"}}
+	cfg.Roles["audit-plan-003"] = config.RoleState{BeadID: "audit-plan-003", EpicBeadID: "audit-plan-001", CodeName: "bravo", Title: "Perf Reviewer", BeadPrefix: "perf-bravo", Status: "running", Order: 2, Intensity: 3}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	teamsDir := config.TeamsDir(workDir, cfg.Session.RunID)
+	writeRole(t, filepath.Join(teamsDir, "audit-plan-001-alpha"), "3", "# Findings\n\n- Slow query in `users` <table>\n")
+	writeRole(t, filepath.Join(teamsDir, "audit-plan-001-bravo"), "1", "")
+	transcriptPath, rawPath := panelog.Paths(workDir, cfg.Session.RunID, "audit-plan-001-alpha")
+	if err := os.MkdirAll(filepath.Dir(rawPath), 0o755); err != nil {
+		t.Fatalf("MkdirAll() returned error: %v", err)
+	}
+	if err := os.WriteFile(rawPath, []byte("\x1b[32mloop 3 done\x1b[0m\r\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	paths, err := Write(workDir, cfg, time.Date(2026, time.January, 2, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	runDir := config.RunDir(workDir, cfg.Session.RunID)
+	if paths.Markdown != filepath.Join(runDir, MarkdownFileName) || paths.HTML != filepath.Join(runDir, HTMLFileName) {
+		t.Fatalf("unexpected report paths: %+v", paths)
+	}
+
+	markdown := readFile(t, paths.Markdown)
+	for _, fragment := range []string{
+		"| Performance (audit-plan-001) | internal/api | running | 1/2 | 4 |",
+		"| alpha | Perf Lead | complete | 3/3 | 42m10s | perf-alpha |",
+		"### alpha — Perf Lead",
+		"Transcript: `.lattice/logs/20260102-090000/audit-plan-001-alpha.log`",
+		"#### Findings",
+		"_No report was written._",
+	} {
+		if !strings.Contains(markdown, fragment) {
+			t.Fatalf("expected markdown to include %q, got:\n%s", fragment, markdown)
+		}
+	}
+
+	if got := readFile(t, transcriptPath); got != "loop 3 done\n" {
+		t.Fatalf("expected report to refresh the stripped transcript, got %q", got)
+	}
+
+	htmlContent := readFile(t, paths.HTML)
+	for _, fragment := range []string{
+		"<h4>Findings</h4>",
+		"<li>Slow query in <code>users</code> &lt;table&gt;</li>",
+		`<a href="#audit-plan-001">Performance (audit-plan-001)</a>`,
+		"<style>",
+	} {
+		if !strings.Contains(htmlContent, fragment) {
+			t.Fatalf("expected html to include %q, got:\n%s", fragment, htmlContent)
+		}
+	}
+}
+
+func TestWriteWithoutRunIDUsesLatticeDirectory(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+
+	paths, err := Write(workDir, cfg, time.Now())
+	if err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	if paths.Markdown != filepath.Join(workDir, config.DirName, MarkdownFileName) {
+		t.Fatalf("unexpected markdown path: %q", paths.Markdown)
+	}
+	if !strings.Contains(readFile(t, paths.Markdown), "No epics recorded for this run.") {
+		t.Fatal("expected empty report to note missing epics")
+	}
+}
+
+func TestMarkdownToHTML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{name: "heading offset caps at h6", markdown: "#### Deep", want: "<h6>Deep</h6>\n"},
+		{name: "paragraph joins lines", markdown: "one\ntwo **bold**", want: "<p>one two <strong>bold</strong></p>\n"},
+		{name: "ordered list", markdown: "1. first\n2. second", want: "<ol>\n<li>first</li>\n<li>second</li>\n</ol>\n"},
+		{name: "code fence is escaped verbatim", markdown: "```go\n# not a heading <b>\n```", want: "<pre><code># not a heading &lt;b&gt;\n</code></pre>\n"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := string(markdownToHTML(tt.markdown, reportHeadingOffset)); got != tt.want {
+				t.Fatalf("markdownToHTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func writeRole(t *testing.T, roleDir, currentLoop, reportContent string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Join(roleDir, "context"), 0o755); err != nil {
+		t.Fatalf("MkdirAll() returned error: %v", err)
+	}
+	team := "current_loop=" + currentLoop + "\nintensity=3\nstatus=running\n"
+	if err := os.WriteFile(filepath.Join(roleDir, ".team"), []byte(team), 0o644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+	if reportContent == "" {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(roleDir, "context", "REPORT.md"), []byte(reportContent), 0o644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() returned error: %v", err)
+	}
+
+	return string(content)
+}
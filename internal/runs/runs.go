@@ -0,0 +1,282 @@
+package runs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"lattice/internal/config"
+	"lattice/internal/teams"
+)
+
+// IDLayout is the timestamp layout used for run ids.
+const IDLayout = "20060102-150405"
+
+// ReportFileName is the report each role session writes under context/.
+const ReportFileName = "REPORT.md"
+
+// Summary describes one archived or active run.
+type Summary struct {
+	ID          string
+	SessionName string
+	CreatedAt   string
+	WorkingDir  string
+	Status      string
+	Epics       int
+	Roles       int
+	Complete    int
+	Failed      int
+	Active      bool
+}
+
+// Detail is a run summary with its epics, roles and report locations.
+type Detail struct {
+	Summary
+	EpicDetails []EpicDetail
+}
+
+// EpicDetail describes one epic recorded in a run snapshot.
+type EpicDetail struct {
+	BeadID    string
+	AuditType string
+	AuditName string
+	Status    string
+	Roles     []RoleDetail
+}
+
+// RoleDetail describes one role recorded in a run snapshot.
+type RoleDetail struct {
+	BeadID     string
+	CodeName   string
+	Title      string
+	Status     string
+	ReportPath string
+}
+
+// NewID returns a run id for now that does not collide with an existing run directory.
+func NewID(cwd string, now time.Time) string {
+	base := now.UTC().Format(IDLayout)
+	id := base
+	for suffix := 2; ; suffix++ {
+		if _, err := os.Stat(config.RunDir(cwd, id)); errors.Is(err, os.ErrNotExist) {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d", base, suffix)
+	}
+}
+
+// Archive prepares cfg for a new run. State from a run that predates run
+// history is moved into its own run directory first, then epics, roles and
+// legacy teams are cleared so the new launch starts from an empty state.
+// The previous run keeps its snapshot under .lattice/runs/{run_id}/.
+func Archive(cwd string, cfg *config.Config) error {
+	if cfg == nil {
+		return errors.New("config must not be nil")
+	}
+
+	if strings.TrimSpace(cfg.Session.RunID) == "" && hasState(cfg) {
+		runID := legacyRunID(cwd, cfg)
+		if err := os.MkdirAll(config.RunDir(cwd, runID), 0o755); err != nil {
+			return fmt.Errorf("create run directory: %w", err)
+		}
+
+		legacyTeams := config.TeamsDir(cwd, "")
+		if _, err := os.Stat(legacyTeams); err == nil {
+			if err := os.Rename(legacyTeams, config.TeamsDir(cwd, runID)); err != nil {
+				return fmt.Errorf("move legacy team directories: %w", err)
+			}
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("stat legacy team directories: %w", err)
+		}
+
+		cfg.Session.RunID = runID
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("save legacy run snapshot: %w", err)
+		}
+	}
+
+	cfg.Teams = map[string]config.TeamState{}
+	cfg.Epics = map[string]config.EpicState{}
+	cfg.Roles = map[string]config.RoleState{}
+	return nil
+}
+
+// List returns every run with a config snapshot, newest first.
+func List(cwd string) ([]Summary, error) {
+	runsDir := filepath.Join(cwd, config.DirName, config.RunsDirName)
+	entries, err := os.ReadDir(runsDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read runs directory: %w", err)
+	}
+
+	activeID := activeRunID(cwd)
+	summaries := make([]Summary, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(runsDir, entry.Name(), config.ConfigFileName)); err != nil {
+			continue
+		}
+
+		cfg, err := config.LoadRun(cwd, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("load run %s: %w", entry.Name(), err)
+		}
+
+		summary := summarize(entry.Name(), cfg)
+		summary.Active = summary.ID == activeID
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].ID > summaries[j].ID
+	})
+
+	return summaries, nil
+}
+
+// Show loads the snapshot for one run and resolves role report paths.
+func Show(cwd, runID string) (Detail, error) {
+	runID = strings.TrimSpace(runID)
+	cfg, err := config.LoadRun(cwd, runID)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Detail{}, fmt.Errorf("run %q not found", runID)
+		}
+		return Detail{}, fmt.Errorf("load run %s: %w", runID, err)
+	}
+
+	summary := summarize(runID, cfg)
+	summary.Active = runID == activeRunID(cwd)
+	detail := Detail{Summary: summary}
+
+	epicKeys := make([]string, 0, len(cfg.Epics))
+	for key := range cfg.Epics {
+		epicKeys = append(epicKeys, key)
+	}
+	sort.Strings(epicKeys)
+
+	teamsDir := config.TeamsDir(cwd, runID)
+	for _, key := range epicKeys {
+		epic := cfg.Epics[key]
+		epicDetail := EpicDetail{
+			BeadID:    epic.BeadID,
+			AuditType: epic.AuditType,
+			AuditName: epic.AuditName,
+			Status:    epic.Status,
+		}
+
+		roleKeys := make([]string, 0)
+		for roleKey, role := range cfg.Roles {
+			if role.EpicBeadID == epic.BeadID {
+				roleKeys = append(roleKeys, roleKey)
+			}
+		}
+		sort.Slice(roleKeys, func(i, j int) bool {
+			left, right := cfg.Roles[roleKeys[i]], cfg.Roles[roleKeys[j]]
+			if left.Order != right.Order {
+				return left.Order < right.Order
+			}
+			return roleKeys[i] < roleKeys[j]
+		})
+
+		for _, roleKey := range roleKeys {
+			role := cfg.Roles[roleKey]
+			epicDetail.Roles = append(epicDetail.Roles, RoleDetail{
+				BeadID:     roleKey,
+				CodeName:   role.CodeName,
+				Title:      role.Title,
+				Status:     role.Status,
+				ReportPath: findReport(teamsDir, role, roleKey),
+			})
+		}
+
+		detail.EpicDetails = append(detail.EpicDetails, epicDetail)
+	}
+
+	return detail, nil
+}
+
+func summarize(runID string, cfg *config.Config) Summary {
+	summary := Summary{
+		ID:          runID,
+		SessionName: cfg.Session.Name,
+		CreatedAt:   cfg.Session.CreatedAt,
+		WorkingDir:  cfg.Session.WorkingDir,
+		Epics:       len(cfg.Epics),
+		Roles:       len(cfg.Roles),
+	}
+
+	unfinished := 0
+	cancelled := 0
+	for _, role := range cfg.Roles {
+		switch strings.ToLower(strings.TrimSpace(role.Status)) {
+		case "complete":
+			summary.Complete++
+		case "failed":
+			summary.Failed++
+		case "cancelled":
+			cancelled++
+		case "skipped":
+			continue
+		default:
+			unfinished++
+		}
+	}
+
+	switch {
+	case summary.Roles == 0:
+		summary.Status = "empty"
+	case unfinished > 0:
+		summary.Status = "running"
+	case summary.Failed > 0:
+		summary.Status = "failed"
+	case cancelled > 0:
+		summary.Status = "cancelled"
+	default:
+		summary.Status = "complete"
+	}
+
+	return summary
+}
+
+func findReport(teamsDir string, role config.RoleState, roleKey string) string {
+	for _, dir := range teams.RoleDirNames(role, roleKey) {
+		reportPath := filepath.Join(teamsDir, dir, "context", ReportFileName)
+		if _, err := os.Stat(reportPath); err == nil {
+			return reportPath
+		}
+	}
+
+	return ""
+}
+
+func activeRunID(cwd string) string {
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(cfg.Session.RunID)
+}
+
+func hasState(cfg *config.Config) bool {
+	return len(cfg.Epics) > 0 || len(cfg.Roles) > 0 || len(cfg.Teams) > 0
+}
+
+func legacyRunID(cwd string, cfg *config.Config) string {
+	createdAt, err := time.Parse(time.RFC3339, strings.TrimSpace(cfg.Session.CreatedAt))
+	if err != nil {
+		createdAt = time.Unix(0, 0)
+	}
+
+	return NewID(cwd, createdAt)
+}
@@ -0,0 +1,130 @@
+package runs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"lattice/internal/config"
+)
+
+func TestNewIDAvoidsExistingRunDirectories(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	now := time.Date(2026, time.March, 4, 5, 6, 7, 0, time.UTC)
+	if got := NewID(workDir, now); got != "20260304-050607" {
+		t.Fatalf("unexpected run id: %q", got)
+	}
+
+	if err := os.MkdirAll(config.RunDir(workDir, "20260304-050607"), 0o755); err != nil {
+		t.Fatalf("MkdirAll() returned error: %v", err)
+	}
+	if got := NewID(workDir, now); got != "20260304-050607-2" {
+		t.Fatalf("expected suffixed run id, got %q", got)
+	}
+}
+
+func TestArchiveMovesLegacyStateIntoRunDirectory(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.Name = "lattice-20260101-090000"
+	cfg.Session.CreatedAt = "2026-01-01T09:00:00Z"
+	cfg.Epics["perf"] = config.EpicState{BeadID: "audit-plan-001", AuditType: "perf", Status: "complete"}
+	cfg.Roles["audit-plan-002"] = config.RoleState{BeadID: "audit-plan-002", EpicBeadID: "audit-plan-001", CodeName: "alpha", BeadPrefix: "perf-alpha", Status: "complete"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+	writeReport(t, config.TeamsDir(workDir, ""), "perf-alpha")
+
+	if err := Archive(workDir, cfg); err != nil {
+		t.Fatalf("Archive() returned error: %v", err)
+	}
+	if len(cfg.Epics) != 0 || len(cfg.Roles) != 0 {
+		t.Fatalf("expected archive to clear active state, got %d epics and %d roles", len(cfg.Epics), len(cfg.Roles))
+	}
+	if _, err := os.Stat(config.TeamsDir(workDir, "")); !os.IsNotExist(err) {
+		t.Fatalf("expected legacy teams directory to be moved, stat err=%v", err)
+	}
+
+	detail, err := Show(workDir, "20260101-090000")
+	if err != nil {
+		t.Fatalf("Show() returned error: %v", err)
+	}
+	if detail.Status != "complete" || detail.Roles != 1 {
+		t.Fatalf("unexpected archived summary: %+v", detail.Summary)
+	}
+	if len(detail.EpicDetails) != 1 || len(detail.EpicDetails[0].Roles) != 1 {
+		t.Fatalf("unexpected archived epics: %+v", detail.EpicDetails)
+	}
+	wantReport := filepath.Join(config.TeamsDir(workDir, "20260101-090000"), "perf-alpha", "context", ReportFileName)
+	if got := detail.EpicDetails[0].Roles[0].ReportPath; got != wantReport {
+		t.Fatalf("unexpected report path: got %q want %q", got, wantReport)
+	}
+}
+
+func TestListReturnsRunsNewestFirstAndMarksActive(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+
+	for _, run := range []struct {
+		id     string
+		status string
+	}{
+		{id: "20260101-090000", status: "failed"},
+		{id: "20260102-090000", status: "running"},
+	} {
+		cfg.Session.RunID = run.id
+		cfg.Session.Name = "lattice-" + run.id
+		cfg.Epics = map[string]config.EpicState{"perf": {BeadID: "audit-plan-001", AuditType: "perf"}}
+		cfg.Roles = map[string]config.RoleState{"audit-plan-002": {EpicBeadID: "audit-plan-001", Status: run.status}}
+		if err := cfg.Save(); err != nil {
+			t.Fatalf("Save() returned error: %v", err)
+		}
+	}
+
+	summaries, err := List(workDir)
+	if err != nil {
+		t.Fatalf("List() returned error: %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(summaries))
+	}
+	if summaries[0].ID != "20260102-090000" || !summaries[0].Active || summaries[0].Status != "running" {
+		t.Fatalf("unexpected newest run: %+v", summaries[0])
+	}
+	if summaries[1].ID != "20260101-090000" || summaries[1].Active || summaries[1].Status != "failed" {
+		t.Fatalf("unexpected archived run: %+v", summaries[1])
+	}
+}
+
+func TestShowReturnsErrorForUnknownRun(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Show(t.TempDir(), "missing"); err == nil {
+		t.Fatal("expected error for unknown run")
+	}
+}
+
+func writeReport(t *testing.T, teamsDir, dirName string) {
+	t.Helper()
+
+	contextDir := filepath.Join(teamsDir, dirName, "context")
+	if err := os.MkdirAll(contextDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll() returned error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(contextDir, ReportFileName), []byte("# Report\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+}
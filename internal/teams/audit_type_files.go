@@ -0,0 +1,195 @@
+package teams
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"lattice/internal/config"
+)
+
+// codeNamePattern keeps code names safe for team directory and tmux window names.
+var codeNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// AuditTypesDirName holds user-defined audit type files, one *.toml per type.
+const AuditTypesDirName = "audit-types"
+
+// AuditTypeDirs returns the directories LoadAuditTypes reads, lowest precedence
+// first: the user config dir (e.g. ~/.config/lattice/audit-types) and then
+// the project's .lattice/audit-types.
+func AuditTypeDirs(cwd string) []string {
+	dirs := make([]string, 0, 2)
+	if userDir, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(userDir, "lattice", AuditTypesDirName))
+	}
+
+	return append(dirs, filepath.Join(cwd, config.DirName, AuditTypesDirName))
+}
+
+// LoadAuditTypes returns the built-in audit types merged with audit type files
+// from AuditTypeDirs. A file whose id matches an earlier type replaces it in place;
+// new ids are appended in file name order.
+func LoadAuditTypes(cwd string) ([]AuditType, error) {
+	return loadAuditTypes(AuditTypes, AuditTypeDirs(cwd)...)
+}
+
+func loadAuditTypes(builtins []AuditType, dirs ...string) ([]AuditType, error) {
+	merged := append([]AuditType(nil), builtins...)
+	index := make(map[string]int, len(merged))
+	for idx, auditType := range merged {
+		index[auditType.ID] = idx
+	}
+
+	for _, dir := range dirs {
+		fileTypes, err := readAuditTypeDir(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, auditType := range fileTypes {
+			if idx, ok := index[auditType.ID]; ok {
+				merged[idx] = auditType
+				continue
+			}
+			index[auditType.ID] = len(merged)
+			merged = append(merged, auditType)
+		}
+	}
+
+	if err := ValidateAuditTypes(merged); err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
+
+func readAuditTypeDir(dir string) ([]AuditType, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.toml"))
+	if err != nil {
+		return nil, fmt.Errorf("list audit types in %s: %w", dir, err)
+	}
+	sort.Strings(paths)
+
+	auditTypes := make([]AuditType, 0, len(paths))
+	seen := make(map[string]string, len(paths))
+	for _, path := range paths {
+		auditType, err := readAuditTypeFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if previous, ok := seen[auditType.ID]; ok {
+			return nil, fmt.Errorf("audit type %q is defined in both %s and %s", auditType.ID, previous, path)
+		}
+		seen[auditType.ID] = path
+		auditTypes = append(auditTypes, auditType)
+	}
+
+	return auditTypes, nil
+}
+
+func readAuditTypeFile(path string) (AuditType, error) {
+	var auditType AuditType
+	meta, err := toml.DecodeFile(path, &auditType)
+	if err != nil {
+		return AuditType{}, fmt.Errorf("decode audit type %s: %w", path, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return AuditType{}, fmt.Errorf("decode audit type %s: unknown field %q", path, undecoded[0].String())
+	}
+
+	if err := validateAuditType(auditType); err != nil {
+		return AuditType{}, fmt.Errorf("audit type %s: %w", path, err)
+	}
+
+	return auditType, nil
+}
+
+// ValidateAuditTypes checks that audit types have unique ids and that no two
+// roles planned for the same agent count share a bead prefix.
+func ValidateAuditTypes(auditTypes []AuditType) error {
+	ids := make(map[string]struct{}, len(auditTypes))
+	prefixes := make(map[int]map[string]string)
+	for _, auditType := range auditTypes {
+		if err := validateAuditType(auditType); err != nil {
+			return err
+		}
+		if _, ok := ids[auditType.ID]; ok {
+			return fmt.Errorf("duplicate audit type id %q", auditType.ID)
+		}
+		ids[auditType.ID] = struct{}{}
+
+		for _, roleConfig := range auditType.RoleConfigs {
+			if prefixes[roleConfig.AgentCount] == nil {
+				prefixes[roleConfig.AgentCount] = make(map[string]string)
+			}
+			for _, role := range roleConfig.Roles {
+				prefix := auditType.BeadPrefix + "-" + slugify(role.Title)
+				if owner, ok := prefixes[roleConfig.AgentCount][prefix]; ok {
+					return fmt.Errorf("role bead prefix %q for %d agents is used by both %q and %q", prefix, roleConfig.AgentCount, owner, auditType.ID)
+				}
+				prefixes[roleConfig.AgentCount][prefix] = auditType.ID
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateAuditType(auditType AuditType) error {
+	switch {
+	case strings.TrimSpace(auditType.ID) == "":
+		return errors.New("audit type id must not be empty")
+	case strings.TrimSpace(auditType.Name) == "":
+		return fmt.Errorf("audit type %q must define a name", auditType.ID)
+	case strings.TrimSpace(auditType.BeadPrefix) == "":
+		return fmt.Errorf("audit type %q must define a bead prefix", auditType.ID)
+	case len(auditType.RoleConfigs) == 0:
+		return fmt.Errorf("audit type %q must define at least one role config", auditType.ID)
+	}
+
+	agentCounts := make(map[int]struct{}, len(auditType.RoleConfigs))
+	for _, roleConfig := range auditType.RoleConfigs {
+		if roleConfig.AgentCount < 1 {
+			return fmt.Errorf("audit type %q has a role config with agent count %d", auditType.ID, roleConfig.AgentCount)
+		}
+		if _, ok := agentCounts[roleConfig.AgentCount]; ok {
+			return fmt.Errorf("audit type %q defines agent count %d more than once", auditType.ID, roleConfig.AgentCount)
+		}
+		agentCounts[roleConfig.AgentCount] = struct{}{}
+
+		if len(roleConfig.Roles) != roleConfig.AgentCount {
+			return fmt.Errorf("audit type %q role config for %d agents defines %d roles", auditType.ID, roleConfig.AgentCount, len(roleConfig.Roles))
+		}
+		codeNames := make(map[string]struct{}, len(roleConfig.Roles))
+		for _, role := range roleConfig.Roles {
+			if strings.TrimSpace(role.CodeName) == "" || strings.TrimSpace(role.Title) == "" || strings.TrimSpace(role.Guidance) == "" {
+				return fmt.Errorf("audit type %q role config for %d agents has an incomplete role", auditType.ID, roleConfig.AgentCount)
+			}
+			if !codeNamePattern.MatchString(role.CodeName) {
+				return fmt.Errorf("audit type %q code name %q must use lowercase letters, digits and dashes", auditType.ID, role.CodeName)
+			}
+			if _, ok := codeNames[role.CodeName]; ok {
+				return fmt.Errorf("audit type %q role config for %d agents repeats code name %q", auditType.ID, roleConfig.AgentCount, role.CodeName)
+			}
+			codeNames[role.CodeName] = struct{}{}
+			if err := role.AgentParams.Validate(); err != nil {
+				return fmt.Errorf("audit type %q role %q: %w", auditType.ID, role.CodeName, err)
+			}
+		}
+		if err := checkRoleGraph(roleConfig.Roles); err != nil {
+			return fmt.Errorf("audit type %q role config for %d agents: %w", auditType.ID, roleConfig.AgentCount, err)
+		}
+	}
+
+	if err := auditType.Retry.Validate(); err != nil {
+		return fmt.Errorf("audit type %q: %w", auditType.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package teams
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const graphQLAuditType = `
+id = "graphql"
+name = "GraphQL Schema Audit"
+bead_prefix = "gql"
+description = "Review schema design and resolver cost."
+focus_areas = ["schema evolution", "resolver fan-out"]
+
+[[role_configs]]
+agent_count = 1
+
+[[role_configs.roles]]
+code_name = "alpha"
+title = "Schema Reviewer"
+guidance = "Review the schema for breaking changes."
+`
+
+func TestLoadAuditTypesMergesFilesOverBuiltins(t *testing.T) {
+	t.Parallel()
+
+	userDir := t.TempDir()
+	projectDir := t.TempDir()
+	writeAuditTypeFile(t, userDir, "graphql.toml", graphQLAuditType+"model = \"anthropic/claude-haiku\"\ntemperature = 0.2\nagent_flags = [\"--max-turns\", \"40\"]\n")
+	writeAuditTypeFile(t, projectDir, "perf.toml", strings.NewReplacer(`id = "graphql"`, `id = "perf"`, `"GraphQL Schema Audit"`, `"Frontend Performance"`, `"gql"`, `"perf"`).Replace(graphQLAuditType))
+	writeAuditTypeFile(t, projectDir, "notes.txt", "ignored")
+
+	auditTypes, err := loadAuditTypes(AuditTypes, userDir, projectDir, filepath.Join(projectDir, "missing"))
+	if err != nil {
+		t.Fatalf("loadAuditTypes() returned error: %v", err)
+	}
+
+	if len(auditTypes) != len(AuditTypes)+1 {
+		t.Fatalf("expected %d audit types, got %d", len(AuditTypes)+1, len(auditTypes))
+	}
+	if auditTypes[0].ID != "perf" || auditTypes[0].Name != "Frontend Performance" {
+		t.Fatalf("expected project file to override perf in place, got %+v", auditTypes[0])
+	}
+	graphQL := auditTypes[len(auditTypes)-1]
+	if graphQL.ID != "graphql" || graphQL.BeadPrefix != "gql" || len(graphQL.FocusAreas) != 2 {
+		t.Fatalf("unexpected custom audit type: %+v", graphQL)
+	}
+	if got := graphQL.RoleConfigs[0].Roles[0].Title; got != "Schema Reviewer" {
+		t.Fatalf("unexpected role title: %q", got)
+	}
+	if got := graphQL.RoleConfigs[0].Roles[0].AgentParams; got.Model != "anthropic/claude-haiku" || got.TemperatureText() != "0.2" || strings.Join(got.AgentFlags, " ") != "--max-turns 40" {
+		t.Fatalf("unexpected role agent params: %+v", got)
+	}
+	if AuditTypes[0].Name == "Frontend Performance" {
+		t.Fatal("loading must not modify the built-in registry")
+	}
+}
+
+func TestLoadAuditTypesRejectsInvalidFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "missing id",
+			files:   map[string]string{"a.toml": strings.Replace(graphQLAuditType, `id = "graphql"`, "", 1)},
+			wantErr: "audit type id must not be empty",
+		},
+		{
+			name:    "unknown field",
+			files:   map[string]string{"a.toml": "agents = 2\n" + graphQLAuditType},
+			wantErr: `unknown field "agents"`,
+		},
+		{
+			name:    "role count mismatch",
+			files:   map[string]string{"a.toml": strings.Replace(graphQLAuditType, "agent_count = 1", "agent_count = 2", 1)},
+			wantErr: "defines 1 roles",
+		},
+		{
+			name:    "self dependency",
+			files:   map[string]string{"a.toml": graphQLAuditType + "after = [\"alpha\"]\n"},
+			wantErr: `role "alpha" depends on itself`,
+		},
+		{
+			name:    "bad retry backoff",
+			files:   map[string]string{"a.toml": graphQLAuditType + "\n[retry]\nmax_attempts = 2\nbackoff = \"later\"\n"},
+			wantErr: `parse retry backoff "later"`,
+		},
+		{
+			name:    "temperature out of range",
+			files:   map[string]string{"a.toml": graphQLAuditType + "temperature = 3.5\n"},
+			wantErr: `audit type "graphql" role "alpha": temperature 3.5 must be between 0 and 2`,
+		},
+		{
+			name:    "duplicate id in one directory",
+			files:   map[string]string{"a.toml": graphQLAuditType, "b.toml": graphQLAuditType},
+			wantErr: `audit type "graphql" is defined in both`,
+		},
+		{
+			name: "duplicate role bead prefix",
+			files: map[string]string{
+				"a.toml": graphQLAuditType,
+				"b.toml": strings.Replace(graphQLAuditType, `id = "graphql"`, `id = "graphql-v2"`, 1),
+			},
+			wantErr: `role bead prefix "gql-schema-reviewer" for 1 agents is used by both "graphql" and "graphql-v2"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeAuditTypeFile(t, dir, name, content)
+			}
+
+			_, err := loadAuditTypes(AuditTypes, dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateAuditTypesAcceptsBuiltins(t *testing.T) {
+	t.Parallel()
+
+	if err := ValidateAuditTypes(AuditTypes); err != nil {
+		t.Fatalf("ValidateAuditTypes() returned error for built-ins: %v", err)
+	}
+}
+
+func writeAuditTypeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+}
@@ -1,26 +1,43 @@
 package teams
 
+import (
+	"strings"
+
+	"lattice/internal/config"
+)
+
 // RoleDefinition describes one role assignment for a team member.
 type RoleDefinition struct {
-	CodeName string
-	Title    string
-	Guidance string
+	CodeName string `toml:"code_name"`
+	Title    string `toml:"title"`
+	Guidance string `toml:"guidance"`
+	// After lists code names in the same role config that must complete before this role starts.
+	// When no role in a config sets it, roles run one after another in order.
+	After []string `toml:"after,omitempty"`
+	// AgentParams picks the role's model, temperature, variant and extra agent
+	// flags; unset fields keep the agent's defaults.
+	config.AgentParams
 }
 
 // AgentConfigRoles defines role assignments for a specific agent count.
 type AgentConfigRoles struct {
-	AgentCount int
-	Roles      []RoleDefinition
+	AgentCount int              `toml:"agent_count"`
+	Roles      []RoleDefinition `toml:"roles"`
 }
 
 // AuditType defines an audit mode selectable by the user.
 type AuditType struct {
-	ID          string
-	Name        string
-	BeadPrefix  string
-	Description string
-	FocusAreas  []string
-	RoleConfigs []AgentConfigRoles
+	ID          string             `toml:"id"`
+	Name        string             `toml:"name"`
+	BeadPrefix  string             `toml:"bead_prefix"`
+	Description string             `toml:"description"`
+	FocusAreas  []string           `toml:"focus_areas"`
+	RoleConfigs []AgentConfigRoles `toml:"role_configs"`
+	// Retry relaunches failed roles of this type unless the run sets its own policy.
+	Retry config.RetryPolicy `toml:"retry,omitempty"`
+	// Agent names the agent runtime this type's roles run with unless the run
+	// picks one; empty uses the user default.
+	Agent string `toml:"agent,omitempty"`
 }
 
 // AuditTypes is the registry of supported audit modes.
@@ -171,6 +188,16 @@ var AuditTypes = []AuditType{
 					{CodeName: "charlie", Title: "Identity and cryptography specialist", Guidance: "Deep dive on auth flows, token lifecycles, and cryptographic control correctness."},
 				},
 			},
+			{
+				AgentCount: 5,
+				Roles: []RoleDefinition{
+					{CodeName: "threat-modeler", Title: "Threat modeler", Guidance: "Map trust boundaries, entry points, and attacker goals so later roles start from the riskiest paths."},
+					{CodeName: "alpha", Title: "Senior security specialist", Guidance: "Work through the threat model, rank vulnerabilities by exploitability, and define mitigations.", After: []string{"threat-modeler"}},
+					{CodeName: "bravo", Title: "Staff application security engineer", Guidance: "Verify code paths and exploitability for the modeled threats, then propose safe refactors with tests.", After: []string{"threat-modeler"}},
+					{CodeName: "charlie", Title: "Identity and cryptography specialist", Guidance: "Deep dive on auth flows, token lifecycles, and cryptographic control correctness.", After: []string{"threat-modeler"}},
+					{CodeName: "reviewer", Title: "Security review lead", Guidance: "Challenge earlier findings, close anything unexploitable, and confirm each remaining bead has a clear fix.", After: []string{"alpha", "bravo", "charlie"}},
+				},
+			},
 		},
 	},
 	{
@@ -322,3 +349,15 @@ var AuditTypes = []AuditType{
 		},
 	},
 }
+
+// FindAuditType returns the audit type with id from auditTypes.
+func FindAuditType(auditTypes []AuditType, id string) (AuditType, bool) {
+	id = strings.TrimSpace(id)
+	for _, auditType := range auditTypes {
+		if auditType.ID == id {
+			return auditType, true
+		}
+	}
+
+	return AuditType{}, false
+}
@@ -27,18 +27,17 @@ func TestAuditTypesRegistryShape(t *testing.T) {
 			t.Fatalf("audit type %s must define 6 focus areas, got %d", auditType.ID, len(auditType.FocusAreas))
 		}
 
-		if len(auditType.RoleConfigs) != 3 {
-			t.Fatalf("audit type %s must define 3 role configs, got %d", auditType.ID, len(auditType.RoleConfigs))
+		if len(auditType.RoleConfigs) < 3 {
+			t.Fatalf("audit type %s must define at least 3 role configs, got %d", auditType.ID, len(auditType.RoleConfigs))
 		}
 
 		for idx, roleConfig := range auditType.RoleConfigs {
-			expectedAgents := idx + 1
-			if roleConfig.AgentCount != expectedAgents {
-				t.Fatalf("audit type %s role config %d expects AgentCount=%d, got %d", auditType.ID, idx, expectedAgents, roleConfig.AgentCount)
+			if idx < 3 && roleConfig.AgentCount != idx+1 {
+				t.Fatalf("audit type %s role config %d expects AgentCount=%d, got %d", auditType.ID, idx, idx+1, roleConfig.AgentCount)
 			}
 
-			if len(roleConfig.Roles) != expectedAgents {
-				t.Fatalf("audit type %s role config %d must define %d roles, got %d", auditType.ID, idx, expectedAgents, len(roleConfig.Roles))
+			if len(roleConfig.Roles) != roleConfig.AgentCount {
+				t.Fatalf("audit type %s role config %d must define %d roles, got %d", auditType.ID, idx, roleConfig.AgentCount, len(roleConfig.Roles))
 			}
 
 			for _, role := range roleConfig.Roles {
@@ -49,3 +48,32 @@ func TestAuditTypesRegistryShape(t *testing.T) {
 		}
 	}
 }
+
+func TestSecurityAuditDefinesFiveRoleChain(t *testing.T) {
+	t.Parallel()
+
+	security, ok := FindAuditType(AuditTypes, "security")
+	if !ok {
+		t.Fatal("expected security audit type")
+	}
+
+	roleConfig, ok := findRoleConfig(security, 5)
+	if !ok {
+		t.Fatal("expected security to define a 5-role config")
+	}
+	if first, last := roleConfig.Roles[0].CodeName, roleConfig.Roles[4].CodeName; first != "threat-modeler" || last != "reviewer" {
+		t.Fatalf("expected chain from threat-modeler to reviewer, got %q to %q", first, last)
+	}
+}
+
+func TestFindAuditType(t *testing.T) {
+	t.Parallel()
+
+	auditType, ok := FindAuditType(AuditTypes, " perf ")
+	if !ok || auditType.ID != "perf" {
+		t.Fatalf("expected perf audit type, got %+v ok=%v", auditType, ok)
+	}
+	if _, ok := FindAuditType(AuditTypes, "missing"); ok {
+		t.Fatal("expected unknown audit type to be missing")
+	}
+}
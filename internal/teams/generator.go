@@ -7,9 +7,11 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 
+	"lattice/internal/agent"
 	"lattice/internal/config"
 	"lattice/templates"
 )
@@ -18,6 +20,20 @@ const (
 	auditTemplateRoot       = "audit"
 	roleSessionTemplateRoot = "role-session"
 	templateExt             = ".tmpl"
+	// investigatorTemplate is copied to .opencode/agents/investigator-{codeName}.md for each active role.
+	investigatorTemplate = ".opencode/agents/investigator.md"
+	// agentTemplateDir holds agent definitions and skills in the templates; role
+	// sessions move it to their runtime's agent directory.
+	agentTemplateDir = ".opencode"
+	// roleAgent is the agent definition RoleRunScript starts.
+	roleAgent = "auditor"
+)
+
+const (
+	// RoleRunScript is the generated wrapper that starts a role session agent.
+	RoleRunScript = "run-agent.sh"
+	// RoleExitFile is written by RoleRunScript with the agent exit code and end time.
+	RoleExitFile = ".exit"
 )
 
 // Role is template-friendly role data for one active investigator.
@@ -56,6 +72,7 @@ type GenerateParams struct {
 // RoleSessionParams defines required inputs to generate a single-role session folder.
 type RoleSessionParams struct {
 	Cwd          string
+	RunID        string
 	EpicBeadID   string
 	RoleBeadID   string
 	RoleTitle    string
@@ -66,6 +83,15 @@ type RoleSessionParams struct {
 	FocusAreas   []string
 	AuditTypeID  string
 	CodeName     string
+	// PriorWork carries the output of earlier roles in the same epic.
+	PriorWork []PriorWork
+	// StartLoop is the current_loop a resumed attempt continues from.
+	StartLoop int
+	// Runtime is the agent the session runs; nil means opencode.
+	Runtime agent.Runtime
+	// AgentParams sets the model, temperature, variant and extra flags the
+	// agent runs with.
+	AgentParams config.AgentParams
 }
 
 // RoleSessionData contains values rendered into role-session templates.
@@ -79,6 +105,10 @@ type RoleSessionData struct {
 	BeadPrefix   string
 	Target       string
 	FocusAreas   []string
+	PriorWork    []PriorWork
+	StartLoop    int
+	// AgentCommand is the shell command RoleRunScript starts the agent with.
+	AgentCommand string
 }
 
 // Generate creates .lattice/teams/audit-{type}/ from embedded templates.
@@ -89,8 +119,8 @@ func Generate(params GenerateParams) (string, error) {
 	if strings.TrimSpace(params.AuditType.ID) == "" {
 		return "", fmt.Errorf("audit type id must not be empty")
 	}
-	if params.AgentCount < 1 || params.AgentCount > 3 {
-		return "", fmt.Errorf("agent count must be between 1 and 3")
+	if params.AgentCount < 1 {
+		return "", fmt.Errorf("agent count must be at least 1")
 	}
 	if params.Intensity < 1 {
 		return "", fmt.Errorf("intensity must be at least 1")
@@ -142,9 +172,12 @@ func Generate(params GenerateParams) (string, error) {
 		}
 		relPath = filepath.ToSlash(relPath)
 
-		if shouldSkipInvestigator(relPath, params.AgentCount) {
-			if entry.IsDir() {
-				return fs.SkipDir
+		if relPath == investigatorTemplate {
+			for _, role := range roles {
+				outputPath := filepath.Join(teamDir, ".opencode", "agents", "investigator-"+role.CodeName+".md")
+				if err := copyStaticFile(path, outputPath); err != nil {
+					return err
+				}
 			}
 			return nil
 		}
@@ -186,8 +219,17 @@ func GenerateRoleSession(params RoleSessionParams) (string, error) {
 		return "", fmt.Errorf("bead prefix must not be empty")
 	}
 
-	teamName := strings.TrimSpace(params.AuditTypeID) + "-" + strings.TrimSpace(params.CodeName)
-	teamDir := filepath.Join(params.Cwd, config.DirName, "teams", teamName)
+	runtime := params.Runtime
+	if runtime == nil {
+		runtime = agent.Opencode{}
+	}
+	agentCommand, err := runtime.RoleCommand(roleAgent, params.AgentParams)
+	if err != nil {
+		return "", fmt.Errorf("build %s command: %w", runtime.Name(), err)
+	}
+
+	teamName := RoleDirName(params.EpicBeadID, params.AuditTypeID, params.CodeName)
+	teamDir := filepath.Join(config.TeamsDir(params.Cwd, params.RunID), teamName)
 
 	if err := os.RemoveAll(teamDir); err != nil {
 		return "", fmt.Errorf("reset team directory: %w", err)
@@ -206,6 +248,9 @@ func GenerateRoleSession(params RoleSessionParams) (string, error) {
 		BeadPrefix:   strings.TrimSpace(params.BeadPrefix),
 		Target:       params.Target,
 		FocusAreas:   append([]string(nil), params.FocusAreas...),
+		PriorWork:    append([]PriorWork(nil), params.PriorWork...),
+		StartLoop:    params.StartLoop,
+		AgentCommand: agentCommand,
 	}
 
 	if err := fs.WalkDir(templates.RoleSessionTemplate, roleSessionTemplateRoot, func(path string, entry fs.DirEntry, walkErr error) error {
@@ -223,6 +268,9 @@ func GenerateRoleSession(params RoleSessionParams) (string, error) {
 		relPath = filepath.ToSlash(relPath)
 
 		outputRel := strings.TrimSuffix(relPath, templateExt)
+		if rest, ok := strings.CutPrefix(outputRel, agentTemplateDir); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+			outputRel = runtime.AgentDir() + rest
+		}
 		outputPath := filepath.Join(teamDir, filepath.FromSlash(outputRel))
 
 		if entry.IsDir() {
@@ -238,9 +286,155 @@ func GenerateRoleSession(params RoleSessionParams) (string, error) {
 		return "", fmt.Errorf("generate role session files: %w", err)
 	}
 
+	if err := setAgentFrontmatter(filepath.Join(teamDir, filepath.FromSlash(runtime.AgentDir()), "agents"), params.AgentParams); err != nil {
+		return "", err
+	}
+
+	if name, content := runtime.ConfigFile(); name != "" {
+		configPath := filepath.Join(teamDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+			return "", fmt.Errorf("create directory for %q: %w", configPath, err)
+		}
+		if err := os.WriteFile(configPath, content, 0o644); err != nil {
+			return "", fmt.Errorf("write %s config: %w", runtime.Name(), err)
+		}
+	}
+
 	return teamDir, nil
 }
 
+// setAgentFrontmatter adds the model and temperature of params to the YAML
+// frontmatter of every agent definition in agentsDir.
+func setAgentFrontmatter(agentsDir string, params config.AgentParams) error {
+	var fields, keys []string
+	if params.Model != "" {
+		fields = append(fields, "model: "+strconv.Quote(params.Model))
+		keys = append(keys, "model:")
+	}
+	if params.Temperature != nil {
+		fields = append(fields, "temperature: "+params.TemperatureText())
+		keys = append(keys, "temperature:")
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(agentsDir, "*.md"))
+	if err != nil {
+		return fmt.Errorf("list agent definitions: %w", err)
+	}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read agent definition %q: %w", path, err)
+		}
+		body, ok := strings.CutPrefix(string(content), "---\n")
+		if !ok {
+			continue
+		}
+		updated := "---\n" + strings.Join(fields, "\n") + "\n" + dropFrontmatterKeys(body, keys)
+		if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+			return fmt.Errorf("write agent definition %q: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// dropFrontmatterKeys removes the top-level lines starting with one of keys
+// from the frontmatter at the start of body, which ends at the closing "---".
+func dropFrontmatterKeys(body string, keys []string) string {
+	end := strings.Index(body, "\n---")
+	if end < 0 || strings.HasPrefix(body, "---") {
+		return body
+	}
+
+	lines := strings.SplitAfter(body[:end+1], "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		drop := false
+		for _, key := range keys {
+			if strings.HasPrefix(line, key) {
+				drop = true
+				break
+			}
+		}
+		if !drop {
+			kept = append(kept, line)
+		}
+	}
+
+	return strings.Join(kept, "") + body[end+1:]
+}
+
+// RoleDirName returns the team directory name GenerateRoleSession uses for a role.
+// Sessions are named after their epic bead so epics of the same audit type do not collide.
+func RoleDirName(epicBeadID, auditTypeID, codeName string) string {
+	scope := fallbackValue(epicBeadID, strings.TrimSpace(auditTypeID))
+	return scope + "-" + strings.TrimSpace(codeName)
+}
+
+// ArchiveRoleAttempt moves a role's team directory to {dirName}.attempt-{n} so a
+// retry starts in a fresh directory. It returns the archived name, or "" when the
+// directory does not exist.
+func ArchiveRoleAttempt(teamsDir, dirName string, attempt int) (string, error) {
+	source := filepath.Join(teamsDir, dirName)
+	if _, err := os.Stat(source); err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("stat team directory: %w", err)
+	}
+
+	archived := fmt.Sprintf("%s.attempt-%d", dirName, attempt)
+	if err := os.RemoveAll(filepath.Join(teamsDir, archived)); err != nil {
+		return "", fmt.Errorf("reset archived team directory: %w", err)
+	}
+	if err := os.Rename(source, filepath.Join(teamsDir, archived)); err != nil {
+		return "", fmt.Errorf("archive team directory: %w", err)
+	}
+
+	return archived, nil
+}
+
+// RoleDirNames returns candidate team directory names for a role, most specific first.
+// The first entry matches GenerateRoleSession; the rest cover older audit-type and
+// prefix-based names.
+func RoleDirNames(role config.RoleState, roleKey string) []string {
+	codeName := fallbackValue(role.CodeName, roleKey)
+	beadPrefix := fallbackValue(role.BeadPrefix, roleKey)
+
+	dirs := make([]string, 0, 3)
+	if epicBeadID := strings.TrimSpace(role.EpicBeadID); epicBeadID != "" {
+		dirs = append(dirs, RoleDirName(epicBeadID, "", codeName))
+	}
+	if auditTypeID := beadPrefixAuditTypeID(beadPrefix); auditTypeID != "" {
+		dirs = append(dirs, auditTypeID+"-"+codeName)
+	}
+	dirs = append(dirs, beadPrefix+"-"+codeName)
+
+	return dirs
+}
+
+func beadPrefixAuditTypeID(beadPrefix string) string {
+	prefix := strings.TrimSpace(beadPrefix)
+	if prefix == "" {
+		return ""
+	}
+
+	parts := strings.SplitN(prefix, "-", 2)
+	return strings.TrimSpace(parts[0])
+}
+
+func fallbackValue(value, fallback string) string {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return fallback
+	}
+
+	return trimmed
+}
+
 func activeRoles(auditType AuditType, agentCount int) ([]Role, error) {
 	for _, roleConfig := range auditType.RoleConfigs {
 		if roleConfig.AgentCount != agentCount {
@@ -261,18 +455,6 @@ func activeRoles(auditType AuditType, agentCount int) ([]Role, error) {
 	return nil, fmt.Errorf("audit type %q has no role config for %d agents", auditType.ID, agentCount)
 }
 
-func shouldSkipInvestigator(relPath string, agentCount int) bool {
-	if relPath == ".opencode/agents/investigator-bravo.md" && agentCount < 2 {
-		return true
-	}
-
-	if relPath == ".opencode/agents/investigator-charlie.md" && agentCount < 3 {
-		return true
-	}
-
-	return false
-}
-
 func renderTemplateFile(srcPath, dstPath string, data TemplateData) error {
 	src, err := fs.ReadFile(templates.AuditTemplate, srcPath)
 	if err != nil {
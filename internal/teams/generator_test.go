@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"lattice/internal/agent"
 	"lattice/internal/config"
 )
 
@@ -102,6 +103,36 @@ func TestGenerateSkipsUnusedInvestigatorsByAgentCount(t *testing.T) {
 	assertFileNotExists(t, filepath.Join(teamDir, ".opencode", "agents", "investigator-charlie.md"))
 }
 
+func TestGenerateWritesInvestigatorPerRoleCodeName(t *testing.T) {
+	t.Parallel()
+
+	security, _ := FindAuditType(AuditTypes, "security")
+	teamDir, err := Generate(GenerateParams{
+		WorkingDir: t.TempDir(),
+		AuditType:  security,
+		AgentCount: 5,
+		Intensity:  1,
+		Target:     "API",
+		BeadPrefix: "sec-8",
+	})
+	if err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+
+	for _, codeName := range []string{"threat-modeler", "alpha", "bravo", "charlie", "reviewer"} {
+		assertFileExists(t, filepath.Join(teamDir, ".opencode", "agents", "investigator-"+codeName+".md"))
+	}
+	assertFileNotExists(t, filepath.Join(teamDir, ".opencode", "agents", "investigator.md"))
+
+	task, err := os.ReadFile(filepath.Join(teamDir, "context", "TASK.md"))
+	if err != nil {
+		t.Fatalf("ReadFile(context/TASK.md) returned error: %v", err)
+	}
+	if !strings.Contains(string(task), "- Threat modeler (investigator-threat-modeler)") {
+		t.Fatalf("expected task to map roles to investigators, got %q", task)
+	}
+}
+
 func TestGenerateUsesCustomFocusAreasWhenProvided(t *testing.T) {
 	t.Parallel()
 
@@ -184,13 +215,15 @@ func TestGenerateRoleSessionCreatesRenderedFolder(t *testing.T) {
 		t.Fatalf("GenerateRoleSession() returned error: %v", err)
 	}
 
-	wantTeamDir := filepath.Join(workDir, config.DirName, "teams", "perf-alpha")
+	wantTeamDir := filepath.Join(workDir, config.DirName, "teams", "epic-120-alpha")
 	if teamDir != wantTeamDir {
 		t.Fatalf("unexpected team directory: got %q want %q", teamDir, wantTeamDir)
 	}
 
 	assertFileExists(t, filepath.Join(teamDir, ".opencode", "agents", "auditor.md"))
 	assertFileExists(t, filepath.Join(teamDir, ".opencode", "agents", "scribe.md"))
+	assertFileExists(t, filepath.Join(teamDir, RoleRunScript))
+	assertFileExists(t, filepath.Join(teamDir, "opencode.jsonc"))
 	assertFileNotExists(t, filepath.Join(teamDir, ".opencode", "agents", "commissar.md"))
 	assertFileNotExists(t, filepath.Join(teamDir, ".opencode", "agents", "investigator-alpha.md"))
 	assertFileNotExists(t, filepath.Join(teamDir, ".opencode", "agents", "investigator-bravo.md"))
@@ -201,7 +234,7 @@ func TestGenerateRoleSessionCreatesRenderedFolder(t *testing.T) {
 		t.Fatalf("ReadFile(.team) returned error: %v", err)
 	}
 	teamText := string(teamFile)
-	if !strings.Contains(teamText, "team=perf-alpha") {
+	if !strings.Contains(teamText, "team=epic-120-alpha") {
 		t.Fatalf("expected .team to include generated team name, got %q", teamText)
 	}
 	if !strings.Contains(teamText, "epic_bead_id=epic-120") {
@@ -244,6 +277,197 @@ func TestGenerateRoleSessionCreatesRenderedFolder(t *testing.T) {
 	}
 }
 
+func TestGenerateRoleSessionLaysOutFilesForAgentRuntime(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		runtime     agent.Runtime
+		wantCommand string
+		wantFiles   []string
+		absentFiles []string
+	}{
+		{
+			name:        "opencode by default",
+			wantCommand: "\nopencode run auditor\n",
+			wantFiles:   []string{".opencode/agents/auditor.md", ".opencode/skills/compile-report/SKILL.md", "opencode.jsonc"},
+		},
+		{
+			name: "command template",
+			runtime: agent.NewTemplate("claude", config.AgentRuntime{
+				Command:    "claude -p --agent {{.Agent}} {{.Prompt}}",
+				ConfigFile: ".mcp.json",
+				Config:     "{}",
+			}),
+			wantCommand: "\nclaude -p --agent auditor 'Follow the instructions in .claude/agents/auditor.md.'\n",
+			wantFiles:   []string{".claude/agents/auditor.md", ".claude/skills/compile-report/SKILL.md", ".mcp.json"},
+			absentFiles: []string{".opencode", "opencode.jsonc"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			teamDir, err := GenerateRoleSession(RoleSessionParams{
+				Cwd:         t.TempDir(),
+				EpicBeadID:  "epic-120",
+				Intensity:   1,
+				BeadPrefix:  "perf-121",
+				AuditTypeID: "perf",
+				CodeName:    "alpha",
+				Runtime:     tt.runtime,
+			})
+			if err != nil {
+				t.Fatalf("GenerateRoleSession() returned error: %v", err)
+			}
+
+			script, err := os.ReadFile(filepath.Join(teamDir, RoleRunScript))
+			if err != nil {
+				t.Fatalf("ReadFile(%s) returned error: %v", RoleRunScript, err)
+			}
+			if !strings.Contains(string(script), tt.wantCommand) {
+				t.Fatalf("expected %s to run %q, got %q", RoleRunScript, tt.wantCommand, script)
+			}
+			for _, file := range tt.wantFiles {
+				assertFileExists(t, filepath.Join(teamDir, filepath.FromSlash(file)))
+			}
+			for _, file := range tt.absentFiles {
+				assertFileNotExists(t, filepath.Join(teamDir, filepath.FromSlash(file)))
+			}
+		})
+	}
+}
+
+func TestGenerateRoleSessionPassesAgentParams(t *testing.T) {
+	t.Parallel()
+
+	temperature := 0.1
+	teamDir, err := GenerateRoleSession(RoleSessionParams{
+		Cwd:         t.TempDir(),
+		EpicBeadID:  "epic-120",
+		Intensity:   1,
+		BeadPrefix:  "perf-121",
+		AuditTypeID: "perf",
+		CodeName:    "alpha",
+		AgentParams: config.AgentParams{Model: "anthropic/claude-haiku", Temperature: &temperature, Variant: "low"},
+	})
+	if err != nil {
+		t.Fatalf("GenerateRoleSession() returned error: %v", err)
+	}
+
+	script, err := os.ReadFile(filepath.Join(teamDir, RoleRunScript))
+	if err != nil {
+		t.Fatalf("ReadFile(%s) returned error: %v", RoleRunScript, err)
+	}
+	if !strings.Contains(string(script), "\nopencode run --model 'anthropic/claude-haiku' --variant 'low' auditor\n") {
+		t.Fatalf("expected the launch command to pass the model and variant, got %q", script)
+	}
+
+	for _, agentName := range []string{"auditor", "scribe"} {
+		definition, err := os.ReadFile(filepath.Join(teamDir, ".opencode", "agents", agentName+".md"))
+		if err != nil {
+			t.Fatalf("ReadFile(%s.md) returned error: %v", agentName, err)
+		}
+		if !strings.HasPrefix(string(definition), "---\nmodel: \"anthropic/claude-haiku\"\ntemperature: 0.1\ndescription: ") {
+			t.Fatalf("expected %s frontmatter to set the model and temperature, got %q", agentName, definition[:80])
+		}
+	}
+}
+
+func TestSetAgentFrontmatterReplacesExistingKeys(t *testing.T) {
+	t.Parallel()
+
+	agentsDir := t.TempDir()
+	path := filepath.Join(agentsDir, "auditor.md")
+	original := "---\ndescription: Audits\nmodel: old/model\ntemperature: 0.9\ntools:\n  model: kept\n---\nmodel: body text stays\n"
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	temperature := 0.2
+	if err := setAgentFrontmatter(agentsDir, config.AgentParams{Model: "anthropic/claude-haiku", Temperature: &temperature}); err != nil {
+		t.Fatalf("setAgentFrontmatter() returned error: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() returned error: %v", err)
+	}
+	want := "---\nmodel: \"anthropic/claude-haiku\"\ntemperature: 0.2\ndescription: Audits\ntools:\n  model: kept\n---\nmodel: body text stays\n"
+	if string(got) != want {
+		t.Fatalf("unexpected agent definition:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGenerateRoleSessionPlacesRunSessionsUnderRunDirectory(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	teamDir, err := GenerateRoleSession(RoleSessionParams{
+		Cwd:         workDir,
+		RunID:       "20260102-030405",
+		RoleBeadID:  "perf-121",
+		RoleTitle:   "Lead Performance Auditor",
+		Intensity:   1,
+		BeadPrefix:  "perf-lead-performance-auditor",
+		AuditTypeID: "perf",
+		CodeName:    "alpha",
+	})
+	if err != nil {
+		t.Fatalf("GenerateRoleSession() returned error: %v", err)
+	}
+
+	wantTeamDir := filepath.Join(workDir, config.DirName, config.RunsDirName, "20260102-030405", config.TeamsDirName, "perf-alpha")
+	if teamDir != wantTeamDir {
+		t.Fatalf("unexpected team directory: got %q want %q", teamDir, wantTeamDir)
+	}
+	assertFileExists(t, filepath.Join(teamDir, RoleRunScript))
+}
+
+func TestGenerateRoleSessionRendersPriorWork(t *testing.T) {
+	t.Parallel()
+
+	teamDir, err := GenerateRoleSession(RoleSessionParams{
+		Cwd:         t.TempDir(),
+		EpicBeadID:  "audit-plan-001",
+		RoleBeadID:  "audit-plan-003",
+		RoleTitle:   "Staff Performance Engineer",
+		Intensity:   1,
+		BeadPrefix:  "perf-staff",
+		AuditTypeID: "perf",
+		CodeName:    "bravo",
+		PriorWork: []PriorWork{{
+			RoleTitle:  "Senior Performance Specialist",
+			CodeName:   "alpha",
+			BeadPrefix: "perf-senior",
+			Report:     "# Findings\n\nN+1 query in checkout (perf-senior-a1).",
+			BeadIDs:    []string{"perf-senior-a1"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("GenerateRoleSession() returned error: %v", err)
+	}
+
+	task, err := os.ReadFile(filepath.Join(teamDir, "context", "TASK.md"))
+	if err != nil {
+		t.Fatalf("ReadFile(context/TASK.md) returned error: %v", err)
+	}
+	taskText := string(task)
+	for _, want := range []string{
+		"## Prior Work",
+		"### Senior Performance Specialist (alpha)",
+		"- Bead prefix: `perf-senior`",
+		"  - `perf-senior-a1`",
+		"N+1 query in checkout",
+	} {
+		if !strings.Contains(taskText, want) {
+			t.Fatalf("expected task to include %q, got %q", want, taskText)
+		}
+	}
+}
+
 func assertFileExists(t *testing.T, path string) {
 	t.Helper()
 
@@ -259,3 +483,65 @@ func assertFileNotExists(t *testing.T, path string) {
 		t.Fatalf("expected file %q to not exist", path)
 	}
 }
+
+func TestArchiveRoleAttemptKeepsPreviousSessionForResume(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	params := RoleSessionParams{
+		Cwd:         workDir,
+		EpicBeadID:  "epic-120",
+		RoleBeadID:  "perf-121",
+		RoleTitle:   "Lead Performance Auditor",
+		Intensity:   3,
+		BeadPrefix:  "perf-121",
+		AuditTypeID: "perf",
+		CodeName:    "alpha",
+	}
+	teamDir, err := GenerateRoleSession(params)
+	if err != nil {
+		t.Fatalf("GenerateRoleSession() returned error: %v", err)
+	}
+
+	teamsDir := filepath.Dir(teamDir)
+	archived, err := ArchiveRoleAttempt(teamsDir, "epic-120-alpha", 1)
+	if err != nil {
+		t.Fatalf("ArchiveRoleAttempt() returned error: %v", err)
+	}
+	if archived != "epic-120-alpha.attempt-1" {
+		t.Fatalf("unexpected archived directory %q", archived)
+	}
+	assertFileExists(t, filepath.Join(teamsDir, archived, ".team"))
+	assertFileNotExists(t, teamDir)
+
+	params.StartLoop = 2
+	if _, err := GenerateRoleSession(params); err != nil {
+		t.Fatalf("GenerateRoleSession() returned error: %v", err)
+	}
+	teamData, err := ReadTeamFile(filepath.Join(teamDir, ".team"))
+	if err != nil {
+		t.Fatalf("ReadTeamFile() returned error: %v", err)
+	}
+	if teamData["current_loop"] != "2" {
+		t.Fatalf("expected resumed session to start at loop 2, got %q", teamData["current_loop"])
+	}
+
+	if archived, err := ArchiveRoleAttempt(teamsDir, "epic-120-bravo", 1); err != nil || archived != "" {
+		t.Fatalf("expected missing directory to be skipped, got %q, %v", archived, err)
+	}
+}
+
+func TestRoleDirNamesPreferEpicThenAuditTypeThenLegacyPrefix(t *testing.T) {
+	t.Parallel()
+
+	dirs := RoleDirNames(config.RoleState{EpicBeadID: "audit-plan-001", BeadPrefix: "perf-senior-performance-specialist", CodeName: "alpha"}, "role-1")
+	want := []string{"audit-plan-001-alpha", "perf-alpha", "perf-senior-performance-specialist-alpha"}
+	if len(dirs) != len(want) {
+		t.Fatalf("expected %d directory candidates, got %#v", len(want), dirs)
+	}
+	for i := range want {
+		if dirs[i] != want[i] {
+			t.Fatalf("unexpected directory candidate %d: got %q want %q", i, dirs[i], want[i])
+		}
+	}
+}
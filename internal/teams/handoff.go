@@ -0,0 +1,73 @@
+package teams
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"lattice/internal/config"
+)
+
+// RoleReportPath is where a role session writes its final report, relative to its team directory.
+const RoleReportPath = "context/REPORT.md"
+
+// PriorWork summarizes what an earlier role in the same epic produced.
+type PriorWork struct {
+	RoleTitle  string
+	CodeName   string
+	BeadPrefix string
+	Report     string
+	BeadIDs    []string
+}
+
+// LoadPriorWork reads the report of a finished role and collects the bead IDs
+// it mentions under its bead prefix. A missing report yields empty prior work
+// rather than an error, since a role may finish without writing one.
+func LoadPriorWork(teamsDir string, role config.RoleState, roleKey string) (PriorWork, error) {
+	work := PriorWork{
+		RoleTitle:  strings.TrimSpace(role.Title),
+		CodeName:   strings.TrimSpace(role.CodeName),
+		BeadPrefix: strings.TrimSpace(role.BeadPrefix),
+	}
+
+	for _, dir := range RoleDirNames(role, roleKey) {
+		content, err := os.ReadFile(filepath.Join(teamsDir, dir, filepath.FromSlash(RoleReportPath)))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return work, fmt.Errorf("read report for %s: %w", roleKey, err)
+		}
+
+		work.Report = strings.TrimSpace(string(content))
+		work.BeadIDs = findBeadIDs(work.Report, work.BeadPrefix)
+		break
+	}
+
+	return work, nil
+}
+
+// findBeadIDs returns the unique bead IDs in text that use beadPrefix, sorted.
+func findBeadIDs(text, beadPrefix string) []string {
+	if strings.TrimSpace(beadPrefix) == "" || text == "" {
+		return nil
+	}
+
+	pattern := regexp.MustCompile(`(^|[^A-Za-z0-9-])(` + regexp.QuoteMeta(beadPrefix) + `-[A-Za-z0-9]+(?:\.[0-9]+)*)`)
+	seen := map[string]struct{}{}
+	for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+		seen[match[2]] = struct{}{}
+	}
+
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
@@ -0,0 +1,52 @@
+package teams
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lattice/internal/config"
+)
+
+func TestLoadPriorWorkReadsReportAndBeadIDs(t *testing.T) {
+	t.Parallel()
+
+	teamsDir := t.TempDir()
+	contextDir := filepath.Join(teamsDir, "audit-plan-001-alpha", "context")
+	if err := os.MkdirAll(contextDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll() returned error: %v", err)
+	}
+	report := "# Report\n\n- perf-senior-b2: slow query\n- perf-senior-a1.1 (subtask)\n- see perf-senior-b2 again\n- not ours: perf-seniority-c3, xperf-senior-d4\n"
+	if err := os.WriteFile(filepath.Join(contextDir, "REPORT.md"), []byte(report), 0o644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	role := config.RoleState{EpicBeadID: "audit-plan-001", CodeName: "alpha", Title: "Senior", BeadPrefix: "perf-senior"}
+	work, err := LoadPriorWork(teamsDir, role, "audit-plan-002")
+	if err != nil {
+		t.Fatalf("LoadPriorWork() returned error: %v", err)
+	}
+
+	if work.RoleTitle != "Senior" || work.CodeName != "alpha" || work.BeadPrefix != "perf-senior" {
+		t.Fatalf("unexpected prior work identity: %+v", work)
+	}
+	if !strings.HasPrefix(work.Report, "# Report") {
+		t.Fatalf("expected report content, got %q", work.Report)
+	}
+	if got := strings.Join(work.BeadIDs, ","); got != "perf-senior-a1.1,perf-senior-b2" {
+		t.Fatalf("unexpected bead ids: %q", got)
+	}
+}
+
+func TestLoadPriorWorkWithoutReport(t *testing.T) {
+	t.Parallel()
+
+	work, err := LoadPriorWork(t.TempDir(), config.RoleState{CodeName: "alpha", BeadPrefix: "perf-senior"}, "audit-plan-002")
+	if err != nil {
+		t.Fatalf("LoadPriorWork() returned error: %v", err)
+	}
+	if work.Report != "" || len(work.BeadIDs) != 0 {
+		t.Fatalf("expected empty prior work, got %+v", work)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+
+	"lattice/internal/config"
 )
 
 // RoleBead describes one role bead generated for an epic.
@@ -14,13 +16,21 @@ type RoleBead struct {
 	Guidance   string
 	BeadPrefix string
 	Order      int
+	// After lists the code names of roles in the same epic that must complete first.
+	After []string
+	// AgentParams holds the role's model and agent flags.
+	AgentParams config.AgentParams
 }
 
 // EpicBead describes one audit epic and its role beads.
 type EpicBead struct {
-	BeadID    string
-	AuditType AuditType
-	RoleBeads []RoleBead
+	BeadID     string
+	AuditType  AuditType
+	Target     string
+	FocusAreas []string
+	RoleBeads  []RoleBead
+	// DependsOn lists the bead IDs of epics that must complete before this epic starts.
+	DependsOn []string
 }
 
 // AuditPlan contains all generated epics and final bead counter.
@@ -29,53 +39,225 @@ type AuditPlan struct {
 	FinalCounter int
 }
 
+// EpicSpec selects one epic to plan: an audit type scoped to a target.
+// The same audit type may appear several times with different targets.
+type EpicSpec struct {
+	AuditType  AuditType
+	Target     string
+	FocusAreas []string
+	// DependsOn lists audit type IDs whose epics for the same target must complete first.
+	DependsOn []string
+}
+
 // BuildAuditPlan builds an ordered audit plan for the selected audit types.
 func BuildAuditPlan(auditTypes []AuditType, agentCount int, intensity int, startCounter int) (*AuditPlan, error) {
-	if len(auditTypes) == 0 {
+	specs := make([]EpicSpec, 0, len(auditTypes))
+	for _, auditType := range auditTypes {
+		specs = append(specs, EpicSpec{AuditType: auditType})
+	}
+
+	return BuildEpicPlan(specs, agentCount, intensity, startCounter)
+}
+
+// BuildEpicPlan builds an ordered audit plan with one epic per spec.
+func BuildEpicPlan(specs []EpicSpec, agentCount int, intensity int, startCounter int) (*AuditPlan, error) {
+	if len(specs) == 0 {
 		return nil, fmt.Errorf("at least one audit type is required")
 	}
-	if agentCount < 1 || agentCount > 3 {
-		return nil, fmt.Errorf("agent count must be between 1 and 3")
+	if agentCount < 1 {
+		return nil, fmt.Errorf("agent count must be at least 1")
 	}
 	if intensity < 1 {
 		return nil, fmt.Errorf("intensity must be at least 1")
 	}
 
+	typeCounts := make(map[string]int, len(specs))
+	scopes := make(map[string]bool, len(specs))
+	// slugCounts counts the scope slugs per audit type; truncation can give
+	// different targets the same slug.
+	slugCounts := make(map[string]int, len(specs))
+	for _, spec := range specs {
+		target := strings.TrimSpace(spec.Target)
+		scopeKey := spec.AuditType.ID + "\x00" + target
+		if scopes[scopeKey] {
+			return nil, fmt.Errorf("audit type %q is planned more than once for target %q", spec.AuditType.ID, target)
+		}
+		scopes[scopeKey] = true
+		typeCounts[spec.AuditType.ID]++
+		if slug := targetSlug(target); slug != "" {
+			slugCounts[spec.AuditType.ID+"\x00"+slug]++
+		}
+	}
+
 	counter := startCounter
-	plan := &AuditPlan{Epics: make([]EpicBead, 0, len(auditTypes))}
+	plan := &AuditPlan{Epics: make([]EpicBead, 0, len(specs))}
 
-	for _, auditType := range auditTypes {
+	for _, spec := range specs {
+		auditType := spec.AuditType
 		roleConfig, ok := findRoleConfig(auditType, agentCount)
 		if !ok {
 			return nil, fmt.Errorf("audit type %q has no role config for %d agents", auditType.ID, agentCount)
 		}
+		if err := checkRoleGraph(roleConfig.Roles); err != nil {
+			return nil, fmt.Errorf("audit type %q role config for %d agents: %w", auditType.ID, agentCount, err)
+		}
 
 		counter++
 		epic := EpicBead{
-			BeadID:    fmt.Sprintf("audit-plan-%03d", counter),
-			AuditType: auditType,
-			RoleBeads: make([]RoleBead, 0, len(roleConfig.Roles)),
+			BeadID:     fmt.Sprintf("audit-plan-%03d", counter),
+			AuditType:  auditType,
+			Target:     strings.TrimSpace(spec.Target),
+			FocusAreas: append([]string(nil), spec.FocusAreas...),
+			RoleBeads:  make([]RoleBead, 0, len(roleConfig.Roles)),
+		}
+
+		prefix := auditType.BeadPrefix
+		if typeCounts[auditType.ID] > 1 {
+			prefix += "-" + epicScopeSlug(epic, slugCounts[auditType.ID+"\x00"+targetSlug(epic.Target)] > 1)
 		}
 
 		for idx, role := range roleConfig.Roles {
 			counter++
 			epic.RoleBeads = append(epic.RoleBeads, RoleBead{
-				BeadID:     fmt.Sprintf("audit-plan-%03d", counter),
-				CodeName:   role.CodeName,
-				Title:      role.Title,
-				Guidance:   role.Guidance,
-				BeadPrefix: auditType.BeadPrefix + "-" + slugify(role.Title),
-				Order:      idx + 1,
+				BeadID:      fmt.Sprintf("audit-plan-%03d", counter),
+				CodeName:    role.CodeName,
+				Title:       role.Title,
+				Guidance:    role.Guidance,
+				BeadPrefix:  prefix + "-" + slugify(role.Title),
+				Order:       idx + 1,
+				After:       append([]string(nil), role.After...),
+				AgentParams: role.AgentParams,
 			})
 		}
 
+		deps := RoleDependencies(epic.RoleBeads)
+		for idx := range epic.RoleBeads {
+			epic.RoleBeads[idx].After = deps[epic.RoleBeads[idx].CodeName]
+		}
+
 		plan.Epics = append(plan.Epics, epic)
 	}
 
+	if err := checkRolePrefixes(plan.Epics); err != nil {
+		return nil, err
+	}
+	if err := resolveEpicDependencies(plan.Epics, specs); err != nil {
+		return nil, err
+	}
+
 	plan.FinalCounter = counter
 	return plan, nil
 }
 
+// resolveEpicDependencies turns each spec's audit type dependencies into the bead
+// IDs of the epics planned for the same target and rejects dependency cycles.
+func resolveEpicDependencies(epics []EpicBead, specs []EpicSpec) error {
+	epicIDs := make(map[string]string, len(epics))
+	for _, epic := range epics {
+		epicIDs[epic.AuditType.ID+"\x00"+epic.Target] = epic.BeadID
+	}
+
+	deps := make(map[string][]string, len(epics))
+	names := make([]string, 0, len(epics))
+	labels := make(map[string]string, len(epics))
+	for idx := range epics {
+		epic := &epics[idx]
+		for _, auditTypeID := range specs[idx].DependsOn {
+			auditTypeID = strings.TrimSpace(auditTypeID)
+			if auditTypeID == epic.AuditType.ID {
+				return fmt.Errorf("audit type %q depends on itself", auditTypeID)
+			}
+			upstream, ok := epicIDs[auditTypeID+"\x00"+epic.Target]
+			if !ok {
+				return fmt.Errorf("audit type %q depends on %q, which is not planned for target %q", epic.AuditType.ID, auditTypeID, epic.Target)
+			}
+			if !containsString(epic.DependsOn, upstream) {
+				epic.DependsOn = append(epic.DependsOn, upstream)
+			}
+		}
+
+		deps[epic.BeadID] = epic.DependsOn
+		names = append(names, epic.BeadID)
+		labels[epic.BeadID] = epic.AuditType.ID
+		if epic.Target != "" {
+			labels[epic.BeadID] += " (" + epic.Target + ")"
+		}
+	}
+
+	if cycle := dependencyCycle(names, deps); cycle != nil {
+		for idx, beadID := range cycle {
+			cycle[idx] = labels[beadID]
+		}
+		return fmt.Errorf("epic dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	return nil
+}
+
+// EpicStages numbers epics by dependency depth: epics without dependencies are
+// stage 1 and every other epic runs one stage after its latest dependency.
+func EpicStages(epics []EpicBead) map[string]int {
+	names := make([]string, 0, len(epics))
+	deps := make(map[string][]string, len(epics))
+	for _, epic := range epics {
+		names = append(names, epic.BeadID)
+		deps[epic.BeadID] = epic.DependsOn
+	}
+
+	return dependencyStages(names, deps)
+}
+
+func containsString(values []string, want string) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+// epicScopeSlug names an epic inside bead prefixes when its audit type repeats.
+// A slug another target of the same audit type shares gets the epic's bead
+// number appended.
+func epicScopeSlug(epic EpicBead, shared bool) string {
+	number := strings.TrimPrefix(epic.BeadID, "audit-plan-")
+	slug := targetSlug(epic.Target)
+	if slug == "" {
+		return number
+	}
+	if shared {
+		return slug + "-" + number
+	}
+
+	return slug
+}
+
+// targetSlug slugifies a project-relative target for bead prefixes.
+func targetSlug(target string) string {
+	return slugify(strings.NewReplacer("/", " ", "\\", " ", ".", " ", "_", " ").Replace(target))
+}
+
+// checkRolePrefixes rejects plans in which two roles would file beads under the
+// same prefix.
+func checkRolePrefixes(epics []EpicBead) error {
+	owners := map[string]string{}
+	for _, epic := range epics {
+		for _, role := range epic.RoleBeads {
+			owner := epic.AuditType.ID + " role " + role.CodeName
+			if epic.Target != "" {
+				owner += " (" + epic.Target + ")"
+			}
+			if previous, ok := owners[role.BeadPrefix]; ok {
+				return fmt.Errorf("bead prefix %q is used by both %s and %s", role.BeadPrefix, previous, owner)
+			}
+			owners[role.BeadPrefix] = owner
+		}
+	}
+
+	return nil
+}
+
 func findRoleConfig(auditType AuditType, agentCount int) (AgentConfigRoles, bool) {
 	for _, roleConfig := range auditType.RoleConfigs {
 		if roleConfig.AgentCount == agentCount {
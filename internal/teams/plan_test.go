@@ -96,16 +96,108 @@ func TestBuildAuditPlanReturnsValidationErrors(t *testing.T) {
 		t.Fatalf("expected empty audit type error, got: %v", err)
 	}
 
-	if _, err := BuildAuditPlan([]AuditType{AuditTypes[0]}, 0, 1, 0); err == nil || !strings.Contains(err.Error(), "agent count must be between 1 and 3") {
+	if _, err := BuildAuditPlan([]AuditType{AuditTypes[0]}, 0, 1, 0); err == nil || !strings.Contains(err.Error(), "agent count must be at least 1") {
 		t.Fatalf("expected invalid agent count error, got: %v", err)
 	}
+
+	cyclic := AuditType{ID: "cyclic", BeadPrefix: "cyc", RoleConfigs: []AgentConfigRoles{{
+		AgentCount: 2,
+		Roles: []RoleDefinition{
+			{CodeName: "alpha", Title: "Alpha", After: []string{"bravo"}},
+			{CodeName: "bravo", Title: "Bravo", After: []string{"alpha"}},
+		},
+	}}}
+	if _, err := BuildAuditPlan([]AuditType{cyclic}, 2, 1, 0); err == nil || !strings.Contains(err.Error(), "role dependency cycle") {
+		t.Fatalf("expected role dependency cycle error, got: %v", err)
+	}
+}
+
+func TestBuildAuditPlanResolvesRoleDependencies(t *testing.T) {
+	t.Parallel()
+
+	security, ok := FindAuditType(AuditTypes, "security")
+	if !ok {
+		t.Fatal("security audit type not found")
+	}
+
+	plan, err := BuildAuditPlan([]AuditType{security}, 5, 1, 0)
+	if err != nil {
+		t.Fatalf("BuildAuditPlan() returned error: %v", err)
+	}
+
+	after := make(map[string][]string)
+	for _, role := range plan.Epics[0].RoleBeads {
+		after[role.CodeName] = role.After
+	}
+	if len(after["threat-modeler"]) != 0 || strings.Join(after["bravo"], ",") != "threat-modeler" || strings.Join(after["reviewer"], ",") != "alpha,bravo,charlie" {
+		t.Fatalf("unexpected security dependencies: %#v", after)
+	}
+
+	plan, err = BuildAuditPlan([]AuditType{AuditTypes[0]}, 3, 1, 0)
+	if err != nil {
+		t.Fatalf("BuildAuditPlan() returned error: %v", err)
+	}
+
+	sequential := plan.Epics[0].RoleBeads
+	for idx, role := range sequential {
+		if idx == 0 && len(role.After) != 0 {
+			t.Fatalf("expected first role to have no dependencies, got %#v", role.After)
+		}
+		if idx > 0 && strings.Join(role.After, ",") != sequential[idx-1].CodeName {
+			t.Fatalf("expected %s to wait for %s, got %#v", role.CodeName, sequential[idx-1].CodeName, role.After)
+		}
+	}
+}
+
+func TestBuildEpicPlanResolvesEpicDependenciesPerTarget(t *testing.T) {
+	t.Parallel()
+
+	perf, security := AuditTypes[0], AuditTypes[1]
+	plan, err := BuildEpicPlan([]EpicSpec{
+		{AuditType: security, Target: "api", DependsOn: []string{perf.ID}},
+		{AuditType: perf, Target: "api"},
+		{AuditType: security, Target: "web", DependsOn: []string{perf.ID}},
+		{AuditType: perf, Target: "web"},
+	}, 1, 1, 0)
+	if err != nil {
+		t.Fatalf("BuildEpicPlan() returned error: %v", err)
+	}
+
+	if got := plan.Epics[0].DependsOn; len(got) != 1 || got[0] != plan.Epics[1].BeadID {
+		t.Fatalf("expected api security epic to wait for api perf epic, got %#v", got)
+	}
+	if got := plan.Epics[2].DependsOn; len(got) != 1 || got[0] != plan.Epics[3].BeadID {
+		t.Fatalf("expected web security epic to wait for web perf epic, got %#v", got)
+	}
+	if stages := EpicStages(plan.Epics); stages[plan.Epics[0].BeadID] != 2 || stages[plan.Epics[1].BeadID] != 1 {
+		t.Fatalf("unexpected epic stages: %#v", stages)
+	}
+
+	if _, err := BuildEpicPlan([]EpicSpec{{AuditType: security, DependsOn: []string{perf.ID}}}, 1, 1, 0); err == nil || !strings.Contains(err.Error(), "not planned for target") {
+		t.Fatalf("expected missing upstream error, got %v", err)
+	}
+
+	cycle := []EpicSpec{{AuditType: perf, DependsOn: []string{security.ID}}, {AuditType: security, DependsOn: []string{perf.ID}}}
+	if _, err := BuildEpicPlan(cycle, 1, 1, 0); err == nil || !strings.Contains(err.Error(), "epic dependency cycle") {
+		t.Fatalf("expected epic dependency cycle error, got %v", err)
+	}
 }
 
 func TestBuildAuditPlanRolePrefixesUniqueAcrossAuditTypesAndAgentCounts(t *testing.T) {
 	t.Parallel()
 
-	for agentCount := 1; agentCount <= 3; agentCount++ {
-		plan, err := BuildAuditPlan(AuditTypes, agentCount, 1, 0)
+	for agentCount := 1; agentCount <= 5; agentCount++ {
+		var auditTypes []AuditType
+		for _, auditType := range AuditTypes {
+			if _, ok := findRoleConfig(auditType, agentCount); ok {
+				auditTypes = append(auditTypes, auditType)
+			}
+		}
+		if len(auditTypes) == 0 {
+			continue
+		}
+
+		plan, err := BuildAuditPlan(auditTypes, agentCount, 1, 0)
 		if err != nil {
 			t.Fatalf("BuildAuditPlan() returned error for %d agents: %v", agentCount, err)
 		}
@@ -122,6 +214,97 @@ func TestBuildAuditPlanRolePrefixesUniqueAcrossAuditTypesAndAgentCounts(t *testi
 	}
 }
 
+func TestBuildEpicPlanAllowsSameAuditTypeForDifferentTargets(t *testing.T) {
+	t.Parallel()
+
+	security := AuditTypes[0]
+	plan, err := BuildEpicPlan([]EpicSpec{
+		{AuditType: security, Target: "internal/api"},
+		{AuditType: security, Target: "internal/auth", FocusAreas: []string{"session tokens"}},
+	}, 2, 1, 0)
+	if err != nil {
+		t.Fatalf("BuildEpicPlan() returned error: %v", err)
+	}
+
+	if len(plan.Epics) != 2 {
+		t.Fatalf("expected 2 epics, got %d", len(plan.Epics))
+	}
+	if plan.Epics[0].BeadID == plan.Epics[1].BeadID {
+		t.Fatalf("expected distinct epic bead ids, got %q", plan.Epics[0].BeadID)
+	}
+	if plan.Epics[1].Target != "internal/auth" || len(plan.Epics[1].FocusAreas) != 1 {
+		t.Fatalf("expected epic scope to be kept, got %+v", plan.Epics[1])
+	}
+
+	wantPrefix := security.BeadPrefix + "-internal-api-"
+	if got := plan.Epics[0].RoleBeads[0].BeadPrefix; !strings.HasPrefix(got, wantPrefix) {
+		t.Fatalf("expected scoped bead prefix %q..., got %q", wantPrefix, got)
+	}
+
+	seen := map[string]struct{}{}
+	for _, epic := range plan.Epics {
+		for _, roleBead := range epic.RoleBeads {
+			if _, ok := seen[roleBead.BeadPrefix]; ok {
+				t.Fatalf("duplicate role bead prefix: %q", roleBead.BeadPrefix)
+			}
+			seen[roleBead.BeadPrefix] = struct{}{}
+		}
+	}
+}
+
+func TestBuildEpicPlanRejectsDuplicateTargetForAuditType(t *testing.T) {
+	t.Parallel()
+
+	_, err := BuildEpicPlan([]EpicSpec{
+		{AuditType: AuditTypes[0], Target: "internal/api"},
+		{AuditType: AuditTypes[0], Target: "internal/api"},
+	}, 1, 1, 0)
+	if err == nil || !strings.Contains(err.Error(), "planned more than once") {
+		t.Fatalf("expected duplicate target error, got: %v", err)
+	}
+}
+
+func TestBuildEpicPlanKeepsPrefixesUniqueForLongSiblingTargets(t *testing.T) {
+	t.Parallel()
+
+	perf, ok := FindAuditType(AuditTypes, "perf")
+	if !ok {
+		t.Fatal("expected the built-in perf audit type")
+	}
+	plan, err := BuildEpicPlan([]EpicSpec{
+		{AuditType: perf, Target: "internal/services/payments/api/handlers"},
+		{AuditType: perf, Target: "internal/services/payments/api/models"},
+		{AuditType: perf, Target: "cmd"},
+	}, 1, 1, 0)
+	if err != nil {
+		t.Fatalf("BuildEpicPlan() returned error: %v", err)
+	}
+
+	first, second := plan.Epics[0].RoleBeads[0].BeadPrefix, plan.Epics[1].RoleBeads[0].BeadPrefix
+	if first == second {
+		t.Fatalf("expected distinct prefixes for sibling targets, got %q twice", first)
+	}
+	if want := perf.BeadPrefix + "-internal-services-payments-001-"; !strings.HasPrefix(first, want) {
+		t.Fatalf("expected the shared slug to carry the epic number %q..., got %q", want, first)
+	}
+	if want := perf.BeadPrefix + "-cmd-"; !strings.HasPrefix(plan.Epics[2].RoleBeads[0].BeadPrefix, want) {
+		t.Fatalf("expected an unshared slug without the epic number %q..., got %q", want, plan.Epics[2].RoleBeads[0].BeadPrefix)
+	}
+}
+
+func TestBuildEpicPlanRejectsDuplicateRolePrefixes(t *testing.T) {
+	t.Parallel()
+
+	auditType := AuditType{ID: "perf", BeadPrefix: "perf", RoleConfigs: []AgentConfigRoles{{AgentCount: 2, Roles: []RoleDefinition{
+		{CodeName: "alpha", Title: "Profiler", Guidance: "Profile."},
+		{CodeName: "bravo", Title: "Profiler", Guidance: "Profile again."},
+	}}}}
+	_, err := BuildEpicPlan([]EpicSpec{{AuditType: auditType}}, 2, 1, 0)
+	if err == nil || !strings.Contains(err.Error(), `bead prefix "perf-profiler" is used by both perf role alpha and perf role bravo`) {
+		t.Fatalf("expected duplicate prefix error, got: %v", err)
+	}
+}
+
 func TestSlugifyTruncatesAtWordBoundary(t *testing.T) {
 	t.Parallel()
 
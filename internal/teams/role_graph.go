@@ -0,0 +1,163 @@
+package teams
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RoleDependencies returns the code names each role waits for, keyed by code name.
+// When no role declares dependencies, each role waits for the one before it.
+func RoleDependencies(roles []RoleBead) map[string][]string {
+	declared := false
+	for _, role := range roles {
+		if len(role.After) > 0 {
+			declared = true
+			break
+		}
+	}
+
+	deps := make(map[string][]string, len(roles))
+	for idx, role := range roles {
+		switch {
+		case declared:
+			deps[role.CodeName] = append([]string(nil), role.After...)
+		case idx > 0:
+			deps[role.CodeName] = []string{roles[idx-1].CodeName}
+		default:
+			deps[role.CodeName] = nil
+		}
+	}
+
+	return deps
+}
+
+// RoleStages numbers roles by dependency depth: roles without dependencies are
+// stage 1 and every other role runs one stage after its latest dependency.
+func RoleStages(roles []RoleBead) map[string]int {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.CodeName)
+	}
+
+	return dependencyStages(names, RoleDependencies(roles))
+}
+
+// checkRoleGraph verifies that every dependency names another role in the same
+// config and that the dependencies contain no cycle.
+func checkRoleGraph(roles []RoleDefinition) error {
+	deps := make(map[string][]string, len(roles))
+	for _, role := range roles {
+		deps[role.CodeName] = role.After
+	}
+
+	for _, role := range roles {
+		seen := make(map[string]struct{}, len(role.After))
+		for _, dep := range role.After {
+			if dep == role.CodeName {
+				return fmt.Errorf("role %q depends on itself", role.CodeName)
+			}
+			if _, ok := deps[dep]; !ok {
+				return fmt.Errorf("role %q depends on unknown role %q", role.CodeName, dep)
+			}
+			if _, ok := seen[dep]; ok {
+				return fmt.Errorf("role %q lists dependency %q more than once", role.CodeName, dep)
+			}
+			seen[dep] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.CodeName)
+	}
+	if cycle := dependencyCycle(names, deps); cycle != nil {
+		return fmt.Errorf("role dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	return nil
+}
+
+// dependencyStages numbers names by dependency depth starting at 1. Unknown
+// dependencies are ignored and cycles are cut where they are found.
+func dependencyStages(names []string, deps map[string][]string) map[string]int {
+	stages := make(map[string]int, len(names))
+	visiting := make(map[string]bool, len(names))
+
+	var stage func(name string) int
+	stage = func(name string) int {
+		if value, ok := stages[name]; ok {
+			return value
+		}
+		if visiting[name] {
+			return 1
+		}
+		visiting[name] = true
+
+		value := 1
+		for _, dep := range deps[name] {
+			if _, ok := deps[dep]; !ok {
+				continue
+			}
+			if next := stage(dep) + 1; next > value {
+				value = next
+			}
+		}
+
+		visiting[name] = false
+		stages[name] = value
+		return value
+	}
+
+	for _, name := range names {
+		stage(name)
+	}
+
+	return stages
+}
+
+// dependencyCycle returns the first dependency cycle found, starting and ending
+// with the same name, or nil when the graph is acyclic.
+func dependencyCycle(names []string, deps map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(names))
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			start := 0
+			for idx, step := range path {
+				if step == name {
+					start = idx
+					break
+				}
+			}
+			return append(append([]string(nil), path[start:]...), name)
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range deps[name] {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, name := range names {
+		if cycle := visit(name); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
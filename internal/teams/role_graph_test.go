@@ -0,0 +1,81 @@
+package teams
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoleDependenciesFallsBackToSequentialOrder(t *testing.T) {
+	t.Parallel()
+
+	got := RoleDependencies([]RoleBead{{CodeName: "alpha"}, {CodeName: "bravo"}, {CodeName: "charlie"}})
+	want := map[string][]string{"alpha": nil, "bravo": {"alpha"}, "charlie": {"bravo"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("RoleDependencies() = %#v, want %#v", got, want)
+	}
+}
+
+func TestRoleDependenciesUsesDeclaredGraph(t *testing.T) {
+	t.Parallel()
+
+	roles := []RoleBead{
+		{CodeName: "alpha"},
+		{CodeName: "bravo"},
+		{CodeName: "charlie", After: []string{"alpha"}},
+	}
+
+	got := RoleDependencies(roles)
+	want := map[string][]string{"alpha": nil, "bravo": nil, "charlie": {"alpha"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("RoleDependencies() = %#v, want %#v", got, want)
+	}
+
+	stages := RoleStages(roles)
+	wantStages := map[string]int{"alpha": 1, "bravo": 1, "charlie": 2}
+	if !reflect.DeepEqual(stages, wantStages) {
+		t.Fatalf("RoleStages() = %#v, want %#v", stages, wantStages)
+	}
+}
+
+func TestCheckRoleGraphRejectsInvalidDependencies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		roles   []RoleDefinition
+		wantErr string
+	}{
+		{
+			name:    "unknown role",
+			roles:   []RoleDefinition{{CodeName: "alpha", After: []string{"zulu"}}},
+			wantErr: `role "alpha" depends on unknown role "zulu"`,
+		},
+		{
+			name:    "duplicate dependency",
+			roles:   []RoleDefinition{{CodeName: "alpha"}, {CodeName: "bravo", After: []string{"alpha", "alpha"}}},
+			wantErr: `role "bravo" lists dependency "alpha" more than once`,
+		},
+		{
+			name: "cycle",
+			roles: []RoleDefinition{
+				{CodeName: "alpha"},
+				{CodeName: "bravo", After: []string{"alpha", "charlie"}},
+				{CodeName: "charlie", After: []string{"bravo"}},
+			},
+			wantErr: "role dependency cycle: bravo -> charlie -> bravo",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkRoleGraph(tt.roles)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
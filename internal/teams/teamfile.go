@@ -0,0 +1,38 @@
+package teams
+
+import (
+	"os"
+	"strings"
+)
+
+// ReadTeamFile parses a key=value session file such as .team or .exit.
+// Blank lines and lines starting with # are ignored.
+func ReadTeamFile(filePath string) (map[string]string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+		if key == "" {
+			continue
+		}
+
+		result[key] = value
+	}
+
+	return result, nil
+}
@@ -18,6 +18,65 @@ type runInteractiveCommand func(ctx context.Context, args ...string) error
 
 var errEmptyName = errors.New("name must not be empty")
 
+// SessionPrefix starts the name of every tmux session lattice creates.
+const SessionPrefix = "lattice-"
+
+// Socket selects the tmux server lattice talks to. The zero value is the
+// user's default server.
+type Socket struct {
+	// Name runs a named server, as with tmux -L name.
+	Name string
+	// Path runs the server listening on a socket file, as with tmux -S path.
+	// It wins over Name.
+	Path string
+}
+
+// ParseSocket reads a tmux_socket setting: a value containing a slash is a
+// socket path, anything else names a server. An empty value is the default
+// server.
+func ParseSocket(value string) Socket {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "/") {
+		return Socket{Path: value}
+	}
+
+	return Socket{Name: value}
+}
+
+// args returns the tmux flags that select the socket's server.
+func (s Socket) args() []string {
+	if path := strings.TrimSpace(s.Path); path != "" {
+		return []string{"-S", path}
+	}
+	if name := strings.TrimSpace(s.Name); name != "" {
+		return []string{"-L", name}
+	}
+
+	return nil
+}
+
+// SessionName returns the tmux session name for a run in project, e.g.
+// lattice-api-20260211-143201. Characters tmux rewrites in session names
+// become dashes.
+func SessionName(project, runID string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(project) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+
+	project = strings.Trim(b.String(), "-")
+	if project == "" {
+		return SessionPrefix + runID
+	}
+
+	return SessionPrefix + project + "-" + runID
+}
+
 // WindowInfo describes a tmux window in one session.
 type WindowInfo struct {
 	Index  int
@@ -25,17 +84,35 @@ type WindowInfo struct {
 	Active bool
 }
 
-// Manager wraps tmux operations, using WSL on Windows or native tmux on Linux.
+// SessionInfo describes a lattice session on a tmux server.
+type SessionInfo struct {
+	Name string
+	// WorkingDir is the directory the session was started in, i.e. the
+	// project the run audits.
+	WorkingDir string
+	Windows    int
+	Attached   bool
+}
+
+// PaneStatus describes whether the process in a window's pane has exited.
+type PaneStatus struct {
+	Dead       bool
+	ExitStatus int
+}
+
+// Manager wraps tmux operations on one server, using WSL on Windows or native
+// tmux on Linux.
 type Manager struct {
 	runCommand            runCommand
 	runInteractiveCommand runInteractiveCommand
 }
 
-// NewManager creates a manager and verifies tmux is available.
-func NewManager() (*Manager, error) {
+// NewManager creates a manager for the server socket selects and verifies tmux
+// is available.
+func NewManager(socket Socket) (*Manager, error) {
 	m := &Manager{
-		runCommand:            defaultRunCommand,
-		runInteractiveCommand: defaultRunInteractiveCommand,
+		runCommand:            defaultRunCommand(socket),
+		runInteractiveCommand: defaultRunInteractiveCommand(socket),
 	}
 
 	if err := m.ensureAvailable(context.Background()); err != nil {
@@ -139,6 +216,174 @@ func (m *Manager) SendKeys(session, window, command string) error {
 	return nil
 }
 
+// SetRemainOnExit keeps a window open after its pane process exits so the
+// exit status can still be read with PaneStatus.
+func (m *Manager) SetRemainOnExit(session, window string) error {
+	session = strings.TrimSpace(session)
+	window = strings.TrimSpace(window)
+	if session == "" || window == "" {
+		return errEmptyName
+	}
+
+	target := fmt.Sprintf("%s:%s", session, window)
+	if _, err := m.runCommand(context.Background(), "set-option", "-w", "-t", target, "remain-on-exit", "on"); err != nil {
+		return fmt.Errorf("set remain-on-exit for tmux window %q in session %q: %w", window, session, err)
+	}
+
+	return nil
+}
+
+// PaneStatus reports whether the first pane of a window is dead and its exit status.
+func (m *Manager) PaneStatus(session, window string) (PaneStatus, error) {
+	session = strings.TrimSpace(session)
+	window = strings.TrimSpace(window)
+	if session == "" || window == "" {
+		return PaneStatus{}, errEmptyName
+	}
+
+	target := fmt.Sprintf("%s:%s", session, window)
+	out, err := m.runCommand(context.Background(), "list-panes", "-t", target, "-F", "#{pane_dead}\t#{pane_dead_status}")
+	if err != nil {
+		return PaneStatus{}, fmt.Errorf("read pane status for tmux window %q in session %q: %w", window, session, err)
+	}
+
+	line := strings.TrimSpace(strings.SplitN(out, "\n", 2)[0])
+	if line == "" {
+		return PaneStatus{}, fmt.Errorf("read pane status for tmux window %q in session %q: no panes", window, session)
+	}
+
+	parts := strings.Split(line, "\t")
+	status := PaneStatus{Dead: parts[0] == "1"}
+	if status.Dead && len(parts) > 1 && strings.TrimSpace(parts[1]) != "" {
+		code, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return PaneStatus{}, fmt.Errorf("parse list-panes output: invalid exit status %q", parts[1])
+		}
+		status.ExitStatus = code
+	}
+
+	return status, nil
+}
+
+// KillWindow closes a window and ends the process running in it.
+func (m *Manager) KillWindow(session, window string) error {
+	session = strings.TrimSpace(session)
+	window = strings.TrimSpace(window)
+	if session == "" || window == "" {
+		return errEmptyName
+	}
+
+	target := fmt.Sprintf("%s:%s", session, window)
+	if _, err := m.runCommand(context.Background(), "kill-window", "-t", target); err != nil {
+		return fmt.Errorf("kill tmux window %q in session %q: %w", window, session, err)
+	}
+
+	return nil
+}
+
+// SendInterrupt sends Ctrl-C to the first pane of a window.
+func (m *Manager) SendInterrupt(session, window string) error {
+	session = strings.TrimSpace(session)
+	window = strings.TrimSpace(window)
+	if session == "" || window == "" {
+		return errEmptyName
+	}
+
+	target := fmt.Sprintf("%s:%s", session, window)
+	if _, err := m.runCommand(context.Background(), "send-keys", "-t", target, "C-c"); err != nil {
+		return fmt.Errorf("interrupt tmux window %q in session %q: %w", window, session, err)
+	}
+
+	return nil
+}
+
+// PipePane streams everything the first pane of a window prints to the stdin of
+// a shell command, replacing any earlier pipe of that pane.
+func (m *Manager) PipePane(session, window, command string) error {
+	session = strings.TrimSpace(session)
+	window = strings.TrimSpace(window)
+	if session == "" || window == "" || strings.TrimSpace(command) == "" {
+		return errEmptyName
+	}
+
+	target := fmt.Sprintf("%s:%s", session, window)
+	if _, err := m.runCommand(context.Background(), "pipe-pane", "-t", target, command); err != nil {
+		return fmt.Errorf("pipe tmux pane for window %q in session %q: %w", window, session, err)
+	}
+
+	return nil
+}
+
+// CapturePane returns the visible content of the first pane of a window.
+func (m *Manager) CapturePane(session, window string) (string, error) {
+	session = strings.TrimSpace(session)
+	window = strings.TrimSpace(window)
+	if session == "" || window == "" {
+		return "", errEmptyName
+	}
+
+	target := fmt.Sprintf("%s:%s", session, window)
+	out, err := m.runCommand(context.Background(), "capture-pane", "-p", "-t", target)
+	if err != nil {
+		return "", fmt.Errorf("capture tmux pane for window %q in session %q: %w", window, session, err)
+	}
+
+	return out, nil
+}
+
+// ListSessions returns the lattice sessions on the manager's server, from every
+// project. A server that is not running has none.
+func (m *Manager) ListSessions() ([]SessionInfo, error) {
+	out, err := m.runCommand(context.Background(), "list-sessions", "-F", "#{session_name}\t#{session_path}\t#{session_windows}\t#{session_attached}")
+	if err != nil {
+		if isNoServerError(err) {
+			return []SessionInfo{}, nil
+		}
+		return nil, fmt.Errorf("list tmux sessions: %w", err)
+	}
+
+	result := []SessionInfo{}
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		parts := strings.Split(line, "\t")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("parse list-sessions output: unexpected line %q", line)
+		}
+		if !strings.HasPrefix(parts[0], SessionPrefix) {
+			continue
+		}
+
+		windows, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("parse list-sessions output: invalid window count %q", parts[2])
+		}
+		attached, err := strconv.Atoi(parts[3])
+		if err != nil {
+			return nil, fmt.Errorf("parse list-sessions output: invalid attached count %q", parts[3])
+		}
+
+		result = append(result, SessionInfo{
+			Name:       parts[0],
+			WorkingDir: parts[1],
+			Windows:    windows,
+			Attached:   attached > 0,
+		})
+	}
+
+	return result, nil
+}
+
+// isNoServerError reports whether err means no tmux server listens on the
+// socket, which tmux reports differently for the default and a missing socket.
+func isNoServerError(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "no server running") || strings.Contains(message, "error connecting to")
+}
+
 // ListWindows returns indexed window metadata for one session.
 func (m *Manager) ListWindows(session string) ([]WindowInfo, error) {
 	session = strings.TrimSpace(session)
@@ -191,49 +436,56 @@ func (m *Manager) ensureAvailable(ctx context.Context) error {
 	return nil
 }
 
-// tmuxCommand returns the executable and arguments for running tmux.
-// On Windows it shells out via WSL; on Linux/macOS it calls tmux directly.
-func tmuxCommand(args ...string) (string, []string) {
+// tmuxCommand returns the executable and arguments for running tmux on the
+// server socket selects. On Windows it shells out via WSL; on Linux/macOS it
+// calls tmux directly.
+func tmuxCommand(socket Socket, args ...string) (string, []string) {
+	args = append(socket.args(), args...)
 	if runtime.GOOS == "windows" {
 		return "wsl", append([]string{"tmux"}, args...)
 	}
 	return "tmux", args
 }
 
-// Command builds an exec.Cmd for tmux with OS-appropriate invocation.
-func Command(args ...string) *exec.Cmd {
-	name, cmdArgs := tmuxCommand(args...)
+// Command builds an exec.Cmd for tmux on the server socket selects with
+// OS-appropriate invocation.
+func Command(socket Socket, args ...string) *exec.Cmd {
+	name, cmdArgs := tmuxCommand(socket, args...)
 	return exec.Command(name, cmdArgs...)
 }
 
-func defaultRunCommand(ctx context.Context, args ...string) (string, error) {
-	name, cmdArgs := tmuxCommand(args...)
-	cmd := exec.CommandContext(ctx, name, cmdArgs...)
+func defaultRunCommand(socket Socket) runCommand {
+	return func(ctx context.Context, args ...string) (string, error) {
+		name, cmdArgs := tmuxCommand(socket, args...)
+		cmd := exec.CommandContext(ctx, name, cmdArgs...)
 
-	var stdout bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
 
-	if err := cmd.Run(); err != nil {
-		return "", wrapExecError(err, stderr.String())
-	}
+		if err := cmd.Run(); err != nil {
+			return "", wrapExecError(err, stderr.String())
+		}
 
-	return strings.TrimSpace(stdout.String()), nil
+		return strings.TrimSpace(stdout.String()), nil
+	}
 }
 
-func defaultRunInteractiveCommand(ctx context.Context, args ...string) error {
-	name, cmdArgs := tmuxCommand(args...)
-	cmd := exec.CommandContext(ctx, name, cmdArgs...)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+func defaultRunInteractiveCommand(socket Socket) runInteractiveCommand {
+	return func(ctx context.Context, args ...string) error {
+		name, cmdArgs := tmuxCommand(socket, args...)
+		cmd := exec.CommandContext(ctx, name, cmdArgs...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("run tmux %s: %w", strings.Join(args, " "), err)
-	}
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("run tmux %s: %w", strings.Join(args, " "), err)
+		}
 
-	return nil
+		return nil
+	}
 }
 
 func wrapExecError(err error, stderr string) error {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -77,6 +78,201 @@ func TestListWindowsParsesOutput(t *testing.T) {
 	}
 }
 
+func TestListSessionsKeepsLatticeSessions(t *testing.T) {
+	t.Parallel()
+
+	m := newManagerWithRunners(func(_ context.Context, args ...string) (string, error) {
+		want := []string{"list-sessions", "-F", "#{session_name}\t#{session_path}\t#{session_windows}\t#{session_attached}"}
+		if !reflect.DeepEqual(args, want) {
+			t.Fatalf("unexpected args: got %#v want %#v", args, want)
+		}
+		return "lattice-api-20260211-143201\t/src/api\t3\t1\nscratch\t/home/me\t1\t0\nlattice-web-20260212-090000\t/src/web\t2\t0", nil
+	}, func(context.Context, ...string) error {
+		return nil
+	})
+
+	got, err := m.ListSessions()
+	if err != nil {
+		t.Fatalf("ListSessions() returned error: %v", err)
+	}
+
+	want := []SessionInfo{
+		{Name: "lattice-api-20260211-143201", WorkingDir: "/src/api", Windows: 3, Attached: true},
+		{Name: "lattice-web-20260212-090000", WorkingDir: "/src/web", Windows: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected sessions: got %#v want %#v", got, want)
+	}
+}
+
+func TestListSessionsTreatsMissingServerAsEmpty(t *testing.T) {
+	t.Parallel()
+
+	for _, message := range []string{
+		"exit status 1: no server running on /tmp/tmux-1000/default",
+		"exit status 1: error connecting to /tmp/tmux-1000/lattice (No such file or directory)",
+	} {
+		m := newManagerWithRunners(func(context.Context, ...string) (string, error) {
+			return "", errors.New(message)
+		}, func(context.Context, ...string) error {
+			return nil
+		})
+
+		got, err := m.ListSessions()
+		if err != nil || len(got) != 0 {
+			t.Fatalf("ListSessions() = %#v, %v; want no sessions for %q", got, err, message)
+		}
+	}
+}
+
+func TestSocketSelectsServer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{name: "default", value: "", want: []string{"attach-session", "-t", "audit-1"}},
+		{name: "named", value: "lattice", want: []string{"-L", "lattice", "attach-session", "-t", "audit-1"}},
+		{name: "path", value: " /tmp/lattice.sock ", want: []string{"-S", "/tmp/lattice.sock", "attach-session", "-t", "audit-1"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, args := tmuxCommand(ParseSocket(tt.value), "attach-session", "-t", "audit-1")
+			if runtime.GOOS == "windows" {
+				args = args[1:]
+			}
+			if !reflect.DeepEqual(args, tt.want) {
+				t.Fatalf("unexpected args: got %#v want %#v", args, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionNameIncludesProject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		project string
+		want    string
+	}{
+		{project: "api", want: "lattice-api-20260211-143201"},
+		{project: "my.app:v2", want: "lattice-my-app-v2-20260211-143201"},
+		{project: "..", want: "lattice-20260211-143201"},
+		{project: "", want: "lattice-20260211-143201"},
+	}
+
+	for _, tt := range tests {
+		if got := SessionName(tt.project, "20260211-143201"); got != tt.want {
+			t.Fatalf("SessionName(%q) = %q, want %q", tt.project, got, tt.want)
+		}
+	}
+}
+
+func TestSetRemainOnExitTargetsWindow(t *testing.T) {
+	t.Parallel()
+
+	var calls [][]string
+	m := newManagerWithRunners(func(_ context.Context, args ...string) (string, error) {
+		calls = append(calls, append([]string{}, args...))
+		return "", nil
+	}, func(context.Context, ...string) error {
+		return nil
+	})
+
+	if err := m.SetRemainOnExit("audit-1", "audit-perf-alpha"); err != nil {
+		t.Fatalf("SetRemainOnExit() returned error: %v", err)
+	}
+
+	want := []string{"set-option", "-w", "-t", "audit-1:audit-perf-alpha", "remain-on-exit", "on"}
+	if len(calls) != 1 || !reflect.DeepEqual(calls[0], want) {
+		t.Fatalf("unexpected command args: got %#v want %#v", calls, [][]string{want})
+	}
+}
+
+func TestWindowCommandsTargetWindow(t *testing.T) {
+	t.Parallel()
+
+	var calls [][]string
+	m := newManagerWithRunners(func(_ context.Context, args ...string) (string, error) {
+		calls = append(calls, append([]string{}, args...))
+		return "working on loop 2\n", nil
+	}, func(context.Context, ...string) error {
+		return nil
+	})
+
+	if err := m.SendInterrupt("audit-1", "alpha"); err != nil {
+		t.Fatalf("SendInterrupt() returned error: %v", err)
+	}
+	if err := m.KillWindow("audit-1", "alpha"); err != nil {
+		t.Fatalf("KillWindow() returned error: %v", err)
+	}
+	if err := m.PipePane("audit-1", "alpha", "cat >> '/tmp/alpha.raw.log'"); err != nil {
+		t.Fatalf("PipePane() returned error: %v", err)
+	}
+	content, err := m.CapturePane("audit-1", "alpha")
+	if err != nil {
+		t.Fatalf("CapturePane() returned error: %v", err)
+	}
+	if content != "working on loop 2\n" {
+		t.Fatalf("unexpected pane content %q", content)
+	}
+
+	want := [][]string{
+		{"send-keys", "-t", "audit-1:alpha", "C-c"},
+		{"kill-window", "-t", "audit-1:alpha"},
+		{"pipe-pane", "-t", "audit-1:alpha", "cat >> '/tmp/alpha.raw.log'"},
+		{"capture-pane", "-p", "-t", "audit-1:alpha"},
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("unexpected command args: got %#v want %#v", calls, want)
+	}
+}
+
+func TestPaneStatusParsesDeadPane(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		output string
+		want   PaneStatus
+	}{
+		{name: "alive pane", output: "0\t", want: PaneStatus{}},
+		{name: "dead pane with status", output: "1\t3", want: PaneStatus{Dead: true, ExitStatus: 3}},
+		{name: "first pane wins", output: "1\t0\n0\t", want: PaneStatus{Dead: true}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := newManagerWithRunners(func(_ context.Context, args ...string) (string, error) {
+				want := []string{"list-panes", "-t", "audit-1:alpha", "-F", "#{pane_dead}\t#{pane_dead_status}"}
+				if !reflect.DeepEqual(args, want) {
+					t.Fatalf("unexpected args: got %#v want %#v", args, want)
+				}
+				return tc.output, nil
+			}, func(context.Context, ...string) error {
+				return nil
+			})
+
+			got, err := m.PaneStatus("audit-1", "alpha")
+			if err != nil {
+				t.Fatalf("PaneStatus() returned error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("PaneStatus() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestEnsureAvailableReturnsFriendlyError(t *testing.T) {
 	t.Parallel()
 
@@ -6,6 +6,9 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"lattice/internal/config"
+	"lattice/internal/teams"
 )
 
 // AppScreen identifies the active top-level app screen.
@@ -15,6 +18,7 @@ const (
 	MenuScreen AppScreen = iota
 	WizardScreen
 	DashboardScreen
+	HistoryScreen
 )
 
 // AppNavigateMsg requests a top-level screen change.
@@ -38,6 +42,7 @@ type AppModel struct {
 	menu      MenuModel
 	wizard    AuditWizardModel
 	dashboard DashboardModel
+	history   HistoryModel
 
 	launchStarted bool
 
@@ -51,7 +56,7 @@ func NewApp(cwd string) AppModel {
 	keyMap := DefaultKeyMap()
 
 	menu := NewMenuModel().SetStyles(styles).SetKeyMap(keyMap)
-	wizard := NewAuditWizardModel().SetStyles(styles).SetKeyMap(keyMap).SetProjectDir(cwd)
+	wizard := newAppWizard(cwd, styles, keyMap)
 
 	return AppModel{
 		cwd:       cwd,
@@ -61,9 +66,17 @@ func NewApp(cwd string) AppModel {
 		menu:      menu,
 		wizard:    wizard,
 		dashboard: NewDashboardModel(cwd, styles, keyMap),
+		history:   NewHistoryModel(cwd, styles, keyMap),
 	}
 }
 
+// newAppWizard creates the audit wizard with the built-in and project audit types.
+func newAppWizard(cwd string, styles Styles, keyMap KeyMap) AuditWizardModel {
+	auditTypes, err := teams.LoadAuditTypes(cwd)
+	settings, settingsErr := config.LoadUserSettings()
+	return NewAuditWizardModel().SetStyles(styles).SetKeyMap(keyMap).SetProjectDir(cwd).SetAuditTypes(auditTypes, err).SetUserSettings(settings, settingsErr)
+}
+
 // Init initializes the root app model.
 func (m AppModel) Init() tea.Cmd {
 	return nil
@@ -85,6 +98,13 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.dashboard = NewDashboardModel(m.cwd, m.styles, m.keyMap)
 			return m, m.dashboard.Init()
 		}
+		if typed.Screen == HistoryScreen {
+			m.history = NewHistoryModel(m.cwd, m.styles, m.keyMap)
+			return m, m.history.Init()
+		}
+		if typed.Screen == MenuScreen {
+			m.menu = NewMenuModel().SetStyles(m.styles).SetKeyMap(m.keyMap)
+		}
 		return m, nil
 	case tea.KeyMsg:
 		if key.Matches(typed, m.keyMap.Quit) {
@@ -100,9 +120,13 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.menu.Confirmed() {
 			switch m.menu.Action() {
 			case MenuActionOpenAuditWizard:
-				m.wizard = NewAuditWizardModel().SetStyles(m.styles).SetKeyMap(m.keyMap).SetProjectDir(m.cwd)
+				m.wizard = newAppWizard(m.cwd, m.styles, m.keyMap)
 				m.screen = WizardScreen
 				return m, nil
+			case MenuActionOpenHistory:
+				m.history = NewHistoryModel(m.cwd, m.styles, m.keyMap)
+				m.screen = HistoryScreen
+				return m, m.history.Init()
 			case MenuActionQuit:
 				return m, tea.Quit
 			}
@@ -122,12 +146,23 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.wizard.Step() == AuditWizardStepGenerating && !m.wizard.Launched() && !m.launchStarted {
 			m.launchStarted = true
 			launchCmd := launchAuditCmd(launchRequest{
-				cwd:        m.cwd,
-				target:     filepath.Base(m.cwd),
-				auditTypes: m.wizard.SelectedAuditTypes(),
-				agentCount: m.wizard.AgentCount(),
-				intensity:  m.wizard.Rigor().Loops,
-				focusAreas: m.wizard.DiscoveredFocusAreas(),
+				cwd:                m.cwd,
+				target:             filepath.Base(m.cwd),
+				auditTypes:         m.wizard.SelectedAuditTypes(),
+				agentCount:         m.wizard.AgentCount(),
+				intensity:          m.wizard.Rigor().Loops,
+				focusAreas:         m.wizard.DiscoveredFocusAreas(),
+				epics:              m.wizard.EpicSpecs(),
+				dependsOn:          m.wizard.EpicDependencies(),
+				maxConcurrentRoles: m.wizard.MaxConcurrentRoles(),
+				stall:              m.wizard.StallPolicy(),
+				hooks:              m.wizard.Hooks(),
+				executor:           m.wizard.Executor(),
+				tmuxSocket:         m.wizard.TmuxSocket(),
+				agent:              m.wizard.Agent(),
+				defaultAgent:       m.wizard.DefaultAgent(),
+				agentRuntimes:      m.wizard.AgentRuntimes(),
+				agentParams:        m.wizard.AgentParams(),
 			})
 			if cmd == nil {
 				return m, launchCmd
@@ -143,6 +178,8 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case DashboardScreen:
 		m.dashboard, cmd = m.dashboard.Update(msg)
+	case HistoryScreen:
+		m.history, cmd = m.history.Update(msg)
 	}
 
 	return m, cmd
@@ -159,6 +196,8 @@ func (m AppModel) View() string {
 		view = m.wizard.View()
 	case DashboardScreen:
 		view = m.dashboard.View()
+	case HistoryScreen:
+		view = m.history.View()
 	default:
 		view = m.styles.Error.Render("Unknown app screen")
 	}
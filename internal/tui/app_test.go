@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -116,3 +117,26 @@ func TestAppQuitKeyReturnsQuitCommand(t *testing.T) {
 		t.Fatalf("expected tea.QuitMsg, got %T", cmd())
 	}
 }
+
+func TestAppRoutesMenuSelectionToHistory(t *testing.T) {
+	t.Parallel()
+
+	model := NewApp(t.TempDir())
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model = updated.(AppModel)
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(AppModel)
+
+	if got := model.Screen(); got != HistoryScreen {
+		t.Fatalf("expected history screen after selecting history, got %v", got)
+	}
+	if cmd == nil {
+		t.Fatal("expected history screen to load runs")
+	}
+
+	updated, _ = model.Update(cmd())
+	model = updated.(AppModel)
+	if view := model.View(); !strings.Contains(view, "No runs recorded yet.") {
+		t.Fatalf("expected empty history view, got %q", view)
+	}
+}
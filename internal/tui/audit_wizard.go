@@ -2,12 +2,15 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"lattice/internal/agent"
+	"lattice/internal/config"
 	"lattice/internal/discovery"
 	"lattice/internal/teams"
 )
@@ -19,6 +22,7 @@ const (
 	AuditWizardStepMode AuditWizardStep = iota
 	AuditWizardStepDiscovery
 	AuditWizardStepTypes
+	AuditWizardStepAreas
 	AuditWizardStepAgentCount
 	AuditWizardStepRigor
 	AuditWizardStepConfirm
@@ -46,15 +50,32 @@ type AuditWizardModel struct {
 
 	step                  AuditWizardStep
 	projectDir            string
-	discover              func(projectDir string) (discovery.Result, error)
+	discover              func(projectDir string, runtime agent.Runtime) (discovery.Result, error)
 	modeCursor            int
 	mode                  WizardMode
+	auditTypes            []teams.AuditType
+	auditTypesErr         error
 	auditTypeSelect       MultiSelectModel[teams.AuditType]
+	areaSelect            MultiSelectModel[discovery.Area]
+	fanOutByArea          bool
+	runInOrder            bool
+	maxConcurrentRoles    int
+	stall                 config.StallPolicy
+	hooks                 config.HookConfig
+	executor              string
+	tmuxSocket            string
+	agent                 string
+	defaultAgent          string
+	agentRuntimes         map[string]config.AgentRuntime
+	models                []string
+	model                 string
+	settingsErr           error
 	agentCursor           int
 	rigorCursor           int
 	discoveryAreas        []discovery.Area
 	discoveryRunning      bool
 	discoveryUsedFallback bool
+	discoveryAgent        string
 
 	spinner  spinner.Model
 	launched bool
@@ -82,7 +103,24 @@ var wizardRigorOptions = []WizardRigor{
 	{Label: "Go Hard", Loops: 99},
 }
 
-var wizardAgentOptions = []int{1, 2, 3}
+// ParseRigor returns the wizard rigor option matching name, ignoring case and
+// treating spaces, dashes and underscores alike ("go-hard" matches "Go Hard").
+func ParseRigor(name string) (WizardRigor, error) {
+	want := rigorKey(name)
+	names := make([]string, 0, len(wizardRigorOptions))
+	for _, rigor := range wizardRigorOptions {
+		if rigorKey(rigor.Label) == want {
+			return rigor, nil
+		}
+		names = append(names, rigorKey(rigor.Label))
+	}
+
+	return WizardRigor{}, fmt.Errorf("unknown rigor %q (valid: %s)", name, strings.Join(names, ", "))
+}
+
+func rigorKey(label string) string {
+	return strings.NewReplacer(" ", "-", "_", "-").Replace(strings.ToLower(strings.TrimSpace(label)))
+}
 
 // NewAuditWizardModel builds the initial wizard state.
 func NewAuditWizardModel() AuditWizardModel {
@@ -94,12 +132,13 @@ func NewAuditWizardModel() AuditWizardModel {
 		keyMap:     DefaultKeyMap(),
 		step:       AuditWizardStepMode,
 		projectDir: ".",
-		discover:   discovery.Discover,
+		discover:   discovery.DiscoverWith,
 		mode:       WizardModeManual,
+		auditTypes: teams.AuditTypes,
 		spinner:    s,
 	}
 
-	m.auditTypeSelect = newAuditTypeSelect(nil)
+	m.auditTypeSelect = newAuditTypeSelect(m.auditTypes, nil)
 	return m
 }
 
@@ -107,6 +146,7 @@ func NewAuditWizardModel() AuditWizardModel {
 func (m AuditWizardModel) SetStyles(styles Styles) AuditWizardModel {
 	m.styles = styles
 	m.auditTypeSelect = m.auditTypeSelect.SetStyles(styles)
+	m.areaSelect = m.areaSelect.SetStyles(styles)
 	return m
 }
 
@@ -116,6 +156,34 @@ func (m AuditWizardModel) SetKeyMap(keyMap KeyMap) AuditWizardModel {
 	return m
 }
 
+// SetAuditTypes replaces the selectable audit types, e.g. with types loaded
+// from TOML files. A load error is shown on the types step and the built-in
+// types stay selectable.
+func (m AuditWizardModel) SetAuditTypes(auditTypes []teams.AuditType, loadErr error) AuditWizardModel {
+	m.auditTypesErr = loadErr
+	if loadErr != nil || len(auditTypes) == 0 {
+		auditTypes = teams.AuditTypes
+	}
+	m.auditTypes = auditTypes
+	m.auditTypeSelect = newAuditTypeSelect(m.auditTypes, nil).SetStyles(m.styles)
+	return m
+}
+
+// SetUserSettings applies user defaults such as the concurrency limit. A load
+// error is shown on the confirm step and the defaults stay empty.
+func (m AuditWizardModel) SetUserSettings(settings config.UserSettings, loadErr error) AuditWizardModel {
+	m.settingsErr = loadErr
+	m.maxConcurrentRoles = settings.MaxConcurrentRoles
+	m.stall = settings.Stall
+	m.hooks = settings.Hooks
+	m.executor = settings.Executor
+	m.tmuxSocket = settings.TmuxSocket
+	m.defaultAgent = settings.Agent
+	m.agentRuntimes = settings.Agents
+	m.models = settings.Models
+	return m
+}
+
 // SetProjectDir sets the target directory for discovery.
 func (m AuditWizardModel) SetProjectDir(projectDir string) AuditWizardModel {
 	m.projectDir = projectDir
@@ -123,7 +191,7 @@ func (m AuditWizardModel) SetProjectDir(projectDir string) AuditWizardModel {
 }
 
 // SetDiscover overrides discovery execution, primarily for tests.
-func (m AuditWizardModel) SetDiscover(discoverFn func(projectDir string) (discovery.Result, error)) AuditWizardModel {
+func (m AuditWizardModel) SetDiscover(discoverFn func(projectDir string, runtime agent.Runtime) (discovery.Result, error)) AuditWizardModel {
 	m.discover = discoverFn
 	return m
 }
@@ -144,6 +212,12 @@ func (m AuditWizardModel) Update(msg tea.Msg) (AuditWizardModel, tea.Cmd) {
 					}
 				case AuditWizardStepDiscovery:
 					m.step = AuditWizardStepMode
+				case AuditWizardStepAgentCount:
+					if m.hasAreaStep() {
+						m.step = AuditWizardStepAreas
+					} else {
+						m.step = AuditWizardStepTypes
+					}
 				default:
 					m.step--
 				}
@@ -167,6 +241,8 @@ func (m AuditWizardModel) Update(msg tea.Msg) (AuditWizardModel, tea.Cmd) {
 			return m, nil
 		case AuditWizardStepTypes:
 			return m.updateStepTypes(typed)
+		case AuditWizardStepAreas:
+			return m.updateStepAreas(typed)
 		case AuditWizardStepAgentCount:
 			return m.updateStepAgentCount(typed)
 		case AuditWizardStepRigor:
@@ -192,6 +268,8 @@ func (m AuditWizardModel) Update(msg tea.Msg) (AuditWizardModel, tea.Cmd) {
 
 			m.discoveryAreas = typed.result.Areas
 			m.discoveryUsedFallback = typed.result.UsedFallback
+			m.areaSelect = newAreaSelect(m.discoveryAreas, nil).SetStyles(m.styles)
+			m.fanOutByArea = false
 			m.validationErr = ""
 			m.step = AuditWizardStepTypes
 		}
@@ -231,6 +309,7 @@ func (m AuditWizardModel) updateStepMode(msg tea.KeyMsg) (AuditWizardModel, tea.
 			m.discoveryRunning = true
 			m.discoveryAreas = nil
 			m.discoveryUsedFallback = false
+			m.discoveryAgent = fallbackText(m.defaultAgent, config.AgentOpencode)
 			m.validationErr = ""
 			return m, m.discoveryCmd()
 		}
@@ -243,13 +322,23 @@ func (m AuditWizardModel) updateStepMode(msg tea.KeyMsg) (AuditWizardModel, tea.
 func (m AuditWizardModel) discoveryCmd() tea.Cmd {
 	projectDir := m.projectDir
 	discoverFn := m.discover
+	defaultAgent, agentRuntimes := m.defaultAgent, m.agentRuntimes
 	return func() tea.Msg {
-		result, err := discoverFn(projectDir)
+		runtime, err := agent.Resolve(defaultAgent, agentRuntimes)
+		if err != nil {
+			return discoveryFinishedMsg{err: err}
+		}
+		result, err := discoverFn(projectDir, runtime)
 		return discoveryFinishedMsg{result: result, err: err}
 	}
 }
 
 func (m AuditWizardModel) updateStepTypes(msg tea.KeyMsg) (AuditWizardModel, tea.Cmd) {
+	if strings.EqualFold(msg.String(), "o") {
+		m.runInOrder = !m.runInOrder
+		return m, nil
+	}
+
 	nextModel, cmd := m.auditTypeSelect.Update(msg)
 	m.auditTypeSelect = nextModel
 
@@ -259,26 +348,108 @@ func (m AuditWizardModel) updateStepTypes(msg tea.KeyMsg) (AuditWizardModel, tea
 
 	if len(m.auditTypeSelect.SelectedItems()) == 0 {
 		m.validationErr = "Select at least one audit type to continue."
-		m.auditTypeSelect = newAuditTypeSelect(nil)
+		m.auditTypeSelect = newAuditTypeSelect(m.auditTypes, nil)
+		return m, nil
+	}
+
+	m.auditTypeSelect = newAuditTypeSelect(m.auditTypes, m.selectedAuditTypeIDs())
+	options := m.agentOptions()
+	if len(options) == 0 {
+		m.validationErr = "The selected audit types have no investigator count in common."
+		return m, nil
+	}
+	if m.agentCursor >= len(options) {
+		m.agentCursor = 0
+	}
+
+	m.validationErr = ""
+	m.step = AuditWizardStepAgentCount
+	if m.hasAreaStep() {
+		m.step = AuditWizardStepAreas
+	}
+	return m, cmd
+}
+
+func (m AuditWizardModel) updateStepAreas(msg tea.KeyMsg) (AuditWizardModel, tea.Cmd) {
+	if strings.EqualFold(msg.String(), "f") {
+		m.fanOutByArea = !m.fanOutByArea
+		return m, nil
+	}
+
+	nextModel, cmd := m.areaSelect.Update(msg)
+	m.areaSelect = nextModel
+
+	if !m.areaSelect.Confirmed() {
+		return m, cmd
+	}
+
+	selectedPaths := m.selectedAreaPaths()
+	if len(selectedPaths) == 0 {
+		m.validationErr = "Select at least one area to continue."
+		m.areaSelect = newAreaSelect(m.discoveryAreas, selectedPaths).SetStyles(m.styles)
 		return m, nil
 	}
 
 	m.validationErr = ""
 	m.step = AuditWizardStepAgentCount
-	m.auditTypeSelect = newAuditTypeSelect(m.selectedAuditTypeIDs())
+	m.areaSelect = newAreaSelect(m.discoveryAreas, selectedPaths).SetStyles(m.styles)
 	return m, cmd
 }
 
+// hasAreaStep reports whether discovery produced areas to choose from.
+func (m AuditWizardModel) hasAreaStep() bool {
+	return m.mode == WizardModeAutoGenerate && len(m.discoveryAreas) > 0
+}
+
+func (m AuditWizardModel) selectedAreaPaths() map[string]struct{} {
+	paths := make(map[string]struct{}, len(m.discoveryAreas))
+	for _, item := range m.areaSelect.SelectedItems() {
+		paths[item.Value.Path] = struct{}{}
+	}
+
+	return paths
+}
+
+// agentOptions returns the investigator counts every selected audit type defines a role config for.
+func (m AuditWizardModel) agentOptions() []int {
+	selected := m.SelectedAuditTypes()
+	if len(selected) == 0 {
+		return nil
+	}
+
+	counts := make(map[int]int)
+	for _, auditType := range selected {
+		for _, roleConfig := range auditType.RoleConfigs {
+			counts[roleConfig.AgentCount]++
+		}
+	}
+
+	options := make([]int, 0, len(counts))
+	for count, types := range counts {
+		if types == len(selected) {
+			options = append(options, count)
+		}
+	}
+	sort.Ints(options)
+
+	return options
+}
+
 func (m AuditWizardModel) updateStepAgentCount(msg tea.KeyMsg) (AuditWizardModel, tea.Cmd) {
+	options := m.agentOptions()
+	if len(options) == 0 {
+		return m, nil
+	}
+
 	switch {
 	case key.Matches(msg, m.keyMap.Up):
 		if m.agentCursor == 0 {
-			m.agentCursor = len(wizardAgentOptions) - 1
+			m.agentCursor = len(options) - 1
 		} else {
 			m.agentCursor--
 		}
 	case key.Matches(msg, m.keyMap.Down):
-		m.agentCursor = (m.agentCursor + 1) % len(wizardAgentOptions)
+		m.agentCursor = (m.agentCursor + 1) % len(options)
 	case key.Matches(msg, m.keyMap.Select):
 		m.step = AuditWizardStepRigor
 	}
@@ -304,6 +475,22 @@ func (m AuditWizardModel) updateStepRigor(msg tea.KeyMsg) (AuditWizardModel, tea
 }
 
 func (m AuditWizardModel) updateStepConfirm(msg tea.KeyMsg) (AuditWizardModel, tea.Cmd) {
+	switch msg.String() {
+	case "+", "=":
+		m.maxConcurrentRoles++
+		return m, nil
+	case "-":
+		if m.maxConcurrentRoles > 0 {
+			m.maxConcurrentRoles--
+		}
+		return m, nil
+	case "a":
+		m.agent = nextAgentChoice(m.agent, m.agentRuntimes)
+		return m, nil
+	case "m":
+		m.model = nextChoice(m.model, append([]string{""}, m.models...))
+		return m, nil
+	}
 	if !key.Matches(msg, m.keyMap.Select) {
 		return m, nil
 	}
@@ -339,6 +526,8 @@ func (m AuditWizardModel) View() string {
 		lines = append(lines, m.viewDiscoveryStep()...)
 	case AuditWizardStepTypes:
 		lines = append(lines, m.viewTypesStep()...)
+	case AuditWizardStepAreas:
+		lines = append(lines, m.viewAreasStep()...)
 	case AuditWizardStepAgentCount:
 		lines = append(lines, m.viewAgentStep()...)
 	case AuditWizardStepRigor:
@@ -389,12 +578,39 @@ func (m AuditWizardModel) viewDiscoveryStep() []string {
 }
 
 func (m AuditWizardModel) viewTypesStep() []string {
-	return []string{m.auditTypeSelect.View()}
+	order := "off: selected audit types start together"
+	if m.runInOrder {
+		order = "on: each audit type waits for the one listed before it"
+	}
+
+	var lines []string
+	if m.auditTypesErr != nil {
+		lines = append(lines, m.styles.Error.Render(fmt.Sprintf("Custom audit types not loaded: %v", m.auditTypesErr)), "")
+	}
+
+	return append(lines,
+		m.auditTypeSelect.View(),
+		"",
+		m.styles.Body.Render(fmt.Sprintf("Run in order: %s", order)),
+	)
+}
+
+func (m AuditWizardModel) viewAreasStep() []string {
+	fanOut := "off: every role reviews all selected areas"
+	if m.fanOutByArea {
+		fanOut = "on: one epic per selected area and audit type"
+	}
+
+	return []string{
+		m.areaSelect.View(),
+		"",
+		m.styles.Body.Render(fmt.Sprintf("Fan out by area: %s", fanOut)),
+	}
 }
 
 func (m AuditWizardModel) viewAgentStep() []string {
 	lines := []string{"Select investigator count:"}
-	for idx, count := range wizardAgentOptions {
+	for idx, count := range m.agentOptions() {
 		prefix := " "
 		if idx == m.agentCursor {
 			prefix = m.styles.FocusedMark.Render(">")
@@ -439,20 +655,57 @@ func (m AuditWizardModel) viewConfirmStep() []string {
 
 	discoveryStatus := "n/a"
 	if m.mode == WizardModeAutoGenerate {
-		discoveryStatus = "opencode"
+		discoveryStatus = m.discoveryAgent
 		if m.discoveryUsedFallback {
 			discoveryStatus = "fallback"
 		}
 	}
 
-	return []string{
+	areaCount := len(m.discoveryAreas)
+	if m.hasAreaStep() {
+		areaCount = len(m.selectedAreas())
+	}
+
+	lines := []string{
 		"Confirm launch settings:",
 		m.styles.ListItem.Render(fmt.Sprintf("Mode: %s", m.Mode().String())),
 		m.styles.ListItem.Render(fmt.Sprintf("Audit types: %s", strings.Join(typeNames, ", "))),
-		m.styles.ListItem.Render(fmt.Sprintf("Discovery areas: %d (%s)", len(m.discoveryAreas), discoveryStatus)),
+		m.styles.ListItem.Render(fmt.Sprintf("Discovery areas: %d (%s)", areaCount, discoveryStatus)),
+	}
+	if specs := m.EpicSpecs(); len(specs) > 0 {
+		lines = append(lines, m.styles.ListItem.Render(fmt.Sprintf("Fan out by area: %d epic%s", len(specs), pluralSuffix(len(specs)))))
+	}
+	if m.runInOrder && len(typeNames) > 1 {
+		lines = append(lines, m.styles.ListItem.Render(fmt.Sprintf("Run in order: %s", strings.Join(typeNames, " → "))))
+	}
+
+	maxConcurrent := "no limit"
+	if m.maxConcurrentRoles > 0 {
+		maxConcurrent = fmt.Sprintf("%d", m.maxConcurrentRoles)
+	}
+
+	lines = append(lines,
 		m.styles.ListItem.Render(fmt.Sprintf("Investigators: %d", m.AgentCount())),
 		m.styles.ListItem.Render(fmt.Sprintf("Rigor: %s (%d loop%s)", m.Rigor().Label, m.Rigor().Loops, pluralSuffix(m.Rigor().Loops))),
+		m.styles.ListItem.Render(fmt.Sprintf("Max concurrent roles: %s", maxConcurrent)),
+	)
+	if timeout := strings.TrimSpace(m.stall.Timeout); timeout != "" {
+		lines = append(lines, m.styles.ListItem.Render(fmt.Sprintf("Stalled after: %s (%s)", timeout, m.stall.ResolvedAction())))
+	}
+	if executor := strings.TrimSpace(m.executor); executor != "" {
+		lines = append(lines, m.styles.ListItem.Render(fmt.Sprintf("Executor: %s", executor)))
+	}
+	agentLabel := m.agent
+	if agentLabel == "" {
+		agentLabel = fmt.Sprintf("per audit type (default %s)", fallbackText(m.defaultAgent, config.AgentOpencode))
 	}
+	lines = append(lines, m.styles.ListItem.Render(fmt.Sprintf("Agent runtime: %s", agentLabel)))
+	lines = append(lines, m.styles.ListItem.Render(fmt.Sprintf("Model: %s", fallbackText(m.model, "per role"))))
+	if m.settingsErr != nil {
+		lines = append(lines, m.styles.Error.Render(fmt.Sprintf("User settings not loaded: %v", m.settingsErr)))
+	}
+
+	return lines
 }
 
 func (m AuditWizardModel) viewGeneratingStep() []string {
@@ -468,34 +721,70 @@ func (m AuditWizardModel) viewGeneratingStep() []string {
 
 func (m AuditWizardModel) helpText() string {
 	if m.step == AuditWizardStepTypes {
-		return "esc: back • ↑/k: up • ↓/j: down • space: toggle • a: select all • enter: continue"
+		return "esc: back • ↑/k: up • ↓/j: down • space: toggle • a: select all • o: run in order • enter: continue"
+	}
+	if m.step == AuditWizardStepAreas {
+		return "esc: back • ↑/k: up • ↓/j: down • space: toggle • a: select all • f: fan out by area • enter: continue"
 	}
 	if m.step == AuditWizardStepDiscovery {
 		return "esc: back • analyzing project structure"
 	}
+	if m.step == AuditWizardStepConfirm {
+		return "esc: back • +/-: max concurrent roles • a: agent runtime • m: model • enter: launch"
+	}
 
 	return "esc: back • ↑/k: up • ↓/j: down • enter: continue"
 }
 
+var wizardStepNames = map[AuditWizardStep]string{
+	AuditWizardStepMode:       "Mode",
+	AuditWizardStepDiscovery:  "Discovery",
+	AuditWizardStepTypes:      "Audit Types",
+	AuditWizardStepAreas:      "Areas",
+	AuditWizardStepAgentCount: "Agent Count",
+	AuditWizardStepRigor:      "Rigor",
+	AuditWizardStepConfirm:    "Confirm",
+	AuditWizardStepGenerating: "Generating",
+}
+
+// stepLabel numbers the current step among the steps the user sees, from Mode
+// as step 0.
 func (m AuditWizardModel) stepLabel() string {
-	switch m.step {
-	case AuditWizardStepMode:
-		return "Step 0/6: Mode"
-	case AuditWizardStepDiscovery:
-		return "Step 1/6: Discovery"
-	case AuditWizardStepTypes:
-		return "Step 2/6: Audit Types"
-	case AuditWizardStepAgentCount:
-		return "Step 3/6: Agent Count"
-	case AuditWizardStepRigor:
-		return "Step 4/6: Rigor"
-	case AuditWizardStepConfirm:
-		return "Step 5/6: Confirm"
-	case AuditWizardStepGenerating:
-		return "Step 6/6: Generating"
-	default:
+	name, ok := wizardStepNames[m.step]
+	if !ok {
 		return "Audit Wizard"
 	}
+
+	steps := m.visibleSteps()
+	for idx, step := range steps {
+		if step == m.step {
+			return fmt.Sprintf("Step %d/%d: %s", idx, len(steps)-1, name)
+		}
+	}
+
+	return name
+}
+
+// visibleSteps lists the steps of the wizard for the chosen mode, or for the
+// highlighted mode on the Mode step. Discovery and Areas appear only in
+// auto-generate mode; Areas is counted while discovery runs and dropped when
+// it finds no areas.
+func (m AuditWizardModel) visibleSteps() []AuditWizardStep {
+	mode := m.mode
+	if m.step == AuditWizardStepMode {
+		mode = wizardModeOptions[m.modeCursor].mode
+	}
+
+	steps := []AuditWizardStep{AuditWizardStepMode}
+	if mode == WizardModeAutoGenerate {
+		steps = append(steps, AuditWizardStepDiscovery)
+	}
+	steps = append(steps, AuditWizardStepTypes)
+	if mode == WizardModeAutoGenerate && (m.step <= AuditWizardStepDiscovery || m.discoveryRunning || len(m.discoveryAreas) > 0) {
+		steps = append(steps, AuditWizardStepAreas)
+	}
+
+	return append(steps, AuditWizardStepAgentCount, AuditWizardStepRigor, AuditWizardStepConfirm, AuditWizardStepGenerating)
 }
 
 func pluralSuffix(count int) string {
@@ -506,9 +795,9 @@ func pluralSuffix(count int) string {
 	return "s"
 }
 
-func newAuditTypeSelect(selectedIDs map[string]struct{}) MultiSelectModel[teams.AuditType] {
-	items := make([]MultiSelectItem[teams.AuditType], 0, len(teams.AuditTypes))
-	for _, auditType := range teams.AuditTypes {
+func newAuditTypeSelect(auditTypes []teams.AuditType, selectedIDs map[string]struct{}) MultiSelectModel[teams.AuditType] {
+	items := make([]MultiSelectItem[teams.AuditType], 0, len(auditTypes))
+	for _, auditType := range auditTypes {
 		_, selected := selectedIDs[auditType.ID]
 		items = append(items, MultiSelectItem[teams.AuditType]{
 			Label:       auditType.Name,
@@ -521,6 +810,24 @@ func newAuditTypeSelect(selectedIDs map[string]struct{}) MultiSelectModel[teams.
 	return NewMultiSelectModel("Select audit types", items)
 }
 
+func newAreaSelect(areas []discovery.Area, selectedPaths map[string]struct{}) MultiSelectModel[discovery.Area] {
+	items := make([]MultiSelectItem[discovery.Area], 0, len(areas))
+	for _, area := range areas {
+		selected := true
+		if selectedPaths != nil {
+			_, selected = selectedPaths[area.Path]
+		}
+		items = append(items, MultiSelectItem[discovery.Area]{
+			Label:       fmt.Sprintf("%s (%s)", area.Name, area.Path),
+			Description: area.Description,
+			Selected:    selected,
+			Value:       area,
+		})
+	}
+
+	return NewMultiSelectModel("Select areas to audit", items)
+}
+
 // Mode returns the selected wizard mode.
 func (m AuditWizardModel) Mode() WizardMode {
 	return m.mode
@@ -539,7 +846,12 @@ func (m AuditWizardModel) SelectedAuditTypes() []teams.AuditType {
 
 // AgentCount returns selected number of investigators.
 func (m AuditWizardModel) AgentCount() int {
-	return wizardAgentOptions[m.agentCursor]
+	options := m.agentOptions()
+	if m.agentCursor >= len(options) {
+		return 0
+	}
+
+	return options[m.agentCursor]
 }
 
 // Rigor returns selected rigor settings.
@@ -547,6 +859,76 @@ func (m AuditWizardModel) Rigor() WizardRigor {
 	return wizardRigorOptions[m.rigorCursor]
 }
 
+// MaxConcurrentRoles returns the running role limit for the launch; 0 means no limit.
+func (m AuditWizardModel) MaxConcurrentRoles() int {
+	return m.maxConcurrentRoles
+}
+
+// StallPolicy returns the stall policy from the user settings.
+func (m AuditWizardModel) StallPolicy() config.StallPolicy {
+	return m.stall
+}
+
+// Hooks returns the hook commands from the user settings.
+func (m AuditWizardModel) Hooks() config.HookConfig {
+	return m.hooks
+}
+
+// Executor returns the executor from the user settings; empty means tmux.
+func (m AuditWizardModel) Executor() string {
+	return m.executor
+}
+
+// TmuxSocket returns the tmux server from the user settings; empty means the
+// default server.
+func (m AuditWizardModel) TmuxSocket() string {
+	return m.tmuxSocket
+}
+
+// Agent returns the agent runtime picked for every epic; empty lets each audit
+// type pick.
+func (m AuditWizardModel) Agent() string {
+	return m.agent
+}
+
+// DefaultAgent returns the default agent runtime from the user settings.
+func (m AuditWizardModel) DefaultAgent() string {
+	return m.defaultAgent
+}
+
+// AgentRuntimes returns the custom agent runtimes from the user settings.
+func (m AuditWizardModel) AgentRuntimes() map[string]config.AgentRuntime {
+	return m.agentRuntimes
+}
+
+// AgentParams returns the run-level override of every role's agent params.
+func (m AuditWizardModel) AgentParams() config.AgentParams {
+	return config.AgentParams{Model: m.model}
+}
+
+// nextAgentChoice cycles the run-level agent choice through "per audit type",
+// opencode and the custom runtimes in name order.
+func nextAgentChoice(current string, runtimes map[string]config.AgentRuntime) string {
+	names := make([]string, 0, len(runtimes))
+	for name := range runtimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return nextChoice(current, append([]string{"", config.AgentOpencode}, names...))
+}
+
+// nextChoice returns the choice after current, wrapping to the first.
+func nextChoice(current string, choices []string) string {
+	for idx, choice := range choices {
+		if choice == current {
+			return choices[(idx+1)%len(choices)]
+		}
+	}
+
+	return choices[0]
+}
+
 // Step returns the active wizard step.
 func (m AuditWizardModel) Step() AuditWizardStep {
 	return m.step
@@ -557,20 +939,79 @@ func (m AuditWizardModel) Launched() bool {
 	return m.launched
 }
 
-// DiscoveredFocusAreas returns discovered area summaries for audit context.
+// DiscoveredFocusAreas returns selected discovered area summaries for audit context.
 func (m AuditWizardModel) DiscoveredFocusAreas() []string {
-	if m.mode != WizardModeAutoGenerate || len(m.discoveryAreas) == 0 {
+	if !m.hasAreaStep() {
 		return nil
 	}
 
-	focus := make([]string, 0, len(m.discoveryAreas))
-	for _, area := range m.discoveryAreas {
-		focus = append(focus, fmt.Sprintf("%s (%s): %s", area.Name, area.Path, area.Description))
+	areas := m.selectedAreas()
+	focus := make([]string, 0, len(areas))
+	for _, area := range areas {
+		focus = append(focus, formatFocusArea(area))
 	}
 
 	return focus
 }
 
+// FanOutByArea reports whether each selected area gets its own epics.
+func (m AuditWizardModel) FanOutByArea() bool {
+	return m.fanOutByArea && m.hasAreaStep()
+}
+
+// EpicSpecs returns one epic per selected area and audit type when fan-out is on.
+// It returns nil otherwise, meaning one epic per audit type covering all areas.
+func (m AuditWizardModel) EpicSpecs() []teams.EpicSpec {
+	if !m.FanOutByArea() {
+		return nil
+	}
+
+	areas := m.selectedAreas()
+	auditTypes := m.SelectedAuditTypes()
+	specs := make([]teams.EpicSpec, 0, len(areas)*len(auditTypes))
+	for _, auditType := range auditTypes {
+		for _, area := range areas {
+			specs = append(specs, teams.EpicSpec{
+				AuditType:  auditType,
+				Target:     area.Path,
+				FocusAreas: []string{formatFocusArea(area)},
+			})
+		}
+	}
+
+	return specs
+}
+
+// EpicDependencies chains the selected audit types in list order when running in
+// order is on, so each audit type waits for the one before it. It returns nil otherwise.
+func (m AuditWizardModel) EpicDependencies() map[string][]string {
+	selected := m.SelectedAuditTypes()
+	if !m.runInOrder || len(selected) < 2 {
+		return nil
+	}
+
+	deps := make(map[string][]string, len(selected)-1)
+	for idx := 1; idx < len(selected); idx++ {
+		deps[selected[idx].ID] = []string{selected[idx-1].ID}
+	}
+
+	return deps
+}
+
+func (m AuditWizardModel) selectedAreas() []discovery.Area {
+	selectedItems := m.areaSelect.SelectedItems()
+	areas := make([]discovery.Area, 0, len(selectedItems))
+	for _, item := range selectedItems {
+		areas = append(areas, item.Value)
+	}
+
+	return areas
+}
+
+func formatFocusArea(area discovery.Area) string {
+	return fmt.Sprintf("%s (%s): %s", area.Name, area.Path, area.Description)
+}
+
 // String renders wizard modes for summary output.
 func (m WizardMode) String() string {
 	switch m {
@@ -7,7 +7,10 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"lattice/internal/agent"
+	"lattice/internal/config"
 	"lattice/internal/discovery"
+	"lattice/internal/teams"
 )
 
 func TestAuditWizardEndToEndFlow(t *testing.T) {
@@ -125,10 +128,13 @@ func TestAuditWizardAllowsEditingSelectionsAfterGoingBack(t *testing.T) {
 func TestAuditWizardAutoModeRunsDiscoveryBeforeAuditTypes(t *testing.T) {
 	t.Parallel()
 
-	model := NewAuditWizardModel().SetProjectDir("/tmp/project").SetDiscover(func(projectDir string) (discovery.Result, error) {
+	model := NewAuditWizardModel().SetProjectDir("/tmp/project").SetDiscover(func(projectDir string, runtime agent.Runtime) (discovery.Result, error) {
 		if projectDir != "/tmp/project" {
 			return discovery.Result{}, fmt.Errorf("unexpected project dir: %s", projectDir)
 		}
+		if runtime.Name() != config.AgentOpencode {
+			return discovery.Result{}, fmt.Errorf("unexpected agent runtime: %s", runtime.Name())
+		}
 
 		return discovery.Result{Areas: []discovery.Area{
 			{Name: "Routing", Path: "internal/tui", Description: "Check navigation state transitions."},
@@ -160,3 +166,284 @@ func TestAuditWizardAutoModeRunsDiscoveryBeforeAuditTypes(t *testing.T) {
 		t.Fatalf("expected rendered focus area details, got %q", focusAreas[0])
 	}
 }
+
+func TestAuditWizardStepLabelsCountVisibleSteps(t *testing.T) {
+	t.Parallel()
+
+	manual := NewAuditWizardModel()
+	if got := manual.stepLabel(); got != "Step 0/5: Mode" {
+		t.Fatalf("expected manual mode to skip discovery and areas, got %q", got)
+	}
+	manual, _ = manual.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := manual.stepLabel(); got != "Step 1/5: Audit Types" {
+		t.Fatalf("unexpected manual types label %q", got)
+	}
+
+	tests := []struct {
+		name  string
+		areas []discovery.Area
+		want  string
+	}{
+		{name: "no areas", want: "Step 2/6: Audit Types"},
+		{name: "areas", areas: []discovery.Area{{Name: "Routing", Path: "internal/tui"}}, want: "Step 2/7: Audit Types"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			model := NewAuditWizardModel().SetDiscover(func(string, agent.Runtime) (discovery.Result, error) {
+				return discovery.Result{Areas: tt.areas}, nil
+			})
+			model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+			if got := model.stepLabel(); got != "Step 0/7: Mode" {
+				t.Fatalf("expected the highlighted auto mode to count its steps, got %q", got)
+			}
+			model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			if got := model.stepLabel(); got != "Step 1/7: Discovery" {
+				t.Fatalf("unexpected discovery label %q", got)
+			}
+
+			model, _ = model.Update(cmd())
+			if got := model.stepLabel(); got != tt.want {
+				t.Fatalf("stepLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditWizardFanOutByAreaBuildsEpicPerAreaAndType(t *testing.T) {
+	t.Parallel()
+
+	model := NewAuditWizardModel().SetDiscover(func(string, agent.Runtime) (discovery.Result, error) {
+		return discovery.Result{Areas: []discovery.Area{
+			{Name: "API", Path: "internal/api", Description: "Request handlers."},
+			{Name: "Auth", Path: "internal/auth", Description: "Session handling."},
+			{Name: "Docs", Path: "docs", Description: "Documentation."},
+		}}, nil
+	})
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(cmd())
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := model.Step(); got != AuditWizardStepAreas {
+		t.Fatalf("expected areas step after audit types, got %v", got)
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if !strings.Contains(model.View(), "Fan out by area: on") {
+		t.Fatalf("expected fan-out toggle in view, got %q", model.View())
+	}
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := model.Step(); got != AuditWizardStepAgentCount {
+		t.Fatalf("expected agent count step after areas, got %v", got)
+	}
+
+	specs := model.EpicSpecs()
+	if len(specs) != 4 {
+		t.Fatalf("expected 2 types x 2 areas = 4 epics, got %d", len(specs))
+	}
+	targets := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		targets = append(targets, spec.Target)
+		if len(spec.FocusAreas) != 1 || !strings.Contains(spec.FocusAreas[0], spec.Target) {
+			t.Fatalf("expected focus area scoped to target, got %+v", spec)
+		}
+	}
+	if strings.Join(targets, ",") != "internal/api,internal/auth,internal/api,internal/auth" {
+		t.Fatalf("unexpected epic targets: %v", targets)
+	}
+	if got := len(model.DiscoveredFocusAreas()); got != 2 {
+		t.Fatalf("expected only selected areas as focus areas, got %d", got)
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := model.Step(); got != AuditWizardStepAreas {
+		t.Fatalf("expected back from agent count to return to areas, got %v", got)
+	}
+}
+
+func TestAuditWizardWithoutFanOutHasNoEpicSpecs(t *testing.T) {
+	t.Parallel()
+
+	model := NewAuditWizardModel()
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := model.Step(); got != AuditWizardStepAgentCount {
+		t.Fatalf("expected manual mode to skip the areas step, got %v", got)
+	}
+	if specs := model.EpicSpecs(); specs != nil {
+		t.Fatalf("expected no epic specs without fan-out, got %+v", specs)
+	}
+}
+
+func TestAuditWizardRunInOrderChainsSelectedTypes(t *testing.T) {
+	t.Parallel()
+
+	model := NewAuditWizardModel()
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if deps := model.EpicDependencies(); deps != nil {
+		t.Fatalf("expected no dependencies before run in order is on, got %#v", deps)
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if !strings.Contains(model.View(), "Run in order: on") {
+		t.Fatalf("expected types step to show run in order, got:\n%s", model.View())
+	}
+
+	selected := model.SelectedAuditTypes()
+	deps := model.EpicDependencies()
+	if len(deps) != len(selected)-1 {
+		t.Fatalf("expected %d dependencies, got %#v", len(selected)-1, deps)
+	}
+	for idx := 1; idx < len(selected); idx++ {
+		if got := deps[selected[idx].ID]; len(got) != 1 || got[0] != selected[idx-1].ID {
+			t.Fatalf("expected %s to wait for %s, got %#v", selected[idx].ID, selected[idx-1].ID, got)
+		}
+	}
+}
+
+func TestAuditWizardConfirmStepAdjustsConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	model := NewAuditWizardModel().SetUserSettings(config.UserSettings{MaxConcurrentRoles: 1}, nil)
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := model.Step(); got != AuditWizardStepConfirm {
+		t.Fatalf("expected step confirm, got %v", got)
+	}
+	if !strings.Contains(model.View(), "Max concurrent roles: 1") {
+		t.Fatalf("expected confirm step to show the user default, got:\n%s", model.View())
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	if got := model.MaxConcurrentRoles(); got != 2 {
+		t.Fatalf("expected limit 2 after +, got %d", got)
+	}
+
+	for i := 0; i < 3; i++ {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+	}
+	if got := model.MaxConcurrentRoles(); got != 0 {
+		t.Fatalf("expected limit to stop at 0, got %d", got)
+	}
+	if !strings.Contains(model.View(), "Max concurrent roles: no limit") {
+		t.Fatalf("expected confirm step to show no limit, got:\n%s", model.View())
+	}
+}
+
+func TestAuditWizardConfirmStepCyclesAgentRuntime(t *testing.T) {
+	t.Parallel()
+
+	settings := config.UserSettings{
+		Agent:  "claude",
+		Agents: map[string]config.AgentRuntime{"claude": {Command: "claude -p {{.Prompt}}"}},
+	}
+	model := NewAuditWizardModel().SetUserSettings(settings, nil)
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := model.Step(); got != AuditWizardStepConfirm {
+		t.Fatalf("expected step confirm, got %v", got)
+	}
+	if !strings.Contains(model.View(), "Agent runtime: per audit type (default claude)") {
+		t.Fatalf("expected confirm step to show the default runtime, got:\n%s", model.View())
+	}
+
+	var picked []string
+	for i := 0; i < 3; i++ {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+		picked = append(picked, model.Agent())
+	}
+	if got := strings.Join(picked, ","); got != "opencode,claude," {
+		t.Fatalf("expected a to cycle opencode, claude and back, got %q", got)
+	}
+	if model.DefaultAgent() != "claude" || len(model.AgentRuntimes()) != 1 {
+		t.Fatalf("expected the settings runtimes to pass through, got %q %v", model.DefaultAgent(), model.AgentRuntimes())
+	}
+}
+
+func TestParseRigorMatchesWizardOptions(t *testing.T) {
+	t.Parallel()
+
+	for input, wantLoops := range map[string]int{"light": 1, "Standard": 3, "go-hard": 99, "go hard": 99, "GO_HARD": 99} {
+		rigor, err := ParseRigor(input)
+		if err != nil {
+			t.Fatalf("ParseRigor(%q) returned error: %v", input, err)
+		}
+		if rigor.Loops != wantLoops {
+			t.Fatalf("ParseRigor(%q) loops = %d, want %d", input, rigor.Loops, wantLoops)
+		}
+	}
+
+	if _, err := ParseRigor("max"); err == nil || !strings.Contains(err.Error(), "light, standard, go-hard") {
+		t.Fatalf("expected error listing valid rigor levels, got %v", err)
+	}
+}
+
+func TestAuditWizardSetAuditTypesListsCustomTypes(t *testing.T) {
+	t.Parallel()
+
+	custom := teams.AuditType{ID: "graphql", Name: "GraphQL Schema Audit", Description: "Schema review"}
+	m := NewAuditWizardModel().SetAuditTypes([]teams.AuditType{custom}, nil)
+	m.step = AuditWizardStepTypes
+	if !strings.Contains(m.View(), "GraphQL Schema Audit") {
+		t.Fatalf("expected custom audit type in view, got %q", m.View())
+	}
+
+	failed := NewAuditWizardModel().SetAuditTypes(nil, fmt.Errorf("duplicate audit type id"))
+	failed.step = AuditWizardStepTypes
+	view := failed.View()
+	if !strings.Contains(view, "Custom audit types not loaded: duplicate audit type id") || !strings.Contains(view, teams.AuditTypes[0].Name) {
+		t.Fatalf("expected load error and built-in types, got %q", view)
+	}
+}
+
+func TestAuditWizardOffersAgentCountsSharedBySelectedTypes(t *testing.T) {
+	t.Parallel()
+
+	security, _ := teams.FindAuditType(teams.AuditTypes, "security")
+	gql := teams.AuditType{
+		ID: "graphql", Name: "GraphQL Schema Audit",
+		RoleConfigs: []teams.AgentConfigRoles{{AgentCount: 2}, {AgentCount: 5}},
+	}
+	m := NewAuditWizardModel().SetAuditTypes([]teams.AuditType{security, gql}, nil)
+	m.step = AuditWizardStepTypes
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := fmt.Sprint(m.agentOptions()); got != "[1 2 3 5]" {
+		t.Fatalf("expected security agent counts, got %s", got)
+	}
+
+	m.step = AuditWizardStepTypes
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Step() != AuditWizardStepAgentCount {
+		t.Fatalf("expected agent count step, got %v", m.Step())
+	}
+	if got := fmt.Sprint(m.agentOptions()); got != "[2 5]" {
+		t.Fatalf("expected shared agent counts, got %s", got)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := m.AgentCount(); got != 5 {
+		t.Fatalf("expected 5 agents, got %d", got)
+	}
+}
@@ -0,0 +1,577 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"lattice/internal/agent"
+	"lattice/internal/config"
+	"lattice/internal/discovery"
+	"lattice/internal/events"
+	"lattice/internal/executor"
+	"lattice/internal/teams"
+)
+
+// AuditOptions configures a non-interactive audit launch.
+type AuditOptions struct {
+	AuditTypes []string
+	AgentCount int
+	Rigor      string
+	// Target is a project-relative directory to audit; empty audits the whole project.
+	Target string
+	// Discover runs area discovery on the target and passes the areas as focus areas.
+	Discover bool
+	// FanOut plans one epic per discovered area and audit type. It requires Discover.
+	FanOut bool
+	// DependsOn maps an audit type ID to the audit types whose epics must complete
+	// before its own epics start. Both sides must be among AuditTypes.
+	DependsOn map[string][]string
+	// MaxConcurrentRoles caps running role sessions across all epics. 0 uses the
+	// user default from settings.toml and a negative value removes the limit.
+	MaxConcurrentRoles int
+	// Retry relaunches failed roles. When MaxAttempts is 0 each audit type's own
+	// policy applies.
+	Retry config.RetryPolicy
+	// Stall decides when a running role counts as stalled. Empty fields use the
+	// user defaults from settings.toml.
+	Stall config.StallPolicy
+	// Executor starts the run's roles: tmux or process. Empty uses the user
+	// default from settings.toml, otherwise tmux.
+	Executor string
+	// Agent names the agent runtime every epic runs with. Empty lets each audit
+	// type pick, falling back to the user default and then opencode.
+	Agent string
+	// AgentParams overrides the model, temperature, variant and extra agent
+	// flags of every role where set.
+	AgentParams config.AgentParams
+}
+
+// AuditLaunch describes a launched run.
+type AuditLaunch struct {
+	RunID       string
+	SessionName string
+	Executor    string
+	Epics       int
+	Roles       int
+}
+
+// StatusVersion is the version of the JSON status document. It changes only
+// when existing fields are renamed or removed.
+const StatusVersion = 1
+
+// Status is a point-in-time view of the active run. Its JSON form is the
+// stable document printed by "lattice status --json".
+type Status struct {
+	Version     int          `json:"version"`
+	RunID       string       `json:"run_id"`
+	SessionName string       `json:"session_name"`
+	CreatedAt   string       `json:"created_at,omitempty"`
+	WorkingDir  string       `json:"working_dir,omitempty"`
+	AllDone     bool         `json:"all_done"`
+	Epics       []EpicStatus `json:"epics"`
+	RefreshedAt time.Time    `json:"refreshed_at"`
+}
+
+// EpicStatus reports progress for one epic.
+type EpicStatus struct {
+	BeadID        string       `json:"bead_id"`
+	AuditType     string       `json:"audit_type"`
+	AuditName     string       `json:"audit_name"`
+	Target        string       `json:"target,omitempty"`
+	Status        string       `json:"status"`
+	RolesTotal    int          `json:"roles_total"`
+	RolesComplete int          `json:"roles_complete"`
+	RolesFailed   int          `json:"roles_failed"`
+	DependsOn     []string     `json:"depends_on,omitempty"`
+	Roles         []RoleStatus `json:"roles"`
+	Timing
+}
+
+// RoleStatus reports progress for one role.
+type RoleStatus struct {
+	BeadID      string   `json:"bead_id"`
+	CodeName    string   `json:"code_name"`
+	Title       string   `json:"title"`
+	Status      string   `json:"status"`
+	CurrentLoop int      `json:"current_loop"`
+	Intensity   int      `json:"intensity"`
+	BeadPrefix  string   `json:"bead_prefix"`
+	After       []string `json:"after,omitempty"`
+	TmuxWindow  string   `json:"tmux_window,omitempty"`
+	ExitCode    *int     `json:"exit_code,omitempty"`
+	ExitedAt    string   `json:"exited_at,omitempty"`
+	// QueuePosition is set while a pending role waits for a free concurrency slot.
+	QueuePosition int `json:"queue_position,omitempty"`
+	// Attempt numbers the role's latest launch; RetryAt is set while it waits to retry.
+	Attempt int    `json:"attempt,omitempty"`
+	RetryAt string `json:"retry_at,omitempty"`
+	// Paused is set while a pending role is held back from launching.
+	Paused bool `json:"paused,omitempty"`
+	Timing
+}
+
+// Timing reports when a role or epic launched and finished. ElapsedSeconds runs
+// from launch until it stopped, or until the status was read.
+type Timing struct {
+	LaunchedAt     string `json:"launched_at,omitempty"`
+	CompletedAt    string `json:"completed_at,omitempty"`
+	FailedAt       string `json:"failed_at,omitempty"`
+	ElapsedSeconds int64  `json:"elapsed_seconds,omitempty"`
+}
+
+func statusTiming(timing config.Timing, now time.Time) Timing {
+	return Timing{
+		LaunchedAt:     timing.LaunchedAt,
+		CompletedAt:    timing.CompletedAt,
+		FailedAt:       timing.FailedAt,
+		ElapsedSeconds: int64(timing.Elapsed(now).Seconds()),
+	}
+}
+
+// StopResult summarizes a stopped run.
+type StopResult struct {
+	SessionName string
+	// SessionErr is set when the session could not be stopped, usually because it already exited.
+	SessionErr error
+	Stopped    []string
+}
+
+type controlDeps struct {
+	launch         launchDeps
+	loadAuditTypes func(cwd string) ([]teams.AuditType, error)
+	loadSettings   func() (config.UserSettings, error)
+	discover       func(projectDir string, runtime agent.Runtime) (discovery.Result, error)
+	// newExecutor returns the run's executor to stop its session or interrupt
+	// roles that are cancelled or skipped.
+	newExecutor  func(kind, tmuxSocket string) (executor.Executor, error)
+	recordEvents recordEventsFunc
+	now          func() time.Time
+}
+
+func defaultControlDeps() controlDeps {
+	return controlDeps{
+		launch:         defaultLaunchDeps(),
+		loadAuditTypes: teams.LoadAuditTypes,
+		loadSettings:   config.LoadUserSettings,
+		discover:       discovery.DiscoverWith,
+		newExecutor:    executor.New,
+		recordEvents:   events.Append,
+		now:            time.Now,
+	}
+}
+
+// LaunchAudit validates opts and launches an audit run in cwd without the TUI.
+func LaunchAudit(cwd string, opts AuditOptions) (AuditLaunch, error) {
+	return launchAuditWithOptions(cwd, opts, defaultControlDeps())
+}
+
+func launchAuditWithOptions(cwd string, opts AuditOptions, deps controlDeps) (AuditLaunch, error) {
+	req, err := buildLaunchRequest(cwd, opts, deps)
+	if err != nil {
+		return AuditLaunch{}, err
+	}
+
+	if failed, ok := launchAudit(req, deps.launch).(LaunchFailedMsg); ok {
+		return AuditLaunch{}, failed.Err
+	}
+
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		return AuditLaunch{}, fmt.Errorf("load lattice config: %w", err)
+	}
+
+	return AuditLaunch{
+		RunID:       cfg.Session.RunID,
+		SessionName: cfg.Session.Name,
+		Executor:    cfg.Session.Executor,
+		Epics:       len(cfg.Epics),
+		Roles:       len(cfg.Roles),
+	}, nil
+}
+
+// buildLaunchRequest resolves audit options into a launch request and checks it
+// against the planner before anything is archived or started.
+func buildLaunchRequest(cwd string, opts AuditOptions, deps controlDeps) (launchRequest, error) {
+	if len(opts.AuditTypes) == 0 {
+		return launchRequest{}, fmt.Errorf("select at least one audit type")
+	}
+
+	available, err := deps.loadAuditTypes(cwd)
+	if err != nil {
+		return launchRequest{}, fmt.Errorf("load audit types: %w", err)
+	}
+
+	auditTypes := make([]teams.AuditType, 0, len(opts.AuditTypes))
+	for _, id := range opts.AuditTypes {
+		auditType, ok := teams.FindAuditType(available, id)
+		if !ok {
+			return launchRequest{}, fmt.Errorf("unknown audit type %q (valid: %s)", strings.TrimSpace(id), strings.Join(auditTypeIDs(available), ", "))
+		}
+		auditTypes = append(auditTypes, auditType)
+	}
+
+	rigor, err := ParseRigor(opts.Rigor)
+	if err != nil {
+		return launchRequest{}, err
+	}
+
+	target, err := resolveAuditTarget(cwd, opts.Target)
+	if err != nil {
+		return launchRequest{}, err
+	}
+
+	dependsOn, err := resolveEpicDependsOn(auditTypes, opts.DependsOn)
+	if err != nil {
+		return launchRequest{}, err
+	}
+
+	// The settings are read once so a concurrent edit cannot mix two versions.
+	var settings config.UserSettings
+	if deps.loadSettings != nil {
+		if settings, err = deps.loadSettings(); err != nil {
+			return launchRequest{}, fmt.Errorf("load user settings: %w", err)
+		}
+	}
+
+	maxConcurrent := resolveMaxConcurrentRoles(opts.MaxConcurrentRoles, settings)
+	if err := opts.Retry.Validate(); err != nil {
+		return launchRequest{}, err
+	}
+	if err := opts.AgentParams.Validate(); err != nil {
+		return launchRequest{}, err
+	}
+	stall, err := resolveStallPolicy(opts.Stall, settings)
+	if err != nil {
+		return launchRequest{}, err
+	}
+	executorKind, err := resolveExecutor(opts.Executor, settings)
+	if err != nil {
+		return launchRequest{}, err
+	}
+
+	req := launchRequest{
+		cwd:                cwd,
+		target:             target,
+		auditTypes:         auditTypes,
+		agentCount:         opts.AgentCount,
+		intensity:          rigor.Loops,
+		dependsOn:          dependsOn,
+		maxConcurrentRoles: maxConcurrent,
+		retry:              opts.Retry,
+		stall:              stall,
+		hooks:              settings.Hooks,
+		executor:           executorKind,
+		tmuxSocket:         settings.TmuxSocket,
+		agent:              strings.TrimSpace(opts.Agent),
+		defaultAgent:       settings.Agent,
+		agentRuntimes:      settings.Agents,
+		agentParams:        opts.AgentParams,
+	}
+	if _, err := launchAgentRuntimes(req); err != nil {
+		return launchRequest{}, err
+	}
+	if target == "" {
+		req.target = filepath.Base(cwd)
+	}
+
+	if opts.FanOut && !opts.Discover {
+		return launchRequest{}, fmt.Errorf("fan-out requires discovery")
+	}
+	if opts.Discover {
+		runtime, err := agent.Resolve(fallbackText(req.agent, req.defaultAgent), req.agentRuntimes)
+		if err != nil {
+			return launchRequest{}, err
+		}
+		result, err := deps.discover(filepath.Join(cwd, target), runtime)
+		if err != nil {
+			return launchRequest{}, fmt.Errorf("discover areas: %w", err)
+		}
+
+		for _, area := range result.Areas {
+			area.Path = filepath.ToSlash(filepath.Join(target, area.Path))
+			req.focusAreas = append(req.focusAreas, formatFocusArea(area))
+			if opts.FanOut {
+				for _, auditType := range auditTypes {
+					req.epics = append(req.epics, teams.EpicSpec{
+						AuditType:  auditType,
+						Target:     area.Path,
+						FocusAreas: []string{formatFocusArea(area)},
+					})
+				}
+			}
+		}
+	}
+
+	if _, err := teams.BuildEpicPlan(launchEpicSpecs(req), req.agentCount, req.intensity, 0); err != nil {
+		return launchRequest{}, err
+	}
+
+	return req, nil
+}
+
+// resolveAuditTarget returns target as a clean project-relative path, or "" for the project root.
+func resolveAuditTarget(cwd, target string) (string, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return "", nil
+	}
+
+	if filepath.IsAbs(target) {
+		rel, err := filepath.Rel(cwd, target)
+		if err != nil {
+			return "", fmt.Errorf("resolve target %q: %w", target, err)
+		}
+		target = rel
+	}
+
+	target = filepath.Clean(target)
+	if target == ".." || strings.HasPrefix(target, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("target %q is outside the project", target)
+	}
+	if target == "." {
+		return "", nil
+	}
+
+	return filepath.ToSlash(target), nil
+}
+
+// resolveMaxConcurrentRoles applies the user default when requested is 0 and
+// treats a negative value as no limit.
+func resolveMaxConcurrentRoles(requested int, settings config.UserSettings) int {
+	switch {
+	case requested < 0:
+		return 0
+	case requested > 0:
+		return requested
+	}
+
+	return settings.MaxConcurrentRoles
+}
+
+// resolveStallPolicy fills empty fields of requested from the user defaults and
+// validates the result.
+func resolveStallPolicy(requested config.StallPolicy, settings config.UserSettings) (config.StallPolicy, error) {
+	policy := requested
+	policy.Timeout = fallbackText(policy.Timeout, settings.Stall.Timeout)
+	policy.Action = fallbackText(policy.Action, settings.Stall.Action)
+	if err := policy.Validate(); err != nil {
+		return config.StallPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// resolveExecutor returns the requested executor, falling back to the user
+// default and then tmux.
+func resolveExecutor(requested string, settings config.UserSettings) (string, error) {
+	if strings.TrimSpace(requested) == "" {
+		requested = settings.Executor
+	}
+
+	return config.ResolveExecutor(requested)
+}
+
+// resolveEpicDependsOn checks that every audit type named in dependsOn is selected
+// and returns the dependencies keyed by canonical audit type ID.
+func resolveEpicDependsOn(auditTypes []teams.AuditType, dependsOn map[string][]string) (map[string][]string, error) {
+	if len(dependsOn) == 0 {
+		return nil, nil
+	}
+
+	resolve := func(id string) (string, error) {
+		auditType, ok := teams.FindAuditType(auditTypes, id)
+		if !ok {
+			return "", fmt.Errorf("dependency references audit type %q, which is not selected", strings.TrimSpace(id))
+		}
+		return auditType.ID, nil
+	}
+
+	resolved := make(map[string][]string, len(dependsOn))
+	for id, upstreamIDs := range dependsOn {
+		auditTypeID, err := resolve(id)
+		if err != nil {
+			return nil, err
+		}
+		for _, upstreamID := range upstreamIDs {
+			upstream, err := resolve(upstreamID)
+			if err != nil {
+				return nil, err
+			}
+			resolved[auditTypeID] = append(resolved[auditTypeID], upstream)
+		}
+	}
+
+	return resolved, nil
+}
+
+func auditTypeIDs(auditTypes []teams.AuditType) []string {
+	ids := make([]string, 0, len(auditTypes))
+	for _, auditType := range auditTypes {
+		ids = append(ids, auditType.ID)
+	}
+
+	return ids
+}
+
+// LoadStatus reads the active run's epics and role progress.
+func LoadStatus(cwd string) (Status, error) {
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		return Status{}, fmt.Errorf("load lattice config: %w", err)
+	}
+
+	epics, err := loadEpicStatuses(cwd, cfg)
+	if err != nil {
+		return Status{}, err
+	}
+
+	snapshot := dashboardSnapshot{SessionName: cfg.Session.Name, Epics: epics, RefreshedAt: time.Now()}
+	status := Status{
+		Version:     StatusVersion,
+		RunID:       cfg.Session.RunID,
+		SessionName: snapshot.SessionName,
+		CreatedAt:   cfg.Session.CreatedAt,
+		WorkingDir:  cfg.Session.WorkingDir,
+		AllDone:     snapshotAllDone(snapshot),
+		Epics:       []EpicStatus{},
+		RefreshedAt: snapshot.RefreshedAt,
+	}
+	for _, epic := range epics {
+		epicStatus := EpicStatus{
+			BeadID:        epic.BeadID,
+			AuditType:     epic.AuditType,
+			AuditName:     epic.EpicName,
+			Target:        epic.Target,
+			Status:        epic.Status,
+			RolesTotal:    epic.RolesTotal,
+			RolesComplete: epic.RolesComplete,
+			RolesFailed:   epic.RolesFailed,
+			DependsOn:     epic.DependsOn,
+			Roles:         []RoleStatus{},
+			Timing:        statusTiming(epic.Timing, snapshot.RefreshedAt),
+		}
+		for _, role := range epic.Roles {
+			epicStatus.Roles = append(epicStatus.Roles, RoleStatus{
+				BeadID:        role.BeadID,
+				CodeName:      role.CodeName,
+				Title:         role.Title,
+				Status:        role.Status,
+				CurrentLoop:   role.CurrentLoop,
+				Intensity:     role.Intensity,
+				BeadPrefix:    role.BeadPrefix,
+				After:         role.After,
+				TmuxWindow:    role.TmuxWindow,
+				ExitCode:      role.ExitCode,
+				ExitedAt:      role.ExitedAt,
+				QueuePosition: role.QueuePosition,
+				Attempt:       role.Attempt,
+				RetryAt:       role.RetryAt,
+				Paused:        role.Paused,
+				Timing:        statusTiming(role.Timing, snapshot.RefreshedAt),
+			})
+		}
+		status.Epics = append(status.Epics, epicStatus)
+	}
+
+	return status, nil
+}
+
+// StopRun stops the active session and marks every unfinished role cancelled.
+// Unfinished epics are cancelled too, or failed when one of their roles failed
+// before the stop.
+func StopRun(cwd string) (StopResult, error) {
+	return stopRun(cwd, defaultControlDeps())
+}
+
+func stopRun(cwd string, deps controlDeps) (StopResult, error) {
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		return StopResult{}, fmt.Errorf("load lattice config: %w", err)
+	}
+	if len(cfg.Roles) == 0 {
+		return StopResult{}, fmt.Errorf("no audit roles found in %s; launch an audit first", config.DirName)
+	}
+
+	result := StopResult{SessionName: cfg.Session.Name}
+	if strings.TrimSpace(cfg.Session.Name) != "" {
+		result.SessionErr = stopSession(cwd, cfg, deps.newExecutor)
+	}
+
+	now := deps.now()
+	exitedAt := now.UTC().Format(time.RFC3339)
+	failedEpics := map[string]bool{}
+	for roleKey, role := range cfg.Roles {
+		status := normalizeRoleStatus(role.Status)
+		if status == "failed" {
+			failedEpics[role.EpicBeadID] = true
+		}
+		if roleStatusTerminal(status) {
+			continue
+		}
+		if status == "running" || status == "stalled" {
+			if role.ExitedAt == "" {
+				role.ExitedAt = exitedAt
+			}
+			role.MarkStopped(now)
+		}
+		role.Status = "cancelled"
+		role.Paused = false
+		role.QueuePosition = 0
+		role.RetryAt = ""
+		cfg.Roles[roleKey] = role
+		result.Stopped = append(result.Stopped, roleKey)
+	}
+
+	sort.Strings(result.Stopped)
+
+	for epicKey, epic := range cfg.Epics {
+		if epicStatusTerminal(epic.Status) {
+			continue
+		}
+		if failedEpics[epicKey] {
+			epic.Status = "failed"
+			epic.MarkFailed(now)
+		} else {
+			epic.Status = "cancelled"
+			epic.MarkStopped(now)
+		}
+		cfg.Epics[epicKey] = epic
+	}
+
+	if err := cfg.Save(); err != nil {
+		return StopResult{}, fmt.Errorf("save lattice config: %w", err)
+	}
+	// The killed session closed every pane pipe, so the transcripts are final.
+	for roleKey, role := range cfg.Roles {
+		if err := writeRoleLog(cwd, cfg.Session.RunID, role); err != nil {
+			return result, fmt.Errorf("write transcript for %s: %w", roleKey, err)
+		}
+	}
+	stopped := events.Event{Kind: events.KindAction, Source: "cli", RunID: cfg.Session.RunID, Action: "stop", Message: "stopped " + fallbackText(strings.Join(result.Stopped, ", "), "nothing")}
+	if err := recordEvents(deps.recordEvents, cwd, stopped); err != nil {
+		return result, fmt.Errorf("record stop: %w", err)
+	}
+
+	return result, nil
+}
+
+// stopSession stops the run's session and every role still running in it.
+func stopSession(cwd string, cfg *config.Config, newExecutor func(kind, tmuxSocket string) (executor.Executor, error)) error {
+	runner, err := newExecutor(cfg.Session.Executor, cfg.Session.TmuxSocket)
+	if err != nil {
+		return err
+	}
+
+	var running []executor.Target
+	for _, role := range cfg.Roles {
+		switch normalizeRoleStatus(role.Status) {
+		case "running", "stalled":
+			running = append(running, roleTarget(cwd, cfg.Session.RunID, cfg.Session.Name, role))
+		}
+	}
+
+	return runner.StopSession(cfg.Session.Name, running)
+}
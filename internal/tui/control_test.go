@@ -0,0 +1,393 @@
+package tui
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"lattice/internal/agent"
+	"lattice/internal/config"
+	"lattice/internal/discovery"
+	"lattice/internal/executor"
+	"lattice/internal/teams"
+)
+
+func TestBuildLaunchRequestValidatesOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    AuditOptions
+		wantErr string
+	}{
+		{name: "missing types", opts: AuditOptions{AgentCount: 2, Rigor: "standard"}, wantErr: "select at least one audit type"},
+		{name: "unknown type", opts: AuditOptions{AuditTypes: []string{"nope"}, AgentCount: 2, Rigor: "standard"}, wantErr: `unknown audit type "nope"`},
+		{name: "unknown rigor", opts: AuditOptions{AuditTypes: []string{"perf"}, AgentCount: 2, Rigor: "extreme"}, wantErr: `unknown rigor "extreme"`},
+		{name: "agent count from planner", opts: AuditOptions{AuditTypes: []string{"perf"}, AgentCount: 7, Rigor: "light"}, wantErr: `audit type "perf" has no role config for 7 agents`},
+		{name: "target outside project", opts: AuditOptions{AuditTypes: []string{"perf"}, AgentCount: 1, Rigor: "light", Target: "../other"}, wantErr: "outside the project"},
+		{name: "fan-out without discovery", opts: AuditOptions{AuditTypes: []string{"perf"}, AgentCount: 1, Rigor: "light", FanOut: true}, wantErr: "fan-out requires discovery"},
+		{name: "dependency on unselected type", opts: AuditOptions{AuditTypes: []string{"perf"}, AgentCount: 1, Rigor: "light", DependsOn: map[string][]string{"perf": {"security"}}}, wantErr: `audit type "security", which is not selected`},
+		{name: "epic dependency cycle", opts: AuditOptions{AuditTypes: []string{"perf", "security"}, AgentCount: 1, Rigor: "light", DependsOn: map[string][]string{"perf": {"security"}, "security": {"perf"}}}, wantErr: "epic dependency cycle"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := buildLaunchRequest("/tmp/project", tt.opts, controlDeps{loadAuditTypes: builtinAuditTypes})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestBuildLaunchRequestDiscoversAreasUnderTarget(t *testing.T) {
+	t.Parallel()
+
+	var discoveredDir string
+	deps := controlDeps{
+		loadAuditTypes: builtinAuditTypes,
+		discover: func(projectDir string, _ agent.Runtime) (discovery.Result, error) {
+			discoveredDir = projectDir
+			return discovery.Result{Areas: []discovery.Area{
+				{Name: "API", Path: "api", Description: "HTTP handlers"},
+				{Name: "Store", Path: "store", Description: "Persistence"},
+			}}, nil
+		},
+	}
+
+	req, err := buildLaunchRequest("/tmp/project", AuditOptions{
+		AuditTypes: []string{"perf", "security"},
+		AgentCount: 2,
+		Rigor:      "Go-Hard",
+		Target:     "./internal",
+		Discover:   true,
+		FanOut:     true,
+	}, deps)
+	if err != nil {
+		t.Fatalf("buildLaunchRequest() returned error: %v", err)
+	}
+
+	if discoveredDir != filepath.Join("/tmp/project", "internal") {
+		t.Fatalf("unexpected discovery dir: %q", discoveredDir)
+	}
+	if req.target != "internal" || req.intensity != 99 || req.agentCount != 2 {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+	if len(req.focusAreas) != 2 || req.focusAreas[0] != "API (internal/api): HTTP handlers" {
+		t.Fatalf("unexpected focus areas: %#v", req.focusAreas)
+	}
+	if len(req.epics) != 4 {
+		t.Fatalf("expected one epic per type and area, got %d", len(req.epics))
+	}
+	if req.epics[3].AuditType.ID != "security" || req.epics[3].Target != "internal/store" {
+		t.Fatalf("unexpected fan-out epic: %+v", req.epics[3])
+	}
+}
+
+func TestLaunchAuditWithOptionsReportsLaunchedRun(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	fakeManager := &fakeLaunchTmuxManager{}
+	fixedNow := time.Date(2026, time.February, 11, 14, 32, 1, 0, time.UTC)
+	deps := controlDeps{
+		loadAuditTypes: builtinAuditTypes,
+		launch: launchDeps{
+			initConfig:     config.Init,
+			newExecutor:    func(string, string) (executor.Executor, error) { return &fakeExecutor{manager: fakeManager}, nil },
+			buildAuditPlan: teams.BuildEpicPlan,
+			generateRoleSession: func(params teams.RoleSessionParams) (string, error) {
+				return filepath.Join(params.Cwd, "role"), nil
+			},
+			now: func() time.Time { return fixedNow },
+		},
+	}
+
+	launch, err := launchAuditWithOptions(workDir, AuditOptions{AuditTypes: []string{"perf"}, AgentCount: 2, Rigor: "light"}, deps)
+	if err != nil {
+		t.Fatalf("launchAuditWithOptions() returned error: %v", err)
+	}
+	if launch.RunID != "20260211-143201" || launch.SessionName != "lattice-"+filepath.Base(workDir)+"-20260211-143201" || launch.Epics != 1 || launch.Roles != 2 {
+		t.Fatalf("unexpected launch summary: %+v", launch)
+	}
+	if len(fakeManager.sessionNames) != 1 {
+		t.Fatalf("expected one tmux session, got %v", fakeManager.sessionNames)
+	}
+}
+
+func TestLaunchAuditWithOptionsRunsAuditTypeAgentRuntime(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	claude := config.AgentRuntime{Command: "claude -p {{.Prompt}}"}
+	var runtimes []string
+	var params []config.AgentParams
+	deps := controlDeps{
+		loadAuditTypes: func(string) ([]teams.AuditType, error) {
+			perf, _ := teams.FindAuditType(teams.AuditTypes, "perf")
+			perf.Agent = "claude"
+			perf.RoleConfigs = []teams.AgentConfigRoles{{AgentCount: 1, Roles: []teams.RoleDefinition{{
+				CodeName:    "alpha",
+				Title:       "Triage",
+				Guidance:    "Find the worst bottlenecks.",
+				AgentParams: config.AgentParams{Model: "anthropic/claude-haiku", AgentFlags: []string{"--max-turns", "20"}},
+			}}}}
+			return []teams.AuditType{perf}, nil
+		},
+		loadSettings: func() (config.UserSettings, error) {
+			return config.UserSettings{Agents: map[string]config.AgentRuntime{"claude": claude}}, nil
+		},
+		launch: launchDeps{
+			initConfig: config.Init,
+			newExecutor: func(string, string) (executor.Executor, error) {
+				return &fakeExecutor{manager: &fakeLaunchTmuxManager{}}, nil
+			},
+			buildAuditPlan: teams.BuildEpicPlan,
+			generateRoleSession: func(session teams.RoleSessionParams) (string, error) {
+				runtimes = append(runtimes, session.Runtime.Name())
+				params = append(params, session.AgentParams)
+				return filepath.Join(session.Cwd, "role"), nil
+			},
+			now: time.Now,
+		},
+	}
+
+	if _, err := launchAuditWithOptions(workDir, AuditOptions{AuditTypes: []string{"perf"}, AgentCount: 1, Rigor: "light", Agent: "aider"}, deps); err == nil || !strings.Contains(err.Error(), `unknown agent runtime "aider"`) {
+		t.Fatalf("expected unknown runtime error, got %v", err)
+	}
+
+	override := config.AgentParams{Variant: "high"}
+	if _, err := launchAuditWithOptions(workDir, AuditOptions{AuditTypes: []string{"perf"}, AgentCount: 1, Rigor: "light", AgentParams: override}, deps); err != nil {
+		t.Fatalf("launchAuditWithOptions() returned error: %v", err)
+	}
+	if strings.Join(runtimes, ",") != "claude" {
+		t.Fatalf("expected the role session to run claude, got %v", runtimes)
+	}
+	wantParams := config.AgentParams{Model: "anthropic/claude-haiku", Variant: "high", AgentFlags: []string{"--max-turns", "20"}}
+	if len(params) != 1 || !reflect.DeepEqual(params[0], wantParams) {
+		t.Fatalf("expected the role's params with the run's variant, got %+v", params)
+	}
+
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	for _, epic := range cfg.Epics {
+		if epic.Agent != "claude" {
+			t.Fatalf("expected epic to record its runtime, got %q", epic.Agent)
+		}
+	}
+	if got := cfg.Session.Agents["claude"]; got != claude || len(cfg.Session.Agents) != 1 {
+		t.Fatalf("expected the run to snapshot the claude runtime, got %+v", cfg.Session.Agents)
+	}
+	for _, role := range cfg.Roles {
+		if !reflect.DeepEqual(role.AgentParams, wantParams) {
+			t.Fatalf("expected the role state to keep its params for relaunches, got %+v", role.AgentParams)
+		}
+	}
+}
+
+func TestStopRunCancelsUnfinishedRoles(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.Name = "lattice-20260213-010203"
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", Status: "running"}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", Status: "complete"}
+	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", Status: "running"}
+	cfg.Roles["r3"] = config.RoleState{BeadID: "r3", EpicBeadID: "e1", Status: "pending"}
+	cfg.Epics["e2"] = config.EpicState{BeadID: "e2", AuditType: "security", Status: "blocked"}
+	cfg.Roles["r4"] = config.RoleState{BeadID: "r4", EpicBeadID: "e2", Status: "failed"}
+	cfg.Roles["r5"] = config.RoleState{BeadID: "r5", EpicBeadID: "e2", Status: "pending"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	var killed string
+	result, err := stopRun(workDir, controlDeps{
+		newExecutor: func(string, string) (executor.Executor, error) {
+			return &fakeExecutor{stopSession: func(name string) error {
+				killed = name
+				return errors.New("no server running")
+			}}, nil
+		},
+		now: func() time.Time { return time.Date(2026, time.February, 13, 2, 0, 0, 0, time.UTC) },
+	})
+	if err != nil {
+		t.Fatalf("stopRun() returned error: %v", err)
+	}
+	if killed != "lattice-20260213-010203" || result.SessionErr == nil {
+		t.Fatalf("expected session kill attempt with reported error, got %q %v", killed, result.SessionErr)
+	}
+	if strings.Join(result.Stopped, ",") != "r2,r3,r5" {
+		t.Fatalf("unexpected stopped roles: %v", result.Stopped)
+	}
+
+	saved, err := config.Load(workDir)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if saved.Roles["r1"].Status != "complete" || saved.Roles["r2"].Status != "cancelled" || saved.Roles["r3"].Status != "cancelled" {
+		t.Fatalf("unexpected saved roles: %+v", saved.Roles)
+	}
+	if saved.Roles["r2"].ExitedAt != "2026-02-13T02:00:00Z" || saved.Roles["r2"].FailedAt != "" || saved.Roles["r3"].ExitedAt != "" {
+		t.Fatalf("expected only the running role to record an exit, got %+v", saved.Roles)
+	}
+	if saved.Epics["e1"].Status != "cancelled" || saved.Epics["e2"].Status != "failed" {
+		t.Fatalf("expected e1 cancelled and e2 failed, got %q and %q", saved.Epics["e1"].Status, saved.Epics["e2"].Status)
+	}
+}
+
+func TestLoadStatusReportsEpicsAndRoles(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.RunID = "20260213-010203"
+	cfg.Session.Name = "lattice-20260213-010203"
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Target: "api", Status: "running"}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Status: "complete", Order: 1, Intensity: 2, TmuxWindow: "lattice-20260213-010203:e1-alpha", ExitedAt: "2026-02-13T01:30:00Z"}
+	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Status: "failed", Order: 2, Intensity: 2}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	status, err := LoadStatus(workDir)
+	if err != nil {
+		t.Fatalf("LoadStatus() returned error: %v", err)
+	}
+	if status.Version != StatusVersion || status.RunID != "20260213-010203" || !status.AllDone || len(status.Epics) != 1 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	epic := status.Epics[0]
+	if epic.AuditName != "Performance" || epic.Target != "api" || epic.RolesComplete != 1 || epic.RolesFailed != 1 {
+		t.Fatalf("unexpected epic status: %+v", epic)
+	}
+	if len(epic.Roles) != 2 || epic.Roles[0].CodeName != "alpha" || epic.Roles[1].Status != "failed" {
+		t.Fatalf("unexpected role statuses: %+v", epic.Roles)
+	}
+	if epic.Roles[0].TmuxWindow != "lattice-20260213-010203:e1-alpha" || epic.Roles[0].ExitedAt != "2026-02-13T01:30:00Z" {
+		t.Fatalf("expected tmux window and exit time, got %+v", epic.Roles[0])
+	}
+}
+
+func TestBuildLaunchRequestUsesLoadedAuditTypes(t *testing.T) {
+	t.Parallel()
+
+	custom := teams.AuditType{
+		ID: "graphql", Name: "GraphQL Schema Audit", BeadPrefix: "gql",
+		RoleConfigs: []teams.AgentConfigRoles{{AgentCount: 1, Roles: []teams.RoleDefinition{{CodeName: "alpha", Title: "Schema Reviewer", Guidance: "Review the schema."}}}},
+	}
+	deps := controlDeps{loadAuditTypes: func(string) ([]teams.AuditType, error) {
+		return append(append([]teams.AuditType(nil), teams.AuditTypes...), custom), nil
+	}}
+
+	req, err := buildLaunchRequest("/tmp/project", AuditOptions{AuditTypes: []string{"graphql"}, AgentCount: 1, Rigor: "light"}, deps)
+	if err != nil {
+		t.Fatalf("buildLaunchRequest() returned error: %v", err)
+	}
+	if len(req.auditTypes) != 1 || req.auditTypes[0].BeadPrefix != "gql" {
+		t.Fatalf("expected custom audit type, got %+v", req.auditTypes)
+	}
+}
+
+func builtinAuditTypes(string) ([]teams.AuditType, error) {
+	return teams.AuditTypes, nil
+}
+
+func TestResolveMaxConcurrentRoles(t *testing.T) {
+	t.Parallel()
+
+	settings := config.UserSettings{MaxConcurrentRoles: 4}
+	tests := []struct {
+		name      string
+		requested int
+		want      int
+	}{
+		{name: "user default", requested: 0, want: 4},
+		{name: "override", requested: 2, want: 2},
+		{name: "no limit", requested: -1, want: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := resolveMaxConcurrentRoles(tt.requested, settings); got != tt.want {
+				t.Fatalf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveStallPolicyFillsUserDefaults(t *testing.T) {
+	t.Parallel()
+
+	settings := config.UserSettings{Stall: config.StallPolicy{Timeout: "30m", Action: "restart"}}
+
+	got, err := resolveStallPolicy(config.StallPolicy{Timeout: "5m"}, settings)
+	if err != nil {
+		t.Fatalf("resolveStallPolicy() error = %v", err)
+	}
+	if want := (config.StallPolicy{Timeout: "5m", Action: "restart"}); got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	if _, err := resolveStallPolicy(config.StallPolicy{Timeout: "5m", Action: "page"}, config.UserSettings{}); err == nil || !strings.Contains(err.Error(), `unknown stall action "page"`) {
+		t.Fatalf("expected unknown action error, got %v", err)
+	}
+}
+
+func TestResolveExecutorFallsBackToSettings(t *testing.T) {
+	t.Parallel()
+
+	settings := config.UserSettings{Executor: config.ExecutorProcess}
+
+	tests := []struct {
+		name     string
+		request  string
+		settings config.UserSettings
+		want     string
+		wantErr  string
+	}{
+		{name: "default", want: config.ExecutorTmux},
+		{name: "settings", settings: settings, want: config.ExecutorProcess},
+		{name: "request wins", request: "tmux", settings: settings, want: config.ExecutorTmux},
+		{name: "unknown", request: "docker", wantErr: `unknown executor "docker"`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := resolveExecutor(tt.request, tt.settings)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveExecutor() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,12 +15,18 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"lattice/internal/config"
+	"lattice/internal/events"
+	"lattice/internal/panelog"
+	"lattice/internal/report"
 	"lattice/internal/teams"
 	"lattice/internal/tmux"
 )
 
 const dashboardRefreshInterval = 3 * time.Second
 
+// dashboardActivityLimit is how many recent journal events the Activity pane shows.
+const dashboardActivityLimit = 8
+
 type dashboardTeamStatus struct {
 	TeamName    string
 	Status      string
@@ -36,23 +43,54 @@ type dashboardRoleStatus struct {
 	CurrentLoop int
 	Intensity   int
 	BeadPrefix  string
+	TmuxWindow  string
+	ExitCode    *int
+	ExitedAt    string
+	// After lists the code names this role waits for; Stage is its depth in that graph.
+	After []string
+	Stage int
+	// QueuePosition is set while a pending role waits for a free concurrency slot.
+	QueuePosition int
+	// Attempt numbers the latest launch; RetryAt is set while a failed role waits to retry.
+	Attempt int
+	RetryAt string
+	// Paused is set while the role is held back from launching.
+	Paused bool
+	// StartLoop is the loop the latest attempt resumed from; Timing covers
+	// that attempt.
+	StartLoop int
+	Timing    config.Timing
 }
 
 type dashboardEpicStatus struct {
 	EpicName      string
 	BeadID        string
 	AuditType     string
+	Target        string
 	Status        string
 	RolesTotal    int
 	RolesComplete int
 	RolesFailed   int
 	Roles         []dashboardRoleStatus
+	// DependsOn lists the bead IDs of epics this epic waits for.
+	DependsOn []string
+	Timing    config.Timing
 }
 
 type dashboardSnapshot struct {
 	SessionName string
-	Epics       []dashboardEpicStatus
-	Teams       []dashboardTeamStatus
+	RunID       string
+	// Executor is the executor the run's roles were started with.
+	Executor string
+	// TmuxSocket is the tmux server the run's session lives on.
+	TmuxSocket string
+	Epics      []dashboardEpicStatus
+	Teams      []dashboardTeamStatus
+	// Activity holds the run's most recent journal events, oldest first.
+	Activity []events.Event
+	// ActivityErr is set when the journal could not be read; the Activity
+	// pane shows it while the rest of the snapshot stays usable.
+	ActivityErr error
 	RefreshedAt time.Time
 }
 
@@ -72,10 +110,23 @@ type dashboardAttachDoneMsg struct {
 	Err error
 }
 
+type dashboardReportWrittenMsg struct {
+	Paths report.Paths
+	Err   error
+}
+
+type dashboardRunActionMsg struct {
+	Action RunAction
+	Result RunActionResult
+	Err    error
+}
+
 type dashboardLoadSnapshotFunc func(cwd string, now time.Time) (dashboardSnapshot, error)
 type dashboardLoadConfigFunc func(cwd string) (*config.Config, error)
 type dashboardBuildPlanFunc func(cfg *config.Config) *teams.AuditPlan
 type dashboardCheckAndAdvanceRolesFunc func(cwd string, cfg *config.Config, sessionName string, plan *teams.AuditPlan, deps SchedulerDeps) (SchedulerResult, error)
+type dashboardWriteReportFunc func(cwd string, cfg *config.Config, now time.Time) (report.Paths, error)
+type dashboardApplyRunActionFunc func(cwd string, target RunActionTarget, action RunAction) (RunActionResult, error)
 
 // DashboardModel renders post-launch team status and actions.
 type DashboardModel struct {
@@ -88,14 +139,27 @@ type DashboardModel struct {
 	loadConfig      dashboardLoadConfigFunc
 	buildPlan       dashboardBuildPlanFunc
 	advanceRoles    dashboardCheckAndAdvanceRolesFunc
+	writeReport     dashboardWriteReportFunc
+	applyAction     dashboardApplyRunActionFunc
+	recordEvents    recordEventsFunc
 	schedulerDeps   SchedulerDeps
 	now             func() time.Time
 
 	sessionName string
+	runID       string
+	executor    string
+	tmuxSocket  string
 	epics       []dashboardEpicStatus
 	teams       []dashboardTeamStatus
-	allDone     bool
+	activity    []events.Event
+	activityErr error
+	// hideActivity collapses the Activity pane.
+	hideActivity bool
+	allDone      bool
+	// cursor selects a row of the epic table: each epic followed by its roles.
+	cursor      int
 	lastUpdated time.Time
+	notice      string
 	err         error
 }
 
@@ -110,6 +174,9 @@ func NewDashboardModel(cwd string, styles Styles, keyMap KeyMap) DashboardModel
 		loadConfig:      config.Load,
 		buildPlan:       buildDashboardPlanFromConfig,
 		advanceRoles:    CheckAndAdvanceRoles,
+		writeReport:     report.Write,
+		applyAction:     ApplyRunAction,
+		recordEvents:    events.Append,
 		schedulerDeps:   SchedulerDeps{},
 		now:             time.Now,
 	}
@@ -130,8 +197,16 @@ func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
 		}
 
 		m.sessionName = typed.Snapshot.SessionName
+		m.tmuxSocket = typed.Snapshot.TmuxSocket
+		m.runID = typed.Snapshot.RunID
+		m.executor = typed.Snapshot.Executor
 		m.epics = typed.Snapshot.Epics
 		m.teams = typed.Snapshot.Teams
+		m.activity = typed.Snapshot.Activity
+		m.activityErr = typed.Snapshot.ActivityErr
+		if rows := len(m.actionTargets()); m.cursor >= rows {
+			m.cursor = max(rows-1, 0)
+		}
 		m.allDone = snapshotAllDone(typed.Snapshot)
 		m.lastUpdated = typed.Snapshot.RefreshedAt
 		m.err = nil
@@ -145,6 +220,13 @@ func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
 		}
 
 		m.allDone = typed.Result.AllDone
+		if len(typed.Result.Stalled) > 0 {
+			m.notice = fmt.Sprintf("Stalled: %s", strings.Join(typed.Result.Stalled, ", "))
+		}
+		if len(typed.Result.HookErrors) > 0 {
+			m.err = errors.Join(typed.Result.HookErrors...)
+			return m, nil
+		}
 		return m, m.refreshCmd()
 	case dashboardAttachDoneMsg:
 		if typed.Err != nil {
@@ -152,10 +234,37 @@ func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
 			return m, nil
 		}
 		return m, m.refreshCmd()
+	case dashboardReportWrittenMsg:
+		if typed.Err != nil {
+			m.err = fmt.Errorf("write report: %w", typed.Err)
+			return m, nil
+		}
+		m.notice = fmt.Sprintf("Report written to %s and %s", typed.Paths.Markdown, typed.Paths.HTML)
+		return m, nil
+	case dashboardRunActionMsg:
+		if typed.Err != nil {
+			m.err = fmt.Errorf("%s: %w", typed.Action, typed.Err)
+			return m, nil
+		}
+		m.err = typed.Result.InterruptErr
+		m.notice = fmt.Sprintf("%s: %s", runActionLabel(typed.Action), strings.Join(typed.Result.Changed, ", "))
+		return m, m.refreshCmd()
 	case tea.KeyMsg:
 		if key.Matches(typed, m.keyMap.Back) {
 			return m, func() tea.Msg { return NavigateTo(MenuScreen) }
 		}
+		if key.Matches(typed, m.keyMap.Up) {
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		}
+		if key.Matches(typed, m.keyMap.Down) {
+			if m.cursor < len(m.actionTargets())-1 {
+				m.cursor++
+			}
+			return m, nil
+		}
 
 		switch strings.ToLower(typed.String()) {
 		case "r":
@@ -165,7 +274,26 @@ func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
 				m.err = fmt.Errorf("no active tmux session found")
 				return m, nil
 			}
+			if m.executor == config.ExecutorProcess {
+				m.err = fmt.Errorf("run %s has no tmux session: its roles run as processes logging to %s", m.runID, panelog.Dir(m.cwd, m.runID))
+				return m, nil
+			}
 			return m, m.attachCmd()
+		case "g":
+			return m, m.reportCmd()
+		case "v":
+			m.hideActivity = !m.hideActivity
+			return m, nil
+		case "p":
+			return m, m.runActionCmd(RunActionPause)
+		case "u":
+			return m, m.runActionCmd(RunActionResume)
+		case "x":
+			return m, m.runActionCmd(RunActionCancel)
+		case "s":
+			return m, m.runActionCmd(RunActionSkip)
+		case "a":
+			return m, m.runActionCmd(RunActionRerun)
 		}
 	}
 
@@ -188,11 +316,18 @@ func (m DashboardModel) View() string {
 	if m.err != nil {
 		lines = append(lines, "", m.styles.Error.Render(m.err.Error()))
 	}
+	if m.notice != "" {
+		lines = append(lines, "", m.styles.Success.Render(m.notice))
+	}
 	if m.allDone {
 		lines = append(lines, "", m.styles.Success.Render("All roles reached a terminal state. Review failed items before closing out."))
 	}
 
-	lines = append(lines, "", m.renderEpicTable(), "", m.styles.Help.Render("t: attach tmux  r: refresh  esc: menu  q: quit"))
+	lines = append(lines, "", m.renderEpicTable())
+	if !m.hideActivity {
+		lines = append(lines, "", m.renderActivity())
+	}
+	lines = append(lines, "", m.styles.Help.Render("↑/↓: select  p: pause  u: resume  x: cancel  s: skip  a: re-run"), m.styles.Help.Render("t: attach tmux  g: generate report  v: activity  r: refresh  esc: menu  q: quit"))
 
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
@@ -206,40 +341,102 @@ func (m DashboardModel) renderEpicTable() string {
 		return m.styles.Muted.Render("No running epics discovered yet.")
 	}
 
-	header := fmt.Sprintf("%-24s %-12s %-14s", "EPIC", "STATUS", "PROGRESS")
+	epicNames := make(map[string]string, len(m.epics))
+	for _, epic := range m.epics {
+		epicNames[epic.BeadID] = epic.EpicName
+	}
+
+	header := fmt.Sprintf("  %-24s %-12s %-14s %s", "EPIC", "STATUS", "PROGRESS", "TARGET")
 	rows := []string{m.styles.Muted.Render(header)}
+	row := 0
+	now := m.now()
 	for _, epic := range m.epics {
 		progress := fmt.Sprintf("%d/%d roles done", epic.RolesComplete, epic.RolesTotal)
 		epicStatus := formatDashboardStatus(epic.Status)
-		epicRow := fmt.Sprintf("%-24s %-12s %-14s", epic.EpicName, epicStatus, progress)
+		epicRow := fmt.Sprintf("%-24s %-12s %-14s %s", epic.EpicName, epicStatus, progress, fallbackText(epic.Target, "-"))
+		if len(epic.DependsOn) > 0 {
+			upstream := make([]string, 0, len(epic.DependsOn))
+			for _, beadID := range epic.DependsOn {
+				upstream = append(upstream, fallbackText(epicNames[beadID], beadID))
+			}
+			epicRow += " after " + strings.Join(upstream, ", ")
+		}
+		if elapsed := epic.Timing.Elapsed(now); elapsed > 0 {
+			epicRow += " elapsed " + formatElapsed(elapsed)
+		}
+		epicStyle := m.styles.Body
 		switch strings.ToLower(strings.TrimSpace(epic.Status)) {
 		case "failed", "blocked":
-			rows = append(rows, m.styles.Error.Render(epicRow))
+			epicStyle = m.styles.Error
 		case "complete":
-			rows = append(rows, m.styles.Success.Render(epicRow))
-		default:
-			rows = append(rows, m.styles.Body.Render(epicRow))
+			epicStyle = m.styles.Success
+		case "paused":
+			epicStyle = m.styles.Warning
+		case "cancelled":
+			epicStyle = m.styles.Muted
 		}
+		rows = append(rows, m.cursorMark(row)+epicStyle.Render(epicRow))
+		row++
 
 		for _, role := range epic.Roles {
 			roleLabel := fmt.Sprintf("  %s (%s)", fallbackText(role.CodeName, "-"), fallbackText(role.Title, "-"))
-			roleStatus := formatDashboardStatus(role.Status)
+			roleStatus := formatRoleStatus(role)
 			roleRow := fmt.Sprintf("%-24s %-12s %-14s", roleLabel, roleStatus, formatRoleProgress(role))
-			if strings.EqualFold(strings.TrimSpace(role.Status), "failed") {
-				rows = append(rows, m.styles.Error.Render(roleRow))
-				continue
+			if timing := formatRoleTiming(role, now); timing != "" {
+				roleRow += " " + timing
 			}
-			if strings.EqualFold(strings.TrimSpace(role.Status), "complete") {
-				rows = append(rows, m.styles.Success.Render(roleRow))
-				continue
+			if role.ExitCode != nil {
+				roleRow += fmt.Sprintf(" exit %d", *role.ExitCode)
+			}
+			if len(role.After) > 0 {
+				roleRow += " after " + strings.Join(role.After, ", ")
+			}
+			if retryAt, err := time.Parse(time.RFC3339, role.RetryAt); err == nil && role.Status == "pending" {
+				roleRow += " at " + retryAt.Local().Format("15:04:05")
+			} else if role.Attempt > 1 {
+				roleRow += fmt.Sprintf(" attempt %d", role.Attempt)
 			}
-			rows = append(rows, m.styles.Body.Render(roleRow))
+			roleStyle := m.styles.Body
+			switch {
+			case strings.EqualFold(strings.TrimSpace(role.Status), "failed"):
+				roleStyle = m.styles.Error
+			case role.Status == "stalled" || (role.Status == "pending" && role.Paused):
+				roleStyle = m.styles.Warning
+			case strings.EqualFold(strings.TrimSpace(role.Status), "complete"):
+				roleStyle = m.styles.Success
+			case role.Status == "cancelled" || role.Status == "skipped":
+				roleStyle = m.styles.Muted
+			}
+			rows = append(rows, m.cursorMark(row)+roleStyle.Render(roleRow))
+			row++
 		}
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
+// cursorMark returns the prefix for one row of the epic table.
+func (m DashboardModel) cursorMark(row int) string {
+	if row == m.cursor {
+		return m.styles.FocusedMark.Render(">") + " "
+	}
+
+	return "  "
+}
+
+// actionTargets lists the epic table's rows in display order.
+func (m DashboardModel) actionTargets() []RunActionTarget {
+	var targets []RunActionTarget
+	for _, epic := range m.epics {
+		targets = append(targets, RunActionTarget{EpicBeadID: epic.BeadID})
+		for _, role := range epic.Roles {
+			targets = append(targets, RunActionTarget{EpicBeadID: epic.BeadID, RoleBeadID: role.BeadID})
+		}
+	}
+
+	return targets
+}
+
 func (m DashboardModel) renderTeamTable() string {
 	if len(m.teams) == 0 {
 		return m.styles.Muted.Render("No running teams discovered yet.")
@@ -278,42 +475,126 @@ func (m DashboardModel) schedulerCmd() tea.Cmd {
 	cwd := m.cwd
 
 	return func() tea.Msg {
-		cfg, err := loadConfig(cwd)
+		result, changed, err := runSchedulerPass(cwd, loadConfig, buildPlan, advanceRoles, deps)
 		if err != nil {
-			return schedulerAdvancedMsg{Err: fmt.Errorf("load lattice config: %w", err)}
+			return schedulerAdvancedMsg{Err: err}
 		}
-		if len(cfg.Epics) == 0 {
+		if !changed {
 			return nil
 		}
 
-		plan := buildPlan(cfg)
-		if plan == nil || len(plan.Epics) == 0 {
-			return nil
-		}
+		return schedulerAdvancedMsg{Result: result}
+	}
+}
 
-		result, err := advanceRoles(cwd, cfg, cfg.Session.Name, plan, deps)
-		if err != nil {
-			return schedulerAdvancedMsg{Err: err}
-		}
+// renderActivity lists the run's most recent journal events, oldest first.
+func (m DashboardModel) renderActivity() string {
+	lines := []string{m.styles.Subheader.Render("Activity")}
+	if m.activityErr != nil {
+		lines = append(lines, m.styles.Error.Render(m.activityErr.Error()))
+	}
+	if len(m.activity) == 0 && m.activityErr == nil {
+		return lipgloss.JoinVertical(lipgloss.Left, append(lines, m.styles.Muted.Render("No events recorded yet."))...)
+	}
 
-		if len(result.Launched) == 0 && len(result.Completed) == 0 && len(result.Failed) == 0 {
-			return nil
+	for _, event := range m.activity {
+		style := m.styles.Muted
+		if event.Kind == events.KindError {
+			style = m.styles.Error
 		}
+		lines = append(lines, style.Render(formatActivityEvent(event)))
+	}
 
-		if err := cfg.Save(); err != nil {
-			return schedulerAdvancedMsg{Err: fmt.Errorf("save scheduler updates: %w", err)}
-		}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
 
-		return schedulerAdvancedMsg{Result: result}
+// formatActivityEvent renders one journal event as "time kind subject detail".
+func formatActivityEvent(event events.Event) string {
+	parts := []string{event.Time.Local().Format("15:04:05"), event.Kind}
+	if subject := fallbackText(event.RoleID, fallbackText(event.EpicID, event.RunID)); subject != "" {
+		parts = append(parts, subject)
+	}
+	if event.Action != "" {
+		parts = append(parts, event.Action)
+	}
+	if event.To != "" {
+		parts = append(parts, fmt.Sprintf("%s → %s", fallbackText(event.From, "-"), event.To))
+	}
+
+	line := strings.Join(parts, " ")
+	if event.Message != "" {
+		line += ": " + event.Message
 	}
+
+	return line
 }
 
 func (m DashboardModel) attachCmd() tea.Cmd {
 	sessionName := m.sessionName
-	attach := tea.ExecProcess(tmux.Command("attach-session", "-t", sessionName), func(err error) tea.Msg {
+	socket := tmux.ParseSocket(m.tmuxSocket)
+	record := m.recordEvents
+	cwd := m.cwd
+	attached := events.Event{Kind: events.KindAttach, Source: "dashboard", RunID: m.runID, Message: sessionName}
+	journal := func() tea.Msg {
+		if err := recordEvents(record, cwd, attached); err != nil {
+			return dashboardAttachDoneMsg{Err: fmt.Errorf("record attach: %w", err)}
+		}
+		return nil
+	}
+	attach := tea.ExecProcess(tmux.Command(socket, "attach-session", "-t", sessionName), func(err error) tea.Msg {
 		return dashboardAttachDoneMsg{Err: err}
 	})
-	return tea.Sequence(attach, m.refreshCmd())
+	return tea.Sequence(journal, attach, m.refreshCmd())
+}
+
+func (m DashboardModel) reportCmd() tea.Cmd {
+	loadConfig := m.loadConfig
+	writeReport := m.writeReport
+	cwd := m.cwd
+	now := m.now()
+
+	return func() tea.Msg {
+		cfg, err := loadConfig(cwd)
+		if err != nil {
+			return dashboardReportWrittenMsg{Err: fmt.Errorf("load lattice config: %w", err)}
+		}
+
+		paths, err := writeReport(cwd, cfg, now)
+		return dashboardReportWrittenMsg{Paths: paths, Err: err}
+	}
+}
+
+func (m DashboardModel) runActionCmd(action RunAction) tea.Cmd {
+	targets := m.actionTargets()
+	if m.cursor >= len(targets) {
+		return nil
+	}
+
+	applyAction := m.applyAction
+	cwd := m.cwd
+	target := targets[m.cursor]
+	return func() tea.Msg {
+		result, err := applyAction(cwd, target, action)
+		return dashboardRunActionMsg{Action: action, Result: result, Err: err}
+	}
+}
+
+// runActionLabel describes a completed action in the dashboard notice.
+func runActionLabel(action RunAction) string {
+	switch action {
+	case RunActionPause:
+		return "Paused"
+	case RunActionResume:
+		return "Resumed"
+	case RunActionCancel:
+		return "Cancelled"
+	case RunActionSkip:
+		return "Skipped"
+	case RunActionRerun:
+		return "Queued for re-run"
+	default:
+		return string(action)
+	}
 }
 
 func loadDashboardSnapshot(cwd string, now time.Time) (dashboardSnapshot, error) {
@@ -327,10 +608,19 @@ func loadDashboardSnapshot(cwd string, now time.Time) (dashboardSnapshot, error)
 		if err != nil {
 			return dashboardSnapshot{}, err
 		}
+		activity, err := events.Read(cwd, events.Filter{RunID: cfg.Session.RunID, Limit: dashboardActivityLimit})
+		if err != nil {
+			err = fmt.Errorf("load activity: %w", err)
+		}
 
 		return dashboardSnapshot{
 			SessionName: cfg.Session.Name,
+			RunID:       cfg.Session.RunID,
+			Executor:    cfg.Session.Executor,
+			TmuxSocket:  cfg.Session.TmuxSocket,
 			Epics:       epics,
+			Activity:    activity,
+			ActivityErr: err,
 			RefreshedAt: now,
 		}, nil
 	}
@@ -362,9 +652,9 @@ func loadEpicStatuses(cwd string, cfg *config.Config) ([]dashboardEpicStatus, er
 	rolesByEpic := make(map[string][]roleSnapshot)
 	for roleKey, roleState := range cfg.Roles {
 		roleData := map[string]string{}
-		for _, roleDirName := range dashboardRoleDirectories(roleState, roleKey) {
-			roleDir := filepath.Join(cwd, config.DirName, "teams", roleDirName)
-			data, err := readTeamFile(filepath.Join(roleDir, ".team"))
+		for _, roleDirName := range teams.RoleDirNames(roleState, roleKey) {
+			roleDir := filepath.Join(config.TeamsDir(cwd, cfg.Session.RunID), roleDirName)
+			data, err := teams.ReadTeamFile(filepath.Join(roleDir, ".team"))
 			if err == nil {
 				roleData = data
 				break
@@ -375,15 +665,35 @@ func loadEpicStatuses(cwd string, cfg *config.Config) ([]dashboardEpicStatus, er
 		}
 
 		status := normalizeRoleStatus(fallbackText(roleData["status"], roleState.Status))
+		switch stateStatus := normalizeRoleStatus(roleState.Status); {
+		case stateStatus == "pending" && strings.TrimSpace(roleState.RetryAt) != "":
+			// The failed attempt's .team still reads active until the retry replaces it.
+			status = "pending"
+		case stateStatus == "stalled" && status == "running":
+			status = "stalled"
+		case stateStatus == "cancelled" || stateStatus == "skipped":
+			// The interrupted session's .team may still read active.
+			status = stateStatus
+		}
 		rolesByEpic[roleState.EpicBeadID] = append(rolesByEpic[roleState.EpicBeadID], roleSnapshot{
 			status: dashboardRoleStatus{
-				BeadID:      fallbackText(roleState.BeadID, roleKey),
-				CodeName:    roleState.CodeName,
-				Title:       roleState.Title,
-				Status:      status,
-				CurrentLoop: parseIntFallback(roleData["current_loop"], 0),
-				Intensity:   parseIntFallback(roleData["intensity"], roleState.Intensity),
-				BeadPrefix:  roleState.BeadPrefix,
+				BeadID:        fallbackText(roleState.BeadID, roleKey),
+				CodeName:      roleState.CodeName,
+				Title:         roleState.Title,
+				Status:        status,
+				CurrentLoop:   parseIntFallback(roleData["current_loop"], 0),
+				StartLoop:     roleState.StartLoop,
+				Intensity:     parseIntFallback(roleData["intensity"], roleState.Intensity),
+				BeadPrefix:    roleState.BeadPrefix,
+				TmuxWindow:    roleState.TmuxWindow,
+				ExitCode:      roleState.ExitCode,
+				ExitedAt:      roleState.ExitedAt,
+				After:         append([]string(nil), roleState.After...),
+				QueuePosition: roleState.QueuePosition,
+				Attempt:       roleState.Attempt,
+				RetryAt:       roleState.RetryAt,
+				Paused:        roleState.Paused,
+				Timing:        roleState.Timing,
 			},
 			order: roleState.Order,
 		})
@@ -409,6 +719,12 @@ func loadEpicStatuses(cwd string, cfg *config.Config) ([]dashboardEpicStatus, er
 		for _, roleSnapshot := range roleSnapshots {
 			roles = append(roles, roleSnapshot.status)
 		}
+		orderRolesByStage(roles)
+
+		status := deriveEpicStatus(roles, epicState.Status)
+		if epicState.Paused && !epicStatusTerminal(status) {
+			status = "paused"
+		}
 
 		rolesComplete := 0
 		rolesFailed := 0
@@ -425,11 +741,14 @@ func loadEpicStatuses(cwd string, cfg *config.Config) ([]dashboardEpicStatus, er
 			EpicName:      fallbackText(epicState.AuditName, fallbackText(epicState.AuditType, epicKey)),
 			BeadID:        epicID,
 			AuditType:     epicState.AuditType,
-			Status:        deriveEpicStatus(roles, epicState.Status),
+			Target:        epicState.Target,
+			Status:        status,
 			RolesTotal:    len(roles),
 			RolesComplete: rolesComplete,
 			RolesFailed:   rolesFailed,
 			Roles:         roles,
+			DependsOn:     append([]string(nil), epicState.DependsOn...),
+			Timing:        epicState.Timing,
 		})
 	}
 
@@ -443,11 +762,11 @@ func loadLegacyTeams(cwd string, cfg *config.Config) ([]dashboardTeamStatus, err
 	}
 	sort.Strings(teamKeys)
 
-	teams := make([]dashboardTeamStatus, 0, len(teamKeys))
+	teamStatuses := make([]dashboardTeamStatus, 0, len(teamKeys))
 	for _, teamKey := range teamKeys {
 		teamState := cfg.Teams[teamKey]
-		teamDir := filepath.Join(cwd, config.DirName, "teams", "audit-"+teamKey)
-		teamData, err := readTeamFile(filepath.Join(teamDir, ".team"))
+		teamDir := filepath.Join(config.TeamsDir(cwd, cfg.Session.RunID), "audit-"+teamKey)
+		teamData, err := teams.ReadTeamFile(filepath.Join(teamDir, ".team"))
 		if err != nil && !os.IsNotExist(err) {
 			return nil, fmt.Errorf("read team status for %s: %w", teamKey, err)
 		}
@@ -457,7 +776,7 @@ func loadLegacyTeams(cwd string, cfg *config.Config) ([]dashboardTeamStatus, err
 		currentLoop := parseIntFallback(teamData["current_loop"], 0)
 		intensity := parseIntFallback(teamData["intensity"], teamState.Intensity)
 
-		teams = append(teams, dashboardTeamStatus{
+		teamStatuses = append(teamStatuses, dashboardTeamStatus{
 			TeamName:    teamName,
 			Status:      status,
 			CurrentLoop: currentLoop,
@@ -466,30 +785,7 @@ func loadLegacyTeams(cwd string, cfg *config.Config) ([]dashboardTeamStatus, err
 		})
 	}
 
-	return teams, nil
-}
-
-func dashboardRoleDirectories(role config.RoleState, roleKey string) []string {
-	codeName := fallbackText(role.CodeName, roleKey)
-	beadPrefix := fallbackText(role.BeadPrefix, roleKey)
-
-	dirs := make([]string, 0, 2)
-	if auditTypeID := beadPrefixAuditTypeID(beadPrefix); auditTypeID != "" {
-		dirs = append(dirs, auditTypeID+"-"+codeName)
-	}
-	dirs = append(dirs, beadPrefix+"-"+codeName)
-
-	return dirs
-}
-
-func beadPrefixAuditTypeID(beadPrefix string) string {
-	prefix := strings.TrimSpace(beadPrefix)
-	if prefix == "" {
-		return ""
-	}
-
-	parts := strings.SplitN(prefix, "-", 2)
-	return strings.TrimSpace(parts[0])
+	return teamStatuses, nil
 }
 
 func normalizeRoleStatus(status string) string {
@@ -497,7 +793,7 @@ func normalizeRoleStatus(status string) string {
 	switch normalized {
 	case "active":
 		return "running"
-	case "pending", "running", "complete", "failed":
+	case "pending", "running", "stalled", "complete", "failed", "cancelled", "skipped":
 		return normalized
 	default:
 		return fallbackText(normalized, "unknown")
@@ -512,27 +808,41 @@ func deriveEpicStatus(roles []dashboardRoleStatus, fallback string) string {
 	allComplete := true
 	hasRunningOrPending := false
 	hasFailed := false
+	hasCancelled := false
+	started := false
 	for _, role := range roles {
+		if role.Status != "pending" {
+			started = true
+		}
 		switch role.Status {
-		case "running", "pending":
+		case "running", "stalled", "pending":
 			hasRunningOrPending = true
 			allComplete = false
-		case "complete":
+		case "complete", "skipped":
 			continue
 		case "failed":
 			hasFailed = true
 			allComplete = false
+		case "cancelled":
+			hasCancelled = true
+			allComplete = false
 		default:
 			allComplete = false
 		}
 	}
 
-	if hasFailed && hasRunningOrPending {
+	if (hasFailed || hasCancelled) && hasRunningOrPending {
 		return "blocked"
 	}
+	if !started && (fallback == "pending" || fallback == "blocked") {
+		return fallback
+	}
 	if hasFailed {
 		return "failed"
 	}
+	if hasCancelled {
+		return "cancelled"
+	}
 	if hasRunningOrPending {
 		return "running"
 	}
@@ -554,13 +864,37 @@ func formatDashboardStatus(status string) string {
 		return "complete"
 	case "running":
 		return "running"
+	case "stalled":
+		return "STALLED"
 	case "pending":
 		return "pending"
+	case "paused":
+		return "PAUSED"
+	case "cancelled":
+		return "cancelled"
+	case "skipped":
+		return "skipped"
 	default:
 		return fallbackText(value, "unknown")
 	}
 }
 
+// formatRoleStatus shows a queued role's place in the launch queue, the next
+// attempt of a role waiting to retry and whether a pending role is paused.
+func formatRoleStatus(role dashboardRoleStatus) string {
+	if role.Status == "pending" && role.Paused {
+		return formatDashboardStatus("paused")
+	}
+	if role.Status == "pending" && role.QueuePosition > 0 {
+		return fmt.Sprintf("queued #%d", role.QueuePosition)
+	}
+	if role.Status == "pending" && strings.TrimSpace(role.RetryAt) != "" {
+		return fmt.Sprintf("retry #%d", role.Attempt+1)
+	}
+
+	return formatDashboardStatus(role.Status)
+}
+
 func snapshotAllDone(snapshot dashboardSnapshot) bool {
 	if len(snapshot.Epics) == 0 {
 		return false
@@ -571,7 +905,7 @@ func snapshotAllDone(snapshot dashboardSnapshot) bool {
 		for _, role := range epic.Roles {
 			hasRoles = true
 			switch strings.ToLower(strings.TrimSpace(role.Status)) {
-			case "complete", "failed":
+			case "complete", "failed", "cancelled", "skipped":
 				continue
 			default:
 				return false
@@ -624,6 +958,7 @@ func buildDashboardPlanFromConfig(cfg *config.Config) *teams.AuditPlan {
 				Guidance:   role.Guidance,
 				BeadPrefix: role.BeadPrefix,
 				Order:      role.Order,
+				After:      append([]string(nil), role.After...),
 			})
 		}
 
@@ -633,13 +968,36 @@ func buildDashboardPlanFromConfig(cfg *config.Config) *teams.AuditPlan {
 				ID:   auditTypeID,
 				Name: epic.AuditName,
 			},
-			RoleBeads: roleBeads,
+			Target:     epic.Target,
+			FocusAreas: append([]string(nil), epic.FocusAreas...),
+			RoleBeads:  roleBeads,
+			DependsOn:  append([]string(nil), epic.DependsOn...),
 		})
 	}
 
 	return plan
 }
 
+// orderRolesByStage fills in dependency stages and sorts roles so each one is
+// listed after the roles it waits for. Roles are expected in config order.
+func orderRolesByStage(roles []dashboardRoleStatus) {
+	roleBeads := make([]teams.RoleBead, 0, len(roles))
+	for _, role := range roles {
+		roleBeads = append(roleBeads, teams.RoleBead{CodeName: role.CodeName, After: role.After})
+	}
+
+	deps := teams.RoleDependencies(roleBeads)
+	stages := teams.RoleStages(roleBeads)
+	for idx := range roles {
+		roles[idx].After = deps[roles[idx].CodeName]
+		roles[idx].Stage = stages[roles[idx].CodeName]
+	}
+
+	sort.SliceStable(roles, func(i, j int) bool {
+		return roles[i].Stage < roles[j].Stage
+	})
+}
+
 func formatRoleProgress(role dashboardRoleStatus) string {
 	if role.Intensity <= 0 {
 		return "-"
@@ -652,34 +1010,37 @@ func formatRoleProgress(role dashboardRoleStatus) string {
 	return fmt.Sprintf("loop %d/%d", role.CurrentLoop, role.Intensity)
 }
 
-func readTeamFile(filePath string) (map[string]string, error) {
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, err
+// formatRoleTiming shows how long a role's attempt has run, its average time per
+// loop and, while it runs, a rough ETA that assumes the remaining loops take as
+// long. Loops a resumed attempt inherited do not count toward the average.
+func formatRoleTiming(role dashboardRoleStatus, now time.Time) string {
+	elapsed := role.Timing.Elapsed(now)
+	if elapsed <= 0 {
+		return ""
 	}
 
-	result := make(map[string]string)
-	for _, line := range strings.Split(string(content), "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) != 2 {
-			continue
+	parts := []string{"elapsed " + formatElapsed(elapsed)}
+	if loops := role.CurrentLoop - role.StartLoop; loops > 0 {
+		perLoop := elapsed / time.Duration(loops)
+		parts = append(parts, formatElapsed(perLoop)+"/loop")
+		if remaining := role.Intensity - role.CurrentLoop; remaining > 0 && (role.Status == "running" || role.Status == "stalled") {
+			parts = append(parts, "ETA ~"+formatElapsed(perLoop*time.Duration(remaining)))
 		}
+	}
 
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-		if key == "" {
-			continue
-		}
+	return strings.Join(parts, ", ")
+}
 
-		result[key] = value
+// formatElapsed renders a duration to the second, or to the minute past an hour.
+func formatElapsed(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
 	}
-
-	return result, nil
 }
 
 func parseIntFallback(value string, fallback int) int {
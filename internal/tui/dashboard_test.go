@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,6 +12,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"lattice/internal/config"
+	"lattice/internal/events"
+	"lattice/internal/report"
 	"lattice/internal/teams"
 )
 
@@ -132,6 +136,74 @@ func TestRenderEpicTableShowsHierarchy(t *testing.T) {
 	}
 }
 
+func TestRenderEpicTableShowsRoleGraphOrder(t *testing.T) {
+	t.Parallel()
+
+	roles := []dashboardRoleStatus{
+		{CodeName: "modeler", Title: "Threat modeler", Status: "complete"},
+		{CodeName: "reviewer", Title: "Review lead", Status: "pending", After: []string{"alpha", "bravo"}},
+		{CodeName: "alpha", Title: "Specialist", Status: "running", After: []string{"modeler"}},
+		{CodeName: "bravo", Title: "Engineer", Status: "running", After: []string{"modeler"}},
+	}
+	orderRolesByStage(roles)
+
+	order := make([]string, 0, len(roles))
+	for _, role := range roles {
+		order = append(order, fmt.Sprintf("%s:%d", role.CodeName, role.Stage))
+	}
+	if got := strings.Join(order, " "); got != "modeler:1 alpha:2 bravo:2 reviewer:3" {
+		t.Fatalf("unexpected role graph order: %s", got)
+	}
+
+	model := NewDashboardModel("/tmp/work", DefaultStyles(), DefaultKeyMap())
+	model.epics = []dashboardEpicStatus{{EpicName: "Security Audit", Status: "running", RolesTotal: 4, Roles: roles}}
+	view := model.renderEpicTable()
+	if !strings.Contains(view, "after alpha, bravo") || !strings.Contains(view, "after modeler") {
+		t.Fatalf("expected epic table to show role dependencies, got:\n%s", view)
+	}
+}
+
+func TestRenderEpicTableShowsWaitingEpics(t *testing.T) {
+	t.Parallel()
+
+	pending := []dashboardRoleStatus{{CodeName: "alpha", Status: "pending"}}
+	if got := deriveEpicStatus(pending, "pending"); got != "pending" {
+		t.Fatalf("expected waiting epic to stay pending, got %q", got)
+	}
+	if got := deriveEpicStatus(pending, "blocked"); got != "blocked" {
+		t.Fatalf("expected epic with failed upstream to stay blocked, got %q", got)
+	}
+
+	model := NewDashboardModel("/tmp/work", DefaultStyles(), DefaultKeyMap())
+	model.epics = []dashboardEpicStatus{
+		{EpicName: "Maintainability", BeadID: "audit-plan-001", Status: "running", RolesTotal: 1},
+		{EpicName: "Error Handling", BeadID: "audit-plan-003", Status: "pending", RolesTotal: 1, Roles: pending, DependsOn: []string{"audit-plan-001"}},
+	}
+	if view := model.renderEpicTable(); !strings.Contains(view, "after Maintainability") {
+		t.Fatalf("expected epic table to show epic dependencies, got:\n%s", view)
+	}
+}
+
+func TestFormatRoleStatusShowsQueueRetryAndStall(t *testing.T) {
+	t.Parallel()
+
+	if got := formatRoleStatus(dashboardRoleStatus{Status: "pending", QueuePosition: 2}); got != "queued #2" {
+		t.Fatalf("expected queued role to show its position, got %q", got)
+	}
+	if got := formatRoleStatus(dashboardRoleStatus{Status: "pending", Attempt: 1, RetryAt: "2026-03-01T03:01:00Z"}); got != "retry #2" {
+		t.Fatalf("expected role waiting to retry to show its next attempt, got %q", got)
+	}
+	if got := formatRoleStatus(dashboardRoleStatus{Status: "stalled"}); got != "STALLED" {
+		t.Fatalf("expected stalled role to stand out, got %q", got)
+	}
+	if got := formatRoleStatus(dashboardRoleStatus{Status: "pending", Paused: true}); got != "PAUSED" {
+		t.Fatalf("expected paused role to stand out, got %q", got)
+	}
+	if got := formatRoleStatus(dashboardRoleStatus{Status: "pending"}); got != "pending" {
+		t.Fatalf("expected unqueued role to stay pending, got %q", got)
+	}
+}
+
 func TestFormatRoleProgress(t *testing.T) {
 	t.Parallel()
 
@@ -170,18 +242,51 @@ func TestFormatRoleProgress(t *testing.T) {
 	}
 }
 
-func TestDashboardRoleDirectoriesPreferAuditTypeNameThenLegacyPrefix(t *testing.T) {
+func TestFormatRoleTiming(t *testing.T) {
 	t.Parallel()
 
-	dirs := dashboardRoleDirectories(config.RoleState{BeadPrefix: "perf-senior-performance-specialist", CodeName: "alpha"}, "role-1")
-	if len(dirs) != 2 {
-		t.Fatalf("expected 2 directory candidates, got %d", len(dirs))
-	}
-	if dirs[0] != "perf-alpha" {
-		t.Fatalf("unexpected primary directory: %q", dirs[0])
+	now := time.Date(2026, time.February, 11, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		role dashboardRoleStatus
+		want string
+	}{
+		{
+			name: "never launched shows nothing",
+			role: dashboardRoleStatus{Status: "pending", Intensity: 3},
+			want: "",
+		},
+		{
+			name: "running shows per-loop average and ETA",
+			role: dashboardRoleStatus{Status: "running", CurrentLoop: 2, Intensity: 5, Timing: config.Timing{LaunchedAt: "2026-02-11T10:10:00Z"}},
+			want: "elapsed 20m00s, 10m00s/loop, ETA ~30m00s",
+		},
+		{
+			name: "resumed attempt averages only its own loops",
+			role: dashboardRoleStatus{Status: "running", CurrentLoop: 4, StartLoop: 2, Intensity: 5, Timing: config.Timing{LaunchedAt: "2026-02-11T10:10:00Z"}},
+			want: "elapsed 20m00s, 10m00s/loop, ETA ~10m00s",
+		},
+		{
+			name: "resumed attempt without a finished loop shows no average",
+			role: dashboardRoleStatus{Status: "running", CurrentLoop: 2, StartLoop: 2, Intensity: 5, Timing: config.Timing{LaunchedAt: "2026-02-11T10:25:00Z"}},
+			want: "elapsed 5m00s",
+		},
+		{
+			name: "finished role uses its recorded duration",
+			role: dashboardRoleStatus{Status: "complete", CurrentLoop: 3, Intensity: 3, Timing: config.Timing{LaunchedAt: "2026-02-11T08:00:00Z", Duration: "1h30m0s"}},
+			want: "elapsed 1h30m, 30m00s/loop",
+		},
 	}
-	if dirs[1] != "perf-senior-performance-specialist-alpha" {
-		t.Fatalf("unexpected fallback directory: %q", dirs[1])
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := formatRoleTiming(tt.role, now); got != tt.want {
+				t.Fatalf("formatRoleTiming() = %q, want %q", got, tt.want)
+			}
+		})
 	}
 }
 
@@ -275,6 +380,86 @@ func TestDashboardRefreshMessageUpdatesState(t *testing.T) {
 	}
 }
 
+func TestDashboardActivityPaneTailsRunJournal(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.Name = "lattice-run-2"
+	cfg.Session.RunID = "run-2"
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance Audit", Status: "running"}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Status: "complete"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+	if err := events.Append(workDir,
+		events.Event{Kind: events.KindLaunch, RunID: "run-1"},
+		events.Event{Kind: events.KindTransition, RunID: "run-2", EpicID: "e1", RoleID: "r1", From: "running", To: "complete"},
+		events.Event{Kind: events.KindError, RunID: "run-2", Source: "scheduler", Message: "save scheduler updates: disk full"},
+	); err != nil {
+		t.Fatalf("Append() returned error: %v", err)
+	}
+
+	snapshot, err := loadDashboardSnapshot(workDir, time.Now())
+	if err != nil {
+		t.Fatalf("loadDashboardSnapshot() returned error: %v", err)
+	}
+	if len(snapshot.Activity) != 2 {
+		t.Fatalf("expected only run-2 events, got %+v", snapshot.Activity)
+	}
+
+	model, _ := NewDashboardModel(workDir, DefaultStyles(), DefaultKeyMap()).Update(dashboardRefreshMsg{Snapshot: snapshot})
+	view := model.View()
+	for _, want := range []string{"Activity", "transition r1 running → complete", "error run-2: save scheduler updates: disk full"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in view:\n%s", want, view)
+		}
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if view := model.View(); strings.Contains(view, "Activity") {
+		t.Fatalf("expected v to hide the activity pane:\n%s", view)
+	}
+}
+
+func TestDashboardSnapshotKeepsEpicsWhenJournalUnreadable(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.RunID = "run-2"
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance Audit", Status: "running"}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Status: "running"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+	if err := os.Mkdir(events.Path(workDir), 0o755); err != nil {
+		t.Fatalf("Mkdir() returned error: %v", err)
+	}
+
+	snapshot, err := loadDashboardSnapshot(workDir, time.Now())
+	if err != nil {
+		t.Fatalf("loadDashboardSnapshot() returned error: %v", err)
+	}
+	if len(snapshot.Epics) != 1 || snapshot.ActivityErr == nil {
+		t.Fatalf("expected epics with an activity error, got %+v", snapshot)
+	}
+
+	model, _ := NewDashboardModel(workDir, DefaultStyles(), DefaultKeyMap()).Update(dashboardRefreshMsg{Snapshot: snapshot})
+	view := model.View()
+	for _, want := range []string{"Performance Audit", "load activity: read event journal"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in view:\n%s", want, view)
+		}
+	}
+}
+
 func TestDashboardModelBackNavigatesToMenu(t *testing.T) {
 	t.Parallel()
 
@@ -297,6 +482,91 @@ func TestDashboardModelBackNavigatesToMenu(t *testing.T) {
 	}
 }
 
+func TestDashboardGenerateReportKeyWritesReport(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Session: config.SessionMetadata{RunID: "20260213-010203"}}
+	var gotRunID string
+	model := NewDashboardModel("/tmp/work", DefaultStyles(), DefaultKeyMap())
+	model.loadConfig = func(string) (*config.Config, error) { return cfg, nil }
+	model.writeReport = func(cwd string, cfg *config.Config, now time.Time) (report.Paths, error) {
+		gotRunID = cfg.Session.RunID
+		return report.Paths{Markdown: "/tmp/work/report.md", HTML: "/tmp/work/report.html"}, nil
+	}
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if cmd == nil {
+		t.Fatal("expected report command")
+	}
+	msg, ok := cmd().(dashboardReportWrittenMsg)
+	if !ok {
+		t.Fatalf("expected dashboardReportWrittenMsg, got %T", msg)
+	}
+	if gotRunID != "20260213-010203" {
+		t.Fatalf("expected report for active run, got %q", gotRunID)
+	}
+
+	updated, _ := model.Update(msg)
+	if !strings.Contains(updated.View(), "Report written to /tmp/work/report.md") {
+		t.Fatalf("expected report notice in view, got %q", updated.View())
+	}
+
+	failed, _ := model.Update(dashboardReportWrittenMsg{Err: errors.New("disk full")})
+	if failed.err == nil || !strings.Contains(failed.err.Error(), "disk full") {
+		t.Fatalf("expected report error, got %v", failed.err)
+	}
+}
+
+func TestDashboardActionKeysApplyToSelectedRow(t *testing.T) {
+	t.Parallel()
+
+	var gotTarget RunActionTarget
+	var gotAction RunAction
+	model := NewDashboardModel("/tmp/work", DefaultStyles(), DefaultKeyMap())
+	model.applyAction = func(cwd string, target RunActionTarget, action RunAction) (RunActionResult, error) {
+		gotTarget, gotAction = target, action
+		return RunActionResult{Changed: []string{target.RoleBeadID}}, nil
+	}
+	model.epics = []dashboardEpicStatus{
+		{BeadID: "e1", EpicName: "Performance", Status: "running", Roles: []dashboardRoleStatus{
+			{BeadID: "r1", CodeName: "alpha", Status: "running"},
+			{BeadID: "r2", CodeName: "bravo", Status: "pending", Paused: true},
+		}},
+	}
+
+	for _, keyMsg := range []tea.KeyMsg{{Type: tea.KeyDown}, {Type: tea.KeyDown}, {Type: tea.KeyDown}} {
+		model, _ = model.Update(keyMsg)
+	}
+	if model.cursor != 2 {
+		t.Fatalf("expected cursor to stop on the last row, got %d", model.cursor)
+	}
+	if view := model.renderEpicTable(); !strings.Contains(view, "> ") || !strings.Contains(view, "PAUSED") {
+		t.Fatalf("expected selected row marker and paused role, got %q", view)
+	}
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if cmd == nil {
+		t.Fatal("expected skip command")
+	}
+	msg, ok := cmd().(dashboardRunActionMsg)
+	if !ok {
+		t.Fatalf("expected dashboardRunActionMsg, got %T", msg)
+	}
+	if gotAction != RunActionSkip || gotTarget != (RunActionTarget{EpicBeadID: "e1", RoleBeadID: "r2"}) {
+		t.Fatalf("unexpected action %q on %+v", gotAction, gotTarget)
+	}
+
+	updated, _ := model.Update(msg)
+	if !strings.Contains(updated.View(), "Skipped: r2") {
+		t.Fatalf("expected skip notice in view, got %q", updated.View())
+	}
+
+	failed, _ := model.Update(dashboardRunActionMsg{Action: RunActionPause, Err: errors.New("role r1 is running")})
+	if failed.err == nil || !strings.Contains(failed.err.Error(), "pause: role r1 is running") {
+		t.Fatalf("expected action error, got %v", failed.err)
+	}
+}
+
 func TestDashboardTickTriggersSchedulerCheck(t *testing.T) {
 	t.Parallel()
 
@@ -399,7 +669,13 @@ func TestRenderEpicTableHighlightsFailedRolesClearly(t *testing.T) {
 		},
 	}}
 
+	exitCode := 2
+	model.epics[0].Roles[0].ExitCode = &exitCode
+
 	view := model.renderEpicTable()
+	if !strings.Contains(view, "exit 2") {
+		t.Fatalf("expected failed role exit code, got: %q", view)
+	}
 	if !strings.Contains(view, "BLOCKED") {
 		t.Fatalf("expected blocked epic status, got: %q", view)
 	}
@@ -0,0 +1,15 @@
+package tui
+
+import "lattice/internal/events"
+
+// recordEventsFunc appends entries to a project's event journal.
+type recordEventsFunc func(cwd string, journal ...events.Event) error
+
+// recordEvents appends journal with record, or events.Append when record is nil.
+func recordEvents(record recordEventsFunc, cwd string, journal ...events.Event) error {
+	if record == nil {
+		record = events.Append
+	}
+
+	return record(cwd, journal...)
+}
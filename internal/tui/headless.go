@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"lattice/internal/config"
+	"lattice/internal/events"
+)
+
+const headlessDefaultInterval = dashboardRefreshInterval
+
+// HeadlessOptions configures an unattended scheduler loop.
+type HeadlessOptions struct {
+	Interval      time.Duration
+	Log           io.Writer
+	SchedulerDeps SchedulerDeps
+}
+
+// HeadlessResult summarizes how an unattended scheduler loop ended.
+type HeadlessResult struct {
+	Passes  int
+	AllDone bool
+	Blocked bool
+	Failed  []string
+	// Unfinished lists every role whose final status is not complete or
+	// skipped, including roles that failed before this loop started.
+	Unfinished []string
+}
+
+// ExitCode maps the loop outcome to a process exit status.
+// 0 means every role completed, 2 means the run ended with failed or blocked roles.
+func (r HeadlessResult) ExitCode() int {
+	if r.AllDone && len(r.Failed) == 0 && len(r.Unfinished) == 0 {
+		return 0
+	}
+
+	return 2
+}
+
+// RunHeadless advances roles without the TUI until all roles are terminal
+// or no further progress is possible.
+func RunHeadless(ctx context.Context, cwd string, opts HeadlessOptions) (HeadlessResult, error) {
+	if strings.TrimSpace(cwd) == "" {
+		return HeadlessResult{}, fmt.Errorf("working directory must not be empty")
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = headlessDefaultInterval
+	}
+	logOut := opts.Log
+	if logOut == nil {
+		logOut = io.Discard
+	}
+
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		return HeadlessResult{}, fmt.Errorf("load lattice config: %w", err)
+	}
+	if len(cfg.Epics) == 0 {
+		return HeadlessResult{}, fmt.Errorf("no audit epics found in %s; launch an audit first", config.DirName)
+	}
+
+	deps, err := resolveSchedulerDeps(opts.SchedulerDeps, cfg.Session)
+	if err != nil {
+		return HeadlessResult{}, err
+	}
+
+	logHeadless(logOut, deps.Now(), "scheduler started for session %s (%d epics, %d roles)", cfg.Session.Name, len(cfg.Epics), len(cfg.Roles))
+
+	result := HeadlessResult{}
+	for {
+		pass, _, err := runSchedulerPass(cwd, config.Load, buildDashboardPlanFromConfig, CheckAndAdvanceRoles, deps)
+		if err != nil {
+			return result, err
+		}
+		result.Passes++
+
+		now := deps.Now()
+		for _, role := range pass.Launched {
+			logHeadless(logOut, now, "launched %s (%s/%s) in window %s", role.RoleBeadID, role.AuditType, role.CodeName, role.WindowName)
+		}
+		for _, roleID := range pass.Completed {
+			logHeadless(logOut, now, "completed %s", roleID)
+		}
+		for _, roleID := range pass.Stalled {
+			logHeadless(logOut, now, "stalled %s (no activity for %s)", roleID, stallTimeoutLabel(cfg.Session.Stall))
+		}
+		for _, retry := range pass.Retried {
+			if retry.MaxAttempts == 0 {
+				logHeadless(logOut, now, "restarting %s as attempt %d", retry.RoleBeadID, retry.NextAttempt)
+				continue
+			}
+			logHeadless(logOut, now, "failed %s; attempt %d/%d at %s", retry.RoleBeadID, retry.NextAttempt, retry.MaxAttempts, retry.RetryAt.Format(time.RFC3339))
+		}
+		for _, roleID := range pass.Failed {
+			logHeadless(logOut, now, "failed %s", roleID)
+			result.Failed = append(result.Failed, roleID)
+		}
+		for _, hookErr := range pass.HookErrors {
+			logHeadless(logOut, now, "%v", hookErr)
+		}
+
+		if pass.AllDone {
+			result.AllDone = true
+			if result.Unfinished, err = unfinishedRoles(cwd); err != nil {
+				return result, err
+			}
+			logHeadless(logOut, now, "all roles reached a terminal state (%d not complete)", len(result.Unfinished))
+			return result, nil
+		}
+		if pass.Blocked {
+			result.Blocked = true
+			if result.Unfinished, err = unfinishedRoles(cwd); err != nil {
+				return result, err
+			}
+			logHeadless(logOut, now, "no role is running and remaining roles are blocked")
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			logHeadless(logOut, deps.Now(), "scheduler stopped: %v", ctx.Err())
+			return result, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// unfinishedRoles returns the bead IDs of roles whose saved status is neither
+// complete nor skipped.
+func unfinishedRoles(cwd string) ([]string, error) {
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		return nil, fmt.Errorf("load lattice config: %w", err)
+	}
+
+	var unfinished []string
+	for _, role := range cfg.Roles {
+		switch role.Status {
+		case "complete", "skipped":
+		default:
+			unfinished = append(unfinished, role.BeadID)
+		}
+	}
+	sort.Strings(unfinished)
+
+	return unfinished, nil
+}
+
+// stallTimeoutLabel returns the stall timeout for log lines.
+func stallTimeoutLabel(policy config.StallPolicy) string {
+	timeout, err := policy.TimeoutDuration()
+	if err != nil || timeout == 0 {
+		return "the stall timeout"
+	}
+
+	return timeout.String()
+}
+
+// runSchedulerPass loads config, advances roles once, persists any transitions
+// and runs their hooks. It reports whether the config changed and was saved.
+func runSchedulerPass(cwd string, loadConfig dashboardLoadConfigFunc, buildPlan dashboardBuildPlanFunc, advanceRoles dashboardCheckAndAdvanceRolesFunc, deps SchedulerDeps) (SchedulerResult, bool, error) {
+	cfg, err := loadConfig(cwd)
+	if err != nil {
+		return SchedulerResult{}, false, fmt.Errorf("load lattice config: %w", err)
+	}
+	if len(cfg.Epics) == 0 {
+		return SchedulerResult{}, false, nil
+	}
+
+	plan := buildPlan(cfg)
+	if plan == nil || len(plan.Epics) == 0 {
+		return SchedulerResult{}, false, nil
+	}
+
+	result, err := advanceRoles(cwd, cfg, cfg.Session.Name, plan, deps)
+	if err != nil {
+		// The scheduler error is what the caller reports; a journal failure on
+		// top of it is dropped.
+		_ = recordEvents(deps.RecordEvents, cwd, events.Event{Kind: events.KindError, Source: "scheduler", RunID: cfg.Session.RunID, Message: err.Error()})
+		return result, false, err
+	}
+
+	if len(result.Launched) == 0 && len(result.Completed) == 0 && len(result.Failed) == 0 && len(result.Retried) == 0 && len(result.Stalled) == 0 && len(result.EpicsFinished) == 0 && len(result.Transitions) == 0 && !result.RunCompleted && !result.ActivityChanged {
+		return result, false, nil
+	}
+
+	if err := cfg.Save(); err != nil {
+		return result, false, fmt.Errorf("save scheduler updates: %w", err)
+	}
+	if err := recordEvents(deps.RecordEvents, cwd, result.Transitions...); err != nil {
+		return result, true, fmt.Errorf("record scheduler events: %w", err)
+	}
+
+	now := time.Now()
+	if deps.Now != nil {
+		now = deps.Now()
+	}
+	hookEvents, hookErrs := runTransitionHooks(cwd, cfg, result, deps.RunHook, now)
+	result.HookErrors = hookErrs
+	if err := recordEvents(deps.RecordEvents, cwd, hookEvents...); err != nil {
+		return result, true, fmt.Errorf("record hook events: %w", err)
+	}
+
+	return result, true, nil
+}
+
+func logHeadless(out io.Writer, now time.Time, format string, args ...any) {
+	fmt.Fprintf(out, "%s %s\n", now.UTC().Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
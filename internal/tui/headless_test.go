@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"lattice/internal/config"
+	"lattice/internal/teams"
+)
+
+func TestRunHeadlessAdvancesUntilAllDone(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.Name = "lattice-20260213-010203"
+	cfg.Epics = map[string]config.EpicState{
+		"perf": {BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"},
+	}
+	cfg.Roles = map[string]config.RoleState{
+		"r1": {BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Title: "Alpha", Guidance: "A", BeadPrefix: "perf-alpha", Order: 1, Status: "running", Intensity: 1},
+		"r2": {BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Title: "Bravo", Guidance: "B", BeadPrefix: "perf-bravo", Order: 2, Status: "pending", Intensity: 1},
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+	writeRoleTeamStatus(t, workDir, "perf-alpha", "complete")
+
+	manager := &fakeLaunchTmuxManager{}
+	var logs bytes.Buffer
+	result, err := RunHeadless(context.Background(), workDir, HeadlessOptions{
+		Interval: time.Millisecond,
+		Log:      &logs,
+		SchedulerDeps: SchedulerDeps{
+			GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) {
+				writeRoleTeamStatus(t, params.Cwd, params.AuditTypeID+"-"+params.CodeName, "complete")
+				return params.Cwd, nil
+			},
+			Executor: &fakeExecutor{
+				manager: manager,
+				alive:   func(sessionName, windowName string) bool { return false },
+			},
+			Now: func() time.Time { return time.Date(2026, time.February, 13, 1, 2, 3, 0, time.UTC) },
+		},
+	})
+	if err != nil {
+		t.Fatalf("RunHeadless() returned error: %v", err)
+	}
+
+	if !result.AllDone || result.ExitCode() != 0 {
+		t.Fatalf("expected successful completion, got %+v", result)
+	}
+	if len(manager.windowCalls) != 1 || manager.windowCalls[0] != "lattice-20260213-010203:e1-bravo" {
+		t.Fatalf("unexpected window calls: %#v", manager.windowCalls)
+	}
+	for _, fragment := range []string{"completed r1", "launched r2 (perf/bravo)", "completed r2", "all roles reached a terminal state"} {
+		if !strings.Contains(logs.String(), fragment) {
+			t.Fatalf("expected log to include %q, got %q", fragment, logs.String())
+		}
+	}
+
+	saved, err := config.Load(workDir)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if saved.Roles["r2"].Status != "complete" {
+		t.Fatalf("expected r2 complete in saved config, got %q", saved.Roles["r2"].Status)
+	}
+}
+
+func TestRunHeadlessStopsWhenRemainingRolesBlocked(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.Name = "sess"
+	cfg.Epics = map[string]config.EpicState{
+		"perf": {BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"},
+	}
+	cfg.Roles = map[string]config.RoleState{
+		"r1": {BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", BeadPrefix: "perf-alpha", Order: 1, Status: "running", Intensity: 1},
+		"r2": {BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", BeadPrefix: "perf-bravo", Order: 2, Status: "pending", Intensity: 1},
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	result, err := RunHeadless(context.Background(), workDir, HeadlessOptions{
+		Interval: time.Millisecond,
+		SchedulerDeps: SchedulerDeps{
+			Executor: &fakeExecutor{
+				alive: func(sessionName, windowName string) bool { return false },
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RunHeadless() returned error: %v", err)
+	}
+
+	if !result.Blocked || result.AllDone {
+		t.Fatalf("expected blocked result, got %+v", result)
+	}
+	if len(result.Failed) != 1 || result.Failed[0] != "r1" {
+		t.Fatalf("unexpected failed roles: %#v", result.Failed)
+	}
+	if result.ExitCode() != 2 {
+		t.Fatalf("expected exit code 2, got %d", result.ExitCode())
+	}
+}
+
+func TestRunHeadlessExitsNonZeroForRolesFailedBeforeStart(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.Name = "sess"
+	cfg.Epics = map[string]config.EpicState{
+		"perf": {BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "failed"},
+	}
+	cfg.Roles = map[string]config.RoleState{
+		"r1": {BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", BeadPrefix: "perf-alpha", Order: 1, Status: "failed", Intensity: 1},
+		"r2": {BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", BeadPrefix: "perf-bravo", Order: 1, Status: "complete", Intensity: 1},
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	result, err := RunHeadless(context.Background(), workDir, HeadlessOptions{
+		Interval: time.Millisecond,
+		SchedulerDeps: SchedulerDeps{
+			Executor: &fakeExecutor{
+				alive: func(sessionName, windowName string) bool { return false },
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RunHeadless() returned error: %v", err)
+	}
+
+	if !result.AllDone || len(result.Failed) != 0 {
+		t.Fatalf("expected all done with no failures this run, got %+v", result)
+	}
+	if len(result.Unfinished) != 1 || result.Unfinished[0] != "r1" {
+		t.Fatalf("unexpected unfinished roles: %#v", result.Unfinished)
+	}
+	if result.ExitCode() != 2 {
+		t.Fatalf("expected exit code 2, got %d", result.ExitCode())
+	}
+}
+
+func TestRunHeadlessRequiresEpics(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	if _, err := config.Init(workDir); err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+
+	_, err := RunHeadless(context.Background(), workDir, HeadlessOptions{SchedulerDeps: SchedulerDeps{Executor: &fakeExecutor{}}})
+	if err == nil || !strings.Contains(err.Error(), "no audit epics") {
+		t.Fatalf("expected missing epics error, got %v", err)
+	}
+}
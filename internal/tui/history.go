@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"lattice/internal/runs"
+)
+
+type historyLoadedMsg struct {
+	Runs []runs.Summary
+	Err  error
+}
+
+type historyDetailMsg struct {
+	Detail runs.Detail
+	Err    error
+}
+
+// HistoryModel lists archived runs and shows the epics and reports of one run.
+type HistoryModel struct {
+	styles Styles
+	keyMap KeyMap
+	cwd    string
+
+	listRuns func(cwd string) ([]runs.Summary, error)
+	showRun  func(cwd, runID string) (runs.Detail, error)
+
+	runs   []runs.Summary
+	cursor int
+	detail *runs.Detail
+	loaded bool
+	err    error
+}
+
+// NewHistoryModel creates the run history screen.
+func NewHistoryModel(cwd string, styles Styles, keyMap KeyMap) HistoryModel {
+	return HistoryModel{
+		styles:   styles,
+		keyMap:   keyMap,
+		cwd:      cwd,
+		listRuns: runs.List,
+		showRun:  runs.Show,
+	}
+}
+
+// Init loads the run list.
+func (m HistoryModel) Init() tea.Cmd {
+	cwd := m.cwd
+	listRuns := m.listRuns
+	return func() tea.Msg {
+		summaries, err := listRuns(cwd)
+		return historyLoadedMsg{Runs: summaries, Err: err}
+	}
+}
+
+// Update handles run selection and navigation.
+func (m HistoryModel) Update(msg tea.Msg) (HistoryModel, tea.Cmd) {
+	switch typed := msg.(type) {
+	case historyLoadedMsg:
+		m.loaded = true
+		m.err = typed.Err
+		m.runs = typed.Runs
+		if m.cursor >= len(m.runs) {
+			m.cursor = 0
+		}
+		return m, nil
+	case historyDetailMsg:
+		if typed.Err != nil {
+			m.err = typed.Err
+			return m, nil
+		}
+		detail := typed.Detail
+		m.detail = &detail
+		m.err = nil
+		return m, nil
+	case tea.KeyMsg:
+		if key.Matches(typed, m.keyMap.Back) {
+			if m.detail != nil {
+				m.detail = nil
+				return m, nil
+			}
+			return m, func() tea.Msg { return NavigateTo(MenuScreen) }
+		}
+		if m.detail != nil || len(m.runs) == 0 {
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(typed, m.keyMap.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(typed, m.keyMap.Down):
+			if m.cursor < len(m.runs)-1 {
+				m.cursor++
+			}
+		case key.Matches(typed, m.keyMap.Select):
+			return m, m.showCmd(m.runs[m.cursor].ID)
+		}
+	}
+
+	return m, nil
+}
+
+// View renders either the run list or the selected run.
+func (m HistoryModel) View() string {
+	lines := []string{
+		m.styles.Header.Render("LATTICE"),
+		m.styles.Subheader.Render("Run History"),
+		"",
+	}
+
+	if m.err != nil {
+		lines = append(lines, m.styles.Error.Render(m.err.Error()), "")
+	}
+
+	if m.detail != nil {
+		lines = append(lines, m.renderDetail(), "", m.styles.Help.Render("esc: runs  q: quit"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	lines = append(lines, m.renderList(), "", m.styles.Help.Render("enter: show run  esc: menu  q: quit"))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (m HistoryModel) renderList() string {
+	if !m.loaded {
+		return m.styles.Muted.Render("Loading runs...")
+	}
+	if len(m.runs) == 0 {
+		return m.styles.Muted.Render("No runs recorded yet.")
+	}
+
+	header := fmt.Sprintf("  %-20s %-10s %-12s %s", "RUN", "STATUS", "ROLES", "CREATED")
+	rows := []string{m.styles.Muted.Render(header)}
+	for idx, run := range m.runs {
+		prefix := " "
+		if idx == m.cursor {
+			prefix = m.styles.FocusedMark.Render(">")
+		}
+
+		label := run.ID
+		if run.Active {
+			label += " *"
+		}
+		row := fmt.Sprintf("%s %-20s %-10s %-12s %s", prefix, label, run.Status, fmt.Sprintf("%d/%d done", run.Complete, run.Roles), fallbackText(run.CreatedAt, "-"))
+		if idx == m.cursor {
+			rows = append(rows, m.styles.Selected.Render(row))
+			continue
+		}
+		rows = append(rows, m.styles.ListItem.Render(row))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+func (m HistoryModel) renderDetail() string {
+	detail := m.detail
+	rows := []string{
+		m.styles.Body.Render(fmt.Sprintf("Run: %s (%s)", detail.ID, detail.Status)),
+		m.styles.Muted.Render(fmt.Sprintf("Session: %s  Created: %s", fallbackText(detail.SessionName, "-"), fallbackText(detail.CreatedAt, "-"))),
+		"",
+	}
+
+	if len(detail.EpicDetails) == 0 {
+		rows = append(rows, m.styles.Muted.Render("No epics recorded for this run."))
+		return lipgloss.JoinVertical(lipgloss.Left, rows...)
+	}
+
+	for _, epic := range detail.EpicDetails {
+		rows = append(rows, m.styles.Body.Render(fmt.Sprintf("%-24s %s", fallbackText(epic.AuditName, epic.AuditType), formatDashboardStatus(epic.Status))))
+		for _, role := range epic.Roles {
+			report := fallbackText(role.ReportPath, "no report")
+			rows = append(rows, m.styles.ListItem.Render(fmt.Sprintf("  %-22s %-12s %s", fallbackText(role.CodeName, role.BeadID), formatDashboardStatus(role.Status), report)))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+func (m HistoryModel) showCmd(runID string) tea.Cmd {
+	cwd := m.cwd
+	showRun := m.showRun
+	return func() tea.Msg {
+		detail, err := showRun(cwd, strings.TrimSpace(runID))
+		return historyDetailMsg{Detail: detail, Err: err}
+	}
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"lattice/internal/runs"
+)
+
+func TestHistoryListsRunsAndShowsSelectedRun(t *testing.T) {
+	t.Parallel()
+
+	var shownID string
+	model := NewHistoryModel("/tmp/test", DefaultStyles(), DefaultKeyMap())
+	model.showRun = func(_ string, runID string) (runs.Detail, error) {
+		shownID = runID
+		return runs.Detail{
+			Summary: runs.Summary{ID: runID, Status: "failed"},
+			EpicDetails: []runs.EpicDetail{{
+				AuditName: "Performance",
+				Status:    "failed",
+				Roles: []runs.RoleDetail{
+					{CodeName: "alpha", Status: "complete", ReportPath: "/tmp/test/.lattice/runs/20260101-090000/teams/perf-alpha/context/REPORT.md"},
+					{CodeName: "bravo", Status: "failed"},
+				},
+			}},
+		}, nil
+	}
+
+	model, _ = model.Update(historyLoadedMsg{Runs: []runs.Summary{
+		{ID: "20260102-090000", Status: "running", Roles: 2, Active: true},
+		{ID: "20260101-090000", Status: "failed", Roles: 2, Complete: 1, Failed: 1},
+	}})
+
+	view := model.View()
+	if !strings.Contains(view, "20260102-090000 *") || !strings.Contains(view, "1/2 done") {
+		t.Fatalf("expected run list in view, got %q", view)
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected show command")
+	}
+	model, _ = model.Update(cmd())
+	if shownID != "20260101-090000" {
+		t.Fatalf("expected selected run to be shown, got %q", shownID)
+	}
+
+	view = model.View()
+	for _, fragment := range []string{"Run: 20260101-090000 (failed)", "Performance", "perf-alpha/context/REPORT.md", "no report"} {
+		if !strings.Contains(view, fragment) {
+			t.Fatalf("expected detail view to include %q, got %q", fragment, view)
+		}
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.detail != nil {
+		t.Fatal("expected esc to return to the run list")
+	}
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("expected esc on run list to navigate back")
+	}
+	if nav, ok := cmd().(AppNavigateMsg); !ok || nav.Screen != MenuScreen {
+		t.Fatalf("expected navigation to menu, got %#v", cmd())
+	}
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"path/filepath"
+	"time"
+
+	"lattice/internal/config"
+	"lattice/internal/events"
+	"lattice/internal/hooks"
+	"lattice/internal/teams"
+)
+
+// runHookFunc runs one hook command for a transition.
+type runHookFunc func(command string, payload hooks.Payload) error
+
+// runTransitionHooks runs the run's configured hooks for every transition in
+// result, in order: launches, completions, failures, finished epics and the
+// finished run. It returns a journal event per command; failing hooks are
+// returned instead of stopping the scheduler.
+func runTransitionHooks(cwd string, cfg *config.Config, result SchedulerResult, runHook runHookFunc, now time.Time) ([]events.Event, []error) {
+	hookConfig := cfg.Session.Hooks
+	if runHook == nil {
+		timeout, err := hookConfig.TimeoutDuration()
+		if err != nil {
+			return nil, []error{err}
+		}
+		runHook = hooks.NewRunner(filepath.Join(config.LogsDir(cwd), hooks.LogFileName), timeout).Run
+	}
+
+	var journal []events.Event
+	var errs []error
+	fire := func(event string, payload hooks.Payload) {
+		for _, command := range hookConfig.Commands(event) {
+			payload.Event = event
+			entry := events.Event{
+				Time:    now.UTC(),
+				Kind:    events.KindHook,
+				Source:  "scheduler",
+				RunID:   payload.RunID,
+				Action:  event,
+				Message: command + ": ok",
+			}
+			if payload.Epic != nil {
+				entry.EpicID = payload.Epic.BeadID
+			}
+			if payload.Role != nil {
+				entry.RoleID = payload.Role.BeadID
+			}
+			if err := runHook(command, payload); err != nil {
+				entry.Message = err.Error()
+				errs = append(errs, err)
+			}
+			journal = append(journal, entry)
+		}
+	}
+
+	base := hooks.Payload{
+		Time:        now.UTC(),
+		RunID:       cfg.Session.RunID,
+		SessionName: cfg.Session.Name,
+		ProjectDir:  cwd,
+	}
+	rolePayload := func(roleID, sessionDir string) hooks.Payload {
+		state := cfg.Roles[roleID]
+		if sessionDir == "" {
+			sessionDir = filepath.Join(config.TeamsDir(cwd, cfg.Session.RunID), teams.RoleDirName(state.EpicBeadID, "", fallbackText(state.CodeName, roleID)))
+		}
+
+		payload := base
+		payload.Epic = hookEpic(cfg, state.EpicBeadID)
+		payload.Role = &hooks.Role{
+			BeadID:     roleID,
+			CodeName:   state.CodeName,
+			Title:      state.Title,
+			Status:     normalizeRoleStatus(state.Status),
+			Attempt:    state.Attempt,
+			TmuxWindow: state.TmuxWindow,
+			SessionDir: sessionDir,
+			ExitCode:   state.ExitCode,
+		}
+		return payload
+	}
+
+	for _, role := range result.Launched {
+		fire(config.HookRoleLaunch, rolePayload(role.RoleBeadID, role.SessionDir))
+	}
+	for _, roleID := range result.Completed {
+		fire(config.HookRoleComplete, rolePayload(roleID, ""))
+	}
+	for _, roleID := range result.Failed {
+		fire(config.HookRoleFail, rolePayload(roleID, ""))
+	}
+	for _, epicID := range result.EpicsFinished {
+		payload := base
+		payload.Epic = hookEpic(cfg, epicID)
+		fire(config.HookEpicComplete, payload)
+	}
+	if result.RunCompleted {
+		fire(config.HookRunComplete, base)
+	}
+
+	return journal, errs
+}
+
+func hookEpic(cfg *config.Config, epicID string) *hooks.Epic {
+	epic := cfg.Epics[epicID]
+	return &hooks.Epic{
+		BeadID:    fallbackText(epic.BeadID, epicID),
+		AuditType: epic.AuditType,
+		AuditName: epic.AuditName,
+		Target:    epic.Target,
+		Status:    epic.Status,
+	}
+}
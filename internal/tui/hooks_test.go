@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"lattice/internal/config"
+	"lattice/internal/events"
+	"lattice/internal/hooks"
+	"lattice/internal/teams"
+)
+
+func TestRunSchedulerPassRunsTransitionHooks(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	cfg, err := config.Init(cwd)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	exitCode := 1
+	cfg.Session.Name = "lattice-20260213-010203"
+	cfg.Session.RunID = "20260213-010203"
+	cfg.Session.Hooks = config.HookConfig{
+		OnRoleLaunch:   []string{"./launched.sh"},
+		OnRoleFail:     []string{"./notify.sh", "./page.sh"},
+		OnEpicComplete: []string{"./copy-reports.sh"},
+		OnRunComplete:  []string{"bd sync"},
+	}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "failed"}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Status: "failed", Attempt: 2, ExitCode: &exitCode}
+	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Status: "running", TmuxWindow: "lattice-20260213-010203:e1-bravo"}
+
+	var calls []string
+	var payloads []hooks.Payload
+	deps := SchedulerDeps{
+		Now: func() time.Time { return time.Date(2026, time.February, 13, 2, 0, 0, 0, time.UTC) },
+		RunHook: func(command string, payload hooks.Payload) error {
+			calls = append(calls, payload.Event+" "+command)
+			payloads = append(payloads, payload)
+			if command == "./page.sh" {
+				return errors.New("pager offline")
+			}
+			return nil
+		},
+	}
+	advance := func(string, *config.Config, string, *teams.AuditPlan, SchedulerDeps) (SchedulerResult, error) {
+		return SchedulerResult{
+			Launched:      []ScheduledRole{{RoleBeadID: "r2", SessionDir: "/tmp/work/teams/e1-bravo"}},
+			Failed:        []string{"r1"},
+			EpicsFinished: []string{"e1"},
+			RunCompleted:  true,
+			Transitions:   []events.Event{{Kind: events.KindTransition, RunID: "20260213-010203", RoleID: "r1", From: "running", To: "failed"}},
+		}, nil
+	}
+
+	result, changed, err := runSchedulerPass(cwd, func(string) (*config.Config, error) { return cfg, nil }, buildDashboardPlanFromConfig, advance, deps)
+	if err != nil || !changed {
+		t.Fatalf("runSchedulerPass() = changed %v, err %v", changed, err)
+	}
+
+	want := []string{
+		"on_role_launch ./launched.sh",
+		"on_role_fail ./notify.sh",
+		"on_role_fail ./page.sh",
+		"on_epic_complete ./copy-reports.sh",
+		"on_run_complete bd sync",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected hook calls:\n%s", strings.Join(calls, "\n"))
+	}
+	if len(result.HookErrors) != 1 || !strings.Contains(result.HookErrors[0].Error(), "pager offline") {
+		t.Fatalf("expected failing hook to be reported, got %v", result.HookErrors)
+	}
+
+	launch := payloads[0]
+	if launch.Role == nil || launch.Role.SessionDir != "/tmp/work/teams/e1-bravo" || launch.Epic == nil || launch.Epic.AuditName != "Performance" {
+		t.Fatalf("unexpected launch payload: %+v", launch)
+	}
+	fail := payloads[1]
+	if fail.Role.Status != "failed" || fail.Role.Attempt != 2 || fail.Role.ExitCode == nil || *fail.Role.ExitCode != 1 || fail.RunID != "20260213-010203" || fail.ProjectDir != cwd {
+		t.Fatalf("unexpected failure payload: %+v %+v", fail, fail.Role)
+	}
+	if run := payloads[4]; run.Role != nil || run.Epic != nil {
+		t.Fatalf("expected run payload without epic or role, got %+v", run)
+	}
+
+	journal, err := events.Read(cwd, events.Filter{})
+	if err != nil {
+		t.Fatalf("events.Read() returned error: %v", err)
+	}
+	if len(journal) != 6 || journal[0].Kind != events.KindTransition || journal[0].To != "failed" {
+		t.Fatalf("expected the transition followed by five hook events, got %+v", journal)
+	}
+	if page := journal[3]; page.Kind != events.KindHook || page.Action != config.HookRoleFail || page.RoleID != "r1" || !strings.Contains(page.Message, "pager offline") {
+		t.Fatalf("unexpected failing hook event: %+v", page)
+	}
+}
@@ -1,14 +1,21 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"lattice/internal/agent"
 	"lattice/internal/config"
+	"lattice/internal/events"
+	"lattice/internal/executor"
+	"lattice/internal/panelog"
+	"lattice/internal/runs"
 	"lattice/internal/teams"
 	"lattice/internal/tmux"
 )
@@ -28,38 +35,55 @@ type launchRequest struct {
 	agentCount int
 	intensity  int
 	focusAreas []string
-}
-
-type launchTmuxManager interface {
-	CreateSession(name string) error
-	CreateWindow(session, name string) error
-	SendKeys(session, window, command string) error
+	// epics overrides auditTypes with explicitly scoped epics when set.
+	epics []teams.EpicSpec
+	// dependsOn maps an audit type ID to the audit types its epics wait for.
+	dependsOn map[string][]string
+	// maxConcurrentRoles caps running role sessions; 0 means no limit.
+	maxConcurrentRoles int
+	// retry overrides each audit type's retry policy when MaxAttempts is set.
+	retry config.RetryPolicy
+	// stall decides when running roles count as stalled; the zero value disables it.
+	stall config.StallPolicy
+	// hooks run shell commands on scheduler transitions.
+	hooks config.HookConfig
+	// executor starts the run's roles: tmux or process; empty means tmux.
+	executor string
+	// tmuxSocket selects the tmux server of tmux runs; empty means the default.
+	tmuxSocket string
+	// agent overrides each audit type's agent runtime when set.
+	agent string
+	// defaultAgent runs audit types that name no runtime; empty means opencode.
+	defaultAgent string
+	// agentRuntimes defines the custom agent runtimes by name.
+	agentRuntimes map[string]config.AgentRuntime
+	// agentParams overrides each role's model and agent flags where set.
+	agentParams config.AgentParams
 }
 
 type launchDeps struct {
 	initConfig          func(cwd string) (*config.Config, error)
-	newTmuxManager      func() (launchTmuxManager, error)
+	newExecutor         func(kind, tmuxSocket string) (executor.Executor, error)
 	generateRoleSession func(params teams.RoleSessionParams) (string, error)
-	buildAuditPlan      func(auditTypes []teams.AuditType, agentCount int, intensity int, startCounter int) (*teams.AuditPlan, error)
-	translatePath       func(path string) (string, error)
-	now                 func() time.Time
+	buildAuditPlan      func(specs []teams.EpicSpec, agentCount int, intensity int, startCounter int) (*teams.AuditPlan, error)
+	recordEvents        recordEventsFunc
+	// runHook runs the on_role_launch hooks of the first admitted roles; nil
+	// runs them through the shell like the scheduler does.
+	runHook runHookFunc
+	now     func() time.Time
 }
 
 func defaultLaunchDeps() launchDeps {
 	return launchDeps{
 		initConfig:          config.Init,
-		newTmuxManager:      newLaunchTmuxManager,
+		newExecutor:         executor.New,
 		generateRoleSession: teams.GenerateRoleSession,
-		buildAuditPlan:      teams.BuildAuditPlan,
-		translatePath:       tmux.TranslateToWSLPath,
+		buildAuditPlan:      teams.BuildEpicPlan,
+		recordEvents:        events.Append,
 		now:                 time.Now,
 	}
 }
 
-func newLaunchTmuxManager() (launchTmuxManager, error) {
-	return tmux.NewManager()
-}
-
 func launchAuditCmd(req launchRequest) tea.Cmd {
 	deps := defaultLaunchDeps()
 	return func() tea.Msg {
@@ -67,123 +91,317 @@ func launchAuditCmd(req launchRequest) tea.Cmd {
 	}
 }
 
+// launchAudit starts a run and journals the launch, or the error that stopped it.
 func launchAudit(req launchRequest, deps launchDeps) tea.Msg {
+	msg := startAudit(req, deps)
+	if failed, ok := msg.(LaunchFailedMsg); ok && strings.TrimSpace(req.cwd) != "" {
+		// The launch error is what the user needs to see; a journal failure on
+		// top of it is dropped.
+		_ = recordEvents(deps.recordEvents, req.cwd, events.Event{Kind: events.KindError, Action: "launch", Message: failed.Err.Error()})
+	}
+
+	return msg
+}
+
+func startAudit(req launchRequest, deps launchDeps) tea.Msg {
 	if strings.TrimSpace(req.cwd) == "" {
 		return LaunchFailedMsg{Err: fmt.Errorf("working directory must not be empty")}
 	}
-	if len(req.auditTypes) == 0 {
+	if len(req.auditTypes) == 0 && len(req.epics) == 0 {
 		return LaunchFailedMsg{Err: fmt.Errorf("select at least one audit type")}
 	}
 
+	runtimes, err := launchAgentRuntimes(req)
+	if err != nil {
+		return LaunchFailedMsg{Err: err}
+	}
+
 	cfg, err := deps.initConfig(req.cwd)
 	if err != nil {
 		return LaunchFailedMsg{Err: fmt.Errorf("initialize lattice config: %w", err)}
 	}
 
-	plan, err := deps.buildAuditPlan(req.auditTypes, req.agentCount, req.intensity, cfg.BeadCounter)
+	// Archiving a run with live roles would leave them running unscheduled.
+	if live := liveRoles(cfg); len(live) > 0 {
+		return LaunchFailedMsg{Err: fmt.Errorf("run %s still has running roles (%s); stop it with lattice stop before launching", fallbackText(cfg.Session.RunID, cfg.Session.Name), strings.Join(live, ", "))}
+	}
+	if err := runs.Archive(req.cwd, cfg); err != nil {
+		return LaunchFailedMsg{Err: fmt.Errorf("archive previous run: %w", err)}
+	}
+	runID := runs.NewID(req.cwd, deps.now())
+
+	plan, err := deps.buildAuditPlan(launchEpicSpecs(req), req.agentCount, req.intensity, cfg.BeadCounter)
 	if err != nil {
 		return LaunchFailedMsg{Err: fmt.Errorf("build audit plan: %w", err)}
 	}
 	cfg.BeadCounter = plan.FinalCounter
 
-	manager, err := deps.newTmuxManager()
+	executorKind, err := config.ResolveExecutor(req.executor)
 	if err != nil {
-		return LaunchFailedMsg{Err: fmt.Errorf("initialize tmux manager: %w", err)}
+		return LaunchFailedMsg{Err: err}
 	}
-
-	sessionName := "lattice-" + deps.now().UTC().Format("20060102-150405")
-	if err := manager.CreateSession(sessionName); err != nil {
-		return LaunchFailedMsg{Err: fmt.Errorf("create tmux session: %w", err)}
+	tmuxSocket := ""
+	if executorKind == config.ExecutorTmux {
+		tmuxSocket = strings.TrimSpace(req.tmuxSocket)
+	}
+	runner, err := deps.newExecutor(executorKind, tmuxSocket)
+	if err != nil {
+		return LaunchFailedMsg{Err: fmt.Errorf("initialize %s executor: %w", executorKind, err)}
 	}
 
-	if cfg.Epics == nil {
-		cfg.Epics = map[string]config.EpicState{}
+	sessionName := tmux.SessionName(filepath.Base(req.cwd), runID)
+	if err := runner.StartSession(sessionName); err != nil {
+		return LaunchFailedMsg{Err: fmt.Errorf("start session %s: %w", sessionName, err)}
 	}
-	if cfg.Roles == nil {
-		cfg.Roles = map[string]config.RoleState{}
+	// abort stops the new session and the roles already started in it, which
+	// the config does not record until the launch is saved.
+	var started []executor.Target
+	abort := func(err error) tea.Msg {
+		if stopErr := runner.StopSession(sessionName, started); stopErr != nil {
+			err = errors.Join(err, fmt.Errorf("stop session %s: %w", sessionName, stopErr))
+		}
+		return LaunchFailedMsg{Err: err}
 	}
 
-	target := strings.TrimSpace(req.target)
-	if target == "" {
-		target = filepath.Base(req.cwd)
+	defaultTarget := strings.TrimSpace(req.target)
+	if defaultTarget == "" {
+		defaultTarget = filepath.Base(req.cwd)
 	}
 
-	for _, epic := range plan.Epics {
+	type launchCandidate struct {
+		epic   teams.EpicBead
+		role   teams.RoleBead
+		target string
+	}
+	eligible := make([][]launchCandidate, len(plan.Epics))
+	for epicIdx, epic := range plan.Epics {
 		auditType := epic.AuditType
-		cfg.Epics[auditType.ID] = config.EpicState{
+		target := fallbackText(epic.Target, defaultTarget)
+		waiting := len(epic.DependsOn) > 0
+		epicStatus := "running"
+		if waiting {
+			epicStatus = "pending"
+		}
+		cfg.Epics[epic.BeadID] = config.EpicState{
 			BeadID:     epic.BeadID,
 			AuditType:  auditType.ID,
 			AuditName:  auditType.Name,
+			Target:     target,
+			FocusAreas: append([]string(nil), epic.FocusAreas...),
+			DependsOn:  append([]string(nil), epic.DependsOn...),
 			AgentCount: req.agentCount,
 			Intensity:  req.intensity,
-			Status:     "running",
+			Status:     epicStatus,
+			Retry:      launchRetryPolicy(req.retry, auditType),
+			Agent:      runtimes[auditType.ID].Name(),
 		}
 
-		for idx, role := range epic.RoleBeads {
-			roleState := config.RoleState{
-				BeadID:     role.BeadID,
-				EpicBeadID: epic.BeadID,
-				CodeName:   role.CodeName,
-				Title:      role.Title,
-				Guidance:   role.Guidance,
-				BeadPrefix: role.BeadPrefix,
-				Order:      role.Order,
-				Status:     "pending",
-				Intensity:  req.intensity,
+		roleDeps := teams.RoleDependencies(epic.RoleBeads)
+		for _, role := range epic.RoleBeads {
+			cfg.Roles[role.BeadID] = config.RoleState{
+				BeadID:      role.BeadID,
+				EpicBeadID:  epic.BeadID,
+				CodeName:    role.CodeName,
+				Title:       role.Title,
+				Guidance:    role.Guidance,
+				BeadPrefix:  role.BeadPrefix,
+				Order:       role.Order,
+				After:       roleDeps[role.CodeName],
+				Status:      "pending",
+				Intensity:   req.intensity,
+				AgentParams: role.AgentParams.Override(req.agentParams),
 			}
 
-			if idx == 0 {
-				roleDir, err := deps.generateRoleSession(teams.RoleSessionParams{
-					Cwd:          req.cwd,
-					EpicBeadID:   epic.BeadID,
-					RoleBeadID:   role.BeadID,
-					RoleTitle:    role.Title,
-					RoleGuidance: role.Guidance,
-					Intensity:    req.intensity,
-					BeadPrefix:   role.BeadPrefix,
-					Target:       target,
-					FocusAreas:   req.focusAreas,
-					AuditTypeID:  auditType.ID,
-					CodeName:     role.CodeName,
-				})
-				if err != nil {
-					return LaunchFailedMsg{Err: fmt.Errorf("generate role session for %s/%s: %w", auditType.ID, role.CodeName, err)}
-				}
-
-				windowName := "audit-" + auditType.ID + "-" + role.CodeName
-				if err := manager.CreateWindow(sessionName, windowName); err != nil {
-					return LaunchFailedMsg{Err: fmt.Errorf("create tmux window for %s/%s: %w", auditType.ID, role.CodeName, err)}
-				}
-
-				wslRoleDir, err := deps.translatePath(roleDir)
-				if err != nil {
-					return LaunchFailedMsg{Err: fmt.Errorf("translate role session path for %s/%s: %w", auditType.ID, role.CodeName, err)}
-				}
-
-				command := fmt.Sprintf("cd %s && opencode run auditor", shellQuote(wslRoleDir))
-				if err := manager.SendKeys(sessionName, windowName, command); err != nil {
-					return LaunchFailedMsg{Err: fmt.Errorf("launch auditor for %s/%s: %w", auditType.ID, role.CodeName, err)}
-				}
-
-				roleState.Status = "running"
-				roleState.TmuxWindow = fmt.Sprintf("%s:%s", sessionName, windowName)
+			if !waiting && len(roleDeps[role.CodeName]) == 0 {
+				eligible[epicIdx] = append(eligible[epicIdx], launchCandidate{epic: epic, role: role, target: target})
 			}
+		}
+	}
 
+	queue := roundRobin(eligible, make([]int, len(eligible)))
+	slots := admissionSlots(req.maxConcurrentRoles, 0, len(queue))
+	journal := []events.Event{{
+		Kind:    events.KindLaunch,
+		RunID:   runID,
+		Message: fmt.Sprintf("session %s with %d epics", sessionName, len(plan.Epics)),
+	}}
+	var launched []ScheduledRole
+	for idx, candidate := range queue {
+		epic, role := candidate.epic, candidate.role
+		roleState := cfg.Roles[role.BeadID]
+		if idx >= slots {
+			roleState.QueuePosition = idx - slots + 1
 			cfg.Roles[role.BeadID] = roleState
+			continue
 		}
+
+		roleDir, err := deps.generateRoleSession(teams.RoleSessionParams{
+			Cwd:          req.cwd,
+			RunID:        runID,
+			EpicBeadID:   epic.BeadID,
+			RoleBeadID:   role.BeadID,
+			RoleTitle:    role.Title,
+			RoleGuidance: role.Guidance,
+			Intensity:    req.intensity,
+			BeadPrefix:   role.BeadPrefix,
+			Target:       candidate.target,
+			FocusAreas:   epic.FocusAreas,
+			AuditTypeID:  epic.AuditType.ID,
+			CodeName:     role.CodeName,
+			Runtime:      runtimes[epic.AuditType.ID],
+			AgentParams:  roleState.AgentParams,
+		})
+		if err != nil {
+			return abort(fmt.Errorf("generate role session for %s/%s: %w", epic.BeadID, role.CodeName, err))
+		}
+
+		windowName := roleWindowName(epic.BeadID, role.CodeName)
+		_, rawLogPath := panelog.Paths(req.cwd, runID, windowName)
+		target := executor.Target{Session: sessionName, Name: windowName, LogPath: rawLogPath}
+		pid, err := runner.StartRole(target, roleDir)
+		if err != nil {
+			return abort(fmt.Errorf("start %s/%s: %w", epic.BeadID, role.CodeName, err))
+		}
+		target.PID = pid
+		started = append(started, target)
+
+		roleState.Status = "running"
+		roleState.TmuxWindow = fmt.Sprintf("%s:%s", sessionName, windowName)
+		roleState.PID = pid
+		roleState.Attempt = 1
+		roleState.Log = windowName
+		roleState.MarkLaunched(deps.now())
+		cfg.Roles[role.BeadID] = roleState
+		if epicState := cfg.Epics[epic.BeadID]; epicState.LaunchedAt == "" {
+			epicState.MarkLaunched(deps.now())
+			cfg.Epics[epic.BeadID] = epicState
+		}
+		journal = append(journal, events.Event{
+			Kind:    events.KindLaunch,
+			RunID:   runID,
+			EpicID:  epic.BeadID,
+			RoleID:  role.BeadID,
+			To:      roleState.Status,
+			Message: roleState.TmuxWindow,
+		})
+		launched = append(launched, ScheduledRole{
+			RoleBeadID: role.BeadID,
+			EpicBeadID: epic.BeadID,
+			AuditType:  epic.AuditType.ID,
+			CodeName:   role.CodeName,
+			WindowName: windowName,
+			SessionDir: roleDir,
+			LaunchedAt: deps.now(),
+			Attempt:    roleState.Attempt,
+		})
 	}
 
 	cfg.Session.Name = sessionName
+	cfg.Session.RunID = runID
 	cfg.Session.CreatedAt = deps.now().UTC().Format(time.RFC3339)
 	cfg.Session.WorkingDir = req.cwd
+	cfg.Session.MaxConcurrentRoles = req.maxConcurrentRoles
+	cfg.Session.Stall = req.stall
+	cfg.Session.Hooks = req.hooks
+	cfg.Session.Executor = executorKind
+	cfg.Session.TmuxSocket = tmuxSocket
+	cfg.Session.Agents = launchAgentDefinitions(req.agentRuntimes, runtimes)
+	cfg.Session.CompletedAt = ""
 
 	if err := cfg.Save(); err != nil {
-		return LaunchFailedMsg{Err: fmt.Errorf("save launch config: %w", err)}
+		return abort(fmt.Errorf("save launch config: %w", err))
+	}
+	if err := recordEvents(deps.recordEvents, req.cwd, journal...); err != nil {
+		return LaunchFailedMsg{Err: fmt.Errorf("record launch events: %w", err)}
+	}
+
+	// Hook failures are journaled by runTransitionHooks; they do not undo a
+	// launch that already started.
+	hookEvents, _ := runTransitionHooks(req.cwd, cfg, SchedulerResult{Launched: launched}, deps.runHook, deps.now())
+	if err := recordEvents(deps.recordEvents, req.cwd, hookEvents...); err != nil {
+		return LaunchFailedMsg{Err: fmt.Errorf("record hook events: %w", err)}
 	}
 
 	return LaunchCompleteMsg{}
 }
 
-func shellQuote(value string) string {
-	return "'" + strings.ReplaceAll(value, "'", "'\"'\"'") + "'"
+// liveRoles returns the sorted keys of the roles still running or stalled in
+// the active run.
+func liveRoles(cfg *config.Config) []string {
+	var live []string
+	for roleKey, role := range cfg.Roles {
+		switch normalizeRoleStatus(role.Status) {
+		case "running", "stalled":
+			live = append(live, roleKey)
+		}
+	}
+	sort.Strings(live)
+
+	return live
+}
+
+// launchEpicSpecs returns the epics to plan for a launch request. Audit types
+// without explicit epics share the request focus areas and default target.
+func launchEpicSpecs(req launchRequest) []teams.EpicSpec {
+	specs := append([]teams.EpicSpec(nil), req.epics...)
+	if len(specs) == 0 {
+		for _, auditType := range req.auditTypes {
+			specs = append(specs, teams.EpicSpec{
+				AuditType:  auditType,
+				FocusAreas: append([]string(nil), req.focusAreas...),
+			})
+		}
+	}
+
+	for idx := range specs {
+		if dependsOn := req.dependsOn[specs[idx].AuditType.ID]; len(dependsOn) > 0 {
+			specs[idx].DependsOn = append([]string(nil), dependsOn...)
+		}
+	}
+
+	return specs
+}
+
+// launchRetryPolicy returns the run's retry policy when it sets one and the
+// audit type's policy otherwise.
+func launchRetryPolicy(runPolicy config.RetryPolicy, auditType teams.AuditType) config.RetryPolicy {
+	if runPolicy.MaxAttempts > 0 {
+		return runPolicy
+	}
+
+	return auditType.Retry
+}
+
+// launchAgentRuntimes resolves the runtime of every audit type in the request:
+// the run's choice, then the audit type's, then the user default.
+func launchAgentRuntimes(req launchRequest) (map[string]agent.Runtime, error) {
+	runtimes := map[string]agent.Runtime{}
+	for _, spec := range launchEpicSpecs(req) {
+		auditType := spec.AuditType
+		runtime, err := agent.Resolve(fallbackText(req.agent, fallbackText(auditType.Agent, req.defaultAgent)), req.agentRuntimes)
+		if err != nil {
+			return nil, fmt.Errorf("audit type %s: %w", auditType.ID, err)
+		}
+		runtimes[auditType.ID] = runtime
+	}
+
+	return runtimes, nil
+}
+
+// launchAgentDefinitions returns the custom runtime definitions the run's
+// epics use, or nil when they all run opencode.
+func launchAgentDefinitions(definitions map[string]config.AgentRuntime, runtimes map[string]agent.Runtime) map[string]config.AgentRuntime {
+	var used map[string]config.AgentRuntime
+	for _, runtime := range runtimes {
+		definition, ok := definitions[runtime.Name()]
+		if !ok {
+			continue
+		}
+		if used == nil {
+			used = map[string]config.AgentRuntime{}
+		}
+		used[runtime.Name()] = definition
+	}
+
+	return used
 }
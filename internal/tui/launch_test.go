@@ -2,12 +2,17 @@ package tui
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"lattice/internal/config"
+	"lattice/internal/events"
+	"lattice/internal/executor"
+	"lattice/internal/hooks"
+	"lattice/internal/panelog"
 	"lattice/internal/teams"
 )
 
@@ -15,6 +20,8 @@ type fakeLaunchTmuxManager struct {
 	sessionNames []string
 	windowCalls  []string
 	keyCalls     []string
+	remainCalls  []string
+	pipeCalls    []string
 
 	createSessionErr error
 }
@@ -34,10 +41,89 @@ func (m *fakeLaunchTmuxManager) SendKeys(session, window, command string) error
 	return nil
 }
 
+func (m *fakeLaunchTmuxManager) SetRemainOnExit(session, window string) error {
+	m.remainCalls = append(m.remainCalls, fmt.Sprintf("%s:%s", session, window))
+	return nil
+}
+
+func (m *fakeLaunchTmuxManager) PipePane(session, window, command string) error {
+	m.pipeCalls = append(m.pipeCalls, fmt.Sprintf("%s:%s:%s", session, window, command))
+	return nil
+}
+
+// fakeExecutor starts roles in a fake tmux manager and answers the scheduler
+// from its function fields; a nil alive reports every window gone.
+type fakeExecutor struct {
+	manager     *fakeLaunchTmuxManager
+	alive       func(sessionName, windowName string) bool
+	exitStatus  func(sessionName, windowName string) (int, bool)
+	output      func(sessionName, windowName string) (string, bool)
+	stop        func(sessionName, windowName string) error
+	interrupt   func(sessionName, windowName string) error
+	stopSession func(name string) error
+}
+
+func (e *fakeExecutor) tmux() *executor.Tmux {
+	if e.manager == nil {
+		e.manager = &fakeLaunchTmuxManager{}
+	}
+	return executor.NewTmux(e.manager, func(path string) (string, error) { return path, nil })
+}
+
+func (e *fakeExecutor) Kind() string { return config.ExecutorTmux }
+
+func (e *fakeExecutor) StartSession(session string) error { return e.tmux().StartSession(session) }
+
+func (e *fakeExecutor) StartRole(target executor.Target, dir string) (int, error) {
+	return e.tmux().StartRole(target, dir)
+}
+
+func (e *fakeExecutor) Alive(target executor.Target) bool {
+	return e.alive != nil && e.alive(target.Session, target.Name)
+}
+
+func (e *fakeExecutor) ExitStatus(target executor.Target) (int, bool) {
+	if e.exitStatus == nil {
+		return 0, false
+	}
+	return e.exitStatus(target.Session, target.Name)
+}
+
+func (e *fakeExecutor) Output(target executor.Target) (string, bool) {
+	if e.output == nil {
+		return "", false
+	}
+	return e.output(target.Session, target.Name)
+}
+
+func (e *fakeExecutor) Stop(target executor.Target) error {
+	if e.stop == nil {
+		return nil
+	}
+	return e.stop(target.Session, target.Name)
+}
+
+func (e *fakeExecutor) Interrupt(target executor.Target) error {
+	if e.interrupt == nil {
+		return nil
+	}
+	return e.interrupt(target.Session, target.Name)
+}
+
+func (e *fakeExecutor) StopSession(name string, _ []executor.Target) error {
+	if e.stopSession == nil {
+		return nil
+	}
+	return e.stopSession(name)
+}
+
 func TestLaunchAuditOrchestratesSessionAndTeams(t *testing.T) {
 	t.Parallel()
 
-	workDir := t.TempDir()
+	workDir := filepath.Join(t.TempDir(), "acme.app")
+	if err := os.Mkdir(workDir, 0o755); err != nil {
+		t.Fatalf("Mkdir() returned error: %v", err)
+	}
 	existingCfg, err := config.Init(workDir)
 	if err != nil {
 		t.Fatalf("Init() returned error: %v", err)
@@ -72,12 +158,18 @@ func TestLaunchAuditOrchestratesSessionAndTeams(t *testing.T) {
 	}
 
 	var roleSessionCalls []teams.RoleSessionParams
+	var tmuxSocket string
 	var planStartCounter int
+	var planSpecs []teams.EpicSpec
 
 	deps := launchDeps{
-		initConfig:     config.Init,
-		newTmuxManager: func() (launchTmuxManager, error) { return fakeManager, nil },
-		buildAuditPlan: func(_ []teams.AuditType, _ int, _ int, startCounter int) (*teams.AuditPlan, error) {
+		initConfig: config.Init,
+		newExecutor: func(_ string, socket string) (executor.Executor, error) {
+			tmuxSocket = socket
+			return &fakeExecutor{manager: fakeManager}, nil
+		},
+		buildAuditPlan: func(specs []teams.EpicSpec, _ int, _ int, startCounter int) (*teams.AuditPlan, error) {
+			planSpecs = specs
 			planStartCounter = startCounter
 			return plan, nil
 		},
@@ -85,8 +177,7 @@ func TestLaunchAuditOrchestratesSessionAndTeams(t *testing.T) {
 			roleSessionCalls = append(roleSessionCalls, params)
 			return filepath.Join(params.Cwd, config.DirName, "teams", params.AuditTypeID+"-"+params.CodeName), nil
 		},
-		translatePath: func(path string) (string, error) { return path, nil },
-		now:           func() time.Time { return fixedNow },
+		now: func() time.Time { return fixedNow },
 	}
 
 	req := launchRequest{
@@ -96,6 +187,7 @@ func TestLaunchAuditOrchestratesSessionAndTeams(t *testing.T) {
 		agentCount: 2,
 		intensity:  3,
 		focusAreas: []string{"hot path", "heap growth"},
+		tmuxSocket: "lattice",
 	}
 
 	msg := launchAudit(req, deps)
@@ -105,27 +197,37 @@ func TestLaunchAuditOrchestratesSessionAndTeams(t *testing.T) {
 	if planStartCounter != 41 {
 		t.Fatalf("expected plan start counter 41, got %d", planStartCounter)
 	}
+	if len(planSpecs) != 2 || planSpecs[1].AuditType.ID != teams.AuditTypes[1].ID || len(planSpecs[1].FocusAreas) != 2 {
+		t.Fatalf("expected one epic spec per audit type with focus areas, got %+v", planSpecs)
+	}
 
-	if len(fakeManager.sessionNames) != 1 || fakeManager.sessionNames[0] != "lattice-20260211-143201" {
+	if len(fakeManager.sessionNames) != 1 || fakeManager.sessionNames[0] != "lattice-acme-app-20260211-143201" {
 		t.Fatalf("unexpected session names: %#v", fakeManager.sessionNames)
 	}
 
 	if len(fakeManager.windowCalls) != 2 {
 		t.Fatalf("expected 2 window calls, got %d", len(fakeManager.windowCalls))
 	}
-	if fakeManager.windowCalls[0] != "lattice-20260211-143201:audit-perf-alpha" {
+	if fakeManager.windowCalls[0] != "lattice-acme-app-20260211-143201:audit-plan-042-alpha" {
 		t.Fatalf("unexpected first window call: %q", fakeManager.windowCalls[0])
 	}
-	if fakeManager.windowCalls[1] != "lattice-20260211-143201:audit-memleak-alpha" {
+	if fakeManager.windowCalls[1] != "lattice-acme-app-20260211-143201:audit-plan-045-alpha" {
 		t.Fatalf("unexpected second window call: %q", fakeManager.windowCalls[1])
 	}
 
 	if len(fakeManager.keyCalls) != 2 {
 		t.Fatalf("expected 2 send-keys calls, got %d", len(fakeManager.keyCalls))
 	}
-	if !strings.Contains(fakeManager.keyCalls[0], "cd '") || !strings.Contains(fakeManager.keyCalls[0], "&& opencode run auditor") {
+	if !strings.Contains(fakeManager.keyCalls[0], "cd '") || !strings.Contains(fakeManager.keyCalls[0], "&& exec sh ./run-agent.sh") {
 		t.Fatalf("unexpected first send-keys command: %q", fakeManager.keyCalls[0])
 	}
+	if len(fakeManager.remainCalls) != 2 || fakeManager.remainCalls[0] != fakeManager.windowCalls[0] {
+		t.Fatalf("expected remain-on-exit for each launched window, got %#v", fakeManager.remainCalls)
+	}
+	_, rawLogPath := panelog.Paths(workDir, "20260211-143201", "audit-plan-042-alpha")
+	if len(fakeManager.pipeCalls) != 2 || fakeManager.pipeCalls[0] != fakeManager.windowCalls[0]+":"+panelog.PipeCommand(rawLogPath) {
+		t.Fatalf("expected each launched window to stream to its raw log, got %#v", fakeManager.pipeCalls)
+	}
 	if strings.Contains(strings.Join(fakeManager.keyCalls, "\n"), "perf-bravo") || strings.Contains(strings.Join(fakeManager.keyCalls, "\n"), "mem-bravo") {
 		t.Fatalf("pending roles should not have send-keys calls: %#v", fakeManager.keyCalls)
 	}
@@ -139,26 +241,48 @@ func TestLaunchAuditOrchestratesSessionAndTeams(t *testing.T) {
 		t.Fatalf("Load() returned error: %v", err)
 	}
 
-	if cfg.Session.Name != "lattice-20260211-143201" {
+	if cfg.Session.Name != "lattice-acme-app-20260211-143201" {
 		t.Fatalf("unexpected session name in config: %q", cfg.Session.Name)
 	}
+	if cfg.Session.RunID != "20260211-143201" {
+		t.Fatalf("unexpected run id in config: %q", cfg.Session.RunID)
+	}
+	if cfg.Session.Executor != config.ExecutorTmux {
+		t.Fatalf("expected the tmux executor by default, got %q", cfg.Session.Executor)
+	}
+	if tmuxSocket != "lattice" || cfg.Session.TmuxSocket != "lattice" {
+		t.Fatalf("expected the run on the lattice tmux server, got executor socket %q and config %q", tmuxSocket, cfg.Session.TmuxSocket)
+	}
+	if roleSessionCalls[0].RunID != cfg.Session.RunID {
+		t.Fatalf("expected role sessions generated under run %q, got %q", cfg.Session.RunID, roleSessionCalls[0].RunID)
+	}
+	snapshot, err := config.LoadRun(workDir, cfg.Session.RunID)
+	if err != nil {
+		t.Fatalf("LoadRun() returned error: %v", err)
+	}
+	if len(snapshot.Roles) != 4 {
+		t.Fatalf("expected run snapshot with 4 roles, got %d", len(snapshot.Roles))
+	}
 	if cfg.BeadCounter != 47 {
 		t.Fatalf("expected BeadCounter=47, got %d", cfg.BeadCounter)
 	}
 	if len(cfg.Epics) != 2 {
 		t.Fatalf("expected 2 epics in config, got %d", len(cfg.Epics))
 	}
-	if got := cfg.Epics["perf"].BeadID; got != "audit-plan-042" {
-		t.Fatalf("unexpected perf epic bead id: %q", got)
+	if got := cfg.Epics["audit-plan-042"].AuditType; got != "perf" {
+		t.Fatalf("unexpected audit type for epic audit-plan-042: %q", got)
+	}
+	if got := cfg.Epics["audit-plan-045"].AuditType; got != "memleak" {
+		t.Fatalf("unexpected audit type for epic audit-plan-045: %q", got)
 	}
-	if got := cfg.Epics["memleak"].BeadID; got != "audit-plan-045" {
-		t.Fatalf("unexpected memleak epic bead id: %q", got)
+	if got := cfg.Epics["audit-plan-042"].Target; got != "acme-app" {
+		t.Fatalf("expected default target on epic, got %q", got)
 	}
 
 	if len(cfg.Roles) != 4 {
 		t.Fatalf("expected 4 roles in config, got %d", len(cfg.Roles))
 	}
-	if role := cfg.Roles["audit-plan-043"]; role.Status != "running" || role.TmuxWindow == "" {
+	if role := cfg.Roles["audit-plan-043"]; role.Status != "running" || role.TmuxWindow == "" || role.Log != "audit-plan-042-alpha" {
 		t.Fatalf("expected first perf role running with tmux window, got %+v", role)
 	}
 	if role := cfg.Roles["audit-plan-046"]; role.Status != "running" || role.TmuxWindow == "" {
@@ -170,6 +294,14 @@ func TestLaunchAuditOrchestratesSessionAndTeams(t *testing.T) {
 	if role := cfg.Roles["audit-plan-047"]; role.Status != "pending" || role.TmuxWindow != "" {
 		t.Fatalf("expected second mem role pending with no tmux window, got %+v", role)
 	}
+
+	journal, err := events.Read(workDir, events.Filter{RunID: cfg.Session.RunID})
+	if err != nil {
+		t.Fatalf("events.Read() returned error: %v", err)
+	}
+	if len(journal) != 3 || journal[0].RoleID != "" || journal[1].RoleID != "audit-plan-043" || journal[2].RoleID != "audit-plan-046" {
+		t.Fatalf("expected run and launched role events, got %+v", journal)
+	}
 }
 
 func TestLaunchAuditReturnsFailedMessageWhenSessionCreationFails(t *testing.T) {
@@ -179,15 +311,15 @@ func TestLaunchAuditReturnsFailedMessageWhenSessionCreationFails(t *testing.T) {
 
 	deps := launchDeps{
 		initConfig:          config.Init,
-		newTmuxManager:      func() (launchTmuxManager, error) { return fakeManager, nil },
+		newExecutor:         func(string, string) (executor.Executor, error) { return &fakeExecutor{manager: fakeManager}, nil },
 		generateRoleSession: teams.GenerateRoleSession,
-		buildAuditPlan:      teams.BuildAuditPlan,
-		translatePath:       func(path string) (string, error) { return path, nil },
+		buildAuditPlan:      teams.BuildEpicPlan,
 		now:                 time.Now,
 	}
 
+	workDir := t.TempDir()
 	msg := launchAudit(launchRequest{
-		cwd:        t.TempDir(),
+		cwd:        workDir,
 		auditTypes: []teams.AuditType{teams.AuditTypes[0]},
 		agentCount: 1,
 		intensity:  1,
@@ -200,4 +332,232 @@ func TestLaunchAuditReturnsFailedMessageWhenSessionCreationFails(t *testing.T) {
 	if !strings.Contains(failed.Err.Error(), "create tmux session") {
 		t.Fatalf("unexpected error: %v", failed.Err)
 	}
+
+	journal, err := events.Read(workDir, events.Filter{})
+	if err != nil {
+		t.Fatalf("events.Read() returned error: %v", err)
+	}
+	if len(journal) != 1 || journal[0].Kind != events.KindError || !strings.Contains(journal[0].Message, "boom") {
+		t.Fatalf("expected the launch failure to be journaled, got %+v", journal)
+	}
+}
+
+func TestLaunchAuditRunsRoleLaunchHooksForFirstBatch(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	plan := &teams.AuditPlan{
+		Epics: []teams.EpicBead{{
+			BeadID:    "e1",
+			AuditType: teams.AuditTypes[0],
+			RoleBeads: []teams.RoleBead{
+				{BeadID: "r1", CodeName: "alpha", Title: "Alpha", BeadPrefix: "perf-alpha", Order: 1},
+				{BeadID: "r2", CodeName: "bravo", Title: "Bravo", BeadPrefix: "perf-bravo", Order: 2},
+			},
+		}},
+	}
+
+	var payloads []hooks.Payload
+	deps := launchDeps{
+		initConfig:     config.Init,
+		newExecutor:    func(string, string) (executor.Executor, error) { return &fakeExecutor{}, nil },
+		buildAuditPlan: func([]teams.EpicSpec, int, int, int) (*teams.AuditPlan, error) { return plan, nil },
+		generateRoleSession: func(params teams.RoleSessionParams) (string, error) {
+			return filepath.Join(params.Cwd, "teams", params.CodeName), nil
+		},
+		runHook: func(command string, payload hooks.Payload) error {
+			payloads = append(payloads, payload)
+			return nil
+		},
+		now: func() time.Time { return time.Date(2026, time.February, 11, 14, 32, 1, 0, time.UTC) },
+	}
+
+	msg := launchAudit(launchRequest{
+		cwd:        workDir,
+		auditTypes: []teams.AuditType{teams.AuditTypes[0]},
+		agentCount: 2,
+		intensity:  1,
+		hooks:      config.HookConfig{OnRoleLaunch: []string{"./launched.sh"}},
+	}, deps)
+	if _, ok := msg.(LaunchCompleteMsg); !ok {
+		t.Fatalf("expected LaunchCompleteMsg, got %#v", msg)
+	}
+
+	if len(payloads) != 1 {
+		t.Fatalf("expected one on_role_launch hook for the admitted role, got %+v", payloads)
+	}
+	launch := payloads[0]
+	if launch.Event != config.HookRoleLaunch || launch.Role == nil || launch.Role.BeadID != "r1" || launch.Role.Status != "running" || launch.Role.SessionDir != filepath.Join(workDir, "teams", "alpha") {
+		t.Fatalf("unexpected launch payload: %+v %+v", launch, launch.Role)
+	}
+	if launch.RunID != "20260211-143201" || launch.Epic == nil || launch.Epic.BeadID != "e1" {
+		t.Fatalf("expected payload for the new run and epic, got %+v", launch)
+	}
+
+	journal, err := events.Read(workDir, events.Filter{})
+	if err != nil {
+		t.Fatalf("events.Read() returned error: %v", err)
+	}
+	if len(journal) != 3 || journal[2].Kind != events.KindHook || journal[2].Action != config.HookRoleLaunch || journal[2].RoleID != "r1" {
+		t.Fatalf("expected the hook run to be journaled, got %+v", journal)
+	}
+}
+
+func TestLaunchAuditStopsStartedRolesWhenALaterRoleFails(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	plan := &teams.AuditPlan{
+		Epics: []teams.EpicBead{
+			{BeadID: "e1", AuditType: teams.AuditTypes[0], RoleBeads: []teams.RoleBead{{BeadID: "r1", CodeName: "alpha", BeadPrefix: "perf-alpha", Order: 1}}},
+			{BeadID: "e2", AuditType: teams.AuditTypes[1], RoleBeads: []teams.RoleBead{{BeadID: "r2", CodeName: "alpha", BeadPrefix: "mem-alpha", Order: 1}}},
+		},
+	}
+
+	manager := &fakeLaunchTmuxManager{}
+	var stoppedSessions []string
+	deps := launchDeps{
+		initConfig: config.Init,
+		newExecutor: func(string, string) (executor.Executor, error) {
+			return &fakeExecutor{manager: manager, stopSession: func(name string) error {
+				stoppedSessions = append(stoppedSessions, name)
+				return nil
+			}}, nil
+		},
+		buildAuditPlan: func([]teams.EpicSpec, int, int, int) (*teams.AuditPlan, error) { return plan, nil },
+		generateRoleSession: func(params teams.RoleSessionParams) (string, error) {
+			if params.EpicBeadID == "e2" {
+				return "", fmt.Errorf("disk full")
+			}
+			return filepath.Join(params.Cwd, "teams", params.CodeName), nil
+		},
+		now: func() time.Time { return time.Date(2026, time.February, 11, 14, 32, 1, 0, time.UTC) },
+	}
+
+	msg := launchAudit(launchRequest{
+		cwd:        workDir,
+		auditTypes: []teams.AuditType{teams.AuditTypes[0], teams.AuditTypes[1]},
+		agentCount: 1,
+		intensity:  1,
+	}, deps)
+	failed, ok := msg.(LaunchFailedMsg)
+	if !ok || !strings.Contains(failed.Err.Error(), "disk full") {
+		t.Fatalf("expected LaunchFailedMsg for the second role, got %#v", msg)
+	}
+
+	if len(manager.windowCalls) != 1 {
+		t.Fatalf("expected the first role to start before the failure, got %#v", manager.windowCalls)
+	}
+	if len(stoppedSessions) != 1 || stoppedSessions[0] != manager.sessionNames[0] {
+		t.Fatalf("expected the new session %v to be stopped, got %v", manager.sessionNames, stoppedSessions)
+	}
+}
+
+func TestLaunchAuditRefusesWhileActiveRunHasRunningRoles(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.Name = "lattice-20260210-010203"
+	cfg.Session.RunID = "20260210-010203"
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", Status: "running"}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Status: "complete"}
+	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Status: "stalled"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	manager := &fakeLaunchTmuxManager{}
+	deps := launchDeps{
+		initConfig:          config.Init,
+		newExecutor:         func(string, string) (executor.Executor, error) { return &fakeExecutor{manager: manager}, nil },
+		generateRoleSession: teams.GenerateRoleSession,
+		buildAuditPlan:      teams.BuildEpicPlan,
+		now:                 time.Now,
+	}
+
+	msg := launchAudit(launchRequest{
+		cwd:        workDir,
+		auditTypes: []teams.AuditType{teams.AuditTypes[0]},
+		agentCount: 1,
+		intensity:  1,
+	}, deps)
+	failed, ok := msg.(LaunchFailedMsg)
+	if !ok || !strings.Contains(failed.Err.Error(), "still has running roles (r2)") {
+		t.Fatalf("expected launch to be refused, got %#v", msg)
+	}
+	if len(manager.sessionNames) != 0 {
+		t.Fatalf("expected no session to start, got %v", manager.sessionNames)
+	}
+
+	saved, err := config.Load(workDir)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if saved.Session.RunID != "20260210-010203" || saved.Roles["r2"].Status != "stalled" {
+		t.Fatalf("expected the active run to stay in place, got %+v", saved.Session)
+	}
+}
+
+func TestLaunchRetryPolicyPrefersRunPolicy(t *testing.T) {
+	t.Parallel()
+
+	auditType := teams.AuditType{ID: "perf", Retry: config.RetryPolicy{MaxAttempts: 3, Backoff: "5m"}}
+	if got := launchRetryPolicy(config.RetryPolicy{}, auditType); got != auditType.Retry {
+		t.Fatalf("expected audit type policy without a run policy, got %+v", got)
+	}
+
+	runPolicy := config.RetryPolicy{MaxAttempts: 1}
+	if got := launchRetryPolicy(runPolicy, auditType); got != runPolicy {
+		t.Fatalf("expected run policy to override the audit type, got %+v", got)
+	}
+}
+
+func TestLaunchAgentRuntimesPreferRunThenAuditTypeThenDefault(t *testing.T) {
+	t.Parallel()
+
+	runtimes := map[string]config.AgentRuntime{
+		"claude": {Command: "claude -p {{.Prompt}}"},
+		"codex":  {Command: "codex exec {{.Prompt}}"},
+	}
+	auditTypes := []teams.AuditType{{ID: "perf", Agent: "codex"}, {ID: "security"}}
+
+	tests := []struct {
+		name         string
+		agent        string
+		defaultAgent string
+		want         map[string]string
+		wantErr      string
+	}{
+		{name: "built-in default", want: map[string]string{"perf": "codex", "security": "opencode"}},
+		{name: "user default", defaultAgent: "claude", want: map[string]string{"perf": "codex", "security": "claude"}},
+		{name: "run choice", agent: "opencode", defaultAgent: "claude", want: map[string]string{"perf": "opencode", "security": "opencode"}},
+		{name: "unknown", agent: "aider", wantErr: `audit type perf: unknown agent runtime "aider"`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := launchAgentRuntimes(launchRequest{auditTypes: auditTypes, agent: tt.agent, defaultAgent: tt.defaultAgent, agentRuntimes: runtimes})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("launchAgentRuntimes() returned error: %v", err)
+			}
+			for auditTypeID, want := range tt.want {
+				if got[auditTypeID].Name() != want {
+					t.Fatalf("expected %s to run %s, got %s", auditTypeID, want, got[auditTypeID].Name())
+				}
+			}
+		})
+	}
 }
@@ -14,6 +14,7 @@ type MenuAction int
 const (
 	MenuActionNone MenuAction = iota
 	MenuActionOpenAuditWizard
+	MenuActionOpenHistory
 	MenuActionQuit
 )
 
@@ -44,6 +45,11 @@ func NewMenuModel() MenuModel {
 				description: "Start the audit wizard",
 				action:      MenuActionOpenAuditWizard,
 			},
+			{
+				label:       "History",
+				description: "Browse previous runs and their reports",
+				action:      MenuActionOpenHistory,
+			},
 			{
 				label:       "Quit",
 				description: "Exit LATTICE",
@@ -28,13 +28,13 @@ func TestMenuNavigationWrapsAndSelectQuit(t *testing.T) {
 	model := NewMenuModel()
 	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyUp})
 
-	if got := model.Cursor(); got != 1 {
+	if got := model.Cursor(); got != 2 {
 		t.Fatalf("expected cursor to wrap to last menu item, got %d", got)
 	}
 
 	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
 	if got := model.Action(); got != MenuActionQuit {
-		t.Fatalf("expected quit action from second menu item, got %v", got)
+		t.Fatalf("expected quit action from last menu item, got %v", got)
 	}
 }
 
@@ -43,7 +43,7 @@ func TestMenuViewShowsBrandingAndOptions(t *testing.T) {
 
 	view := NewMenuModel().View()
 
-	for _, fragment := range []string{"LATTICE", "Main Menu", "Audit", "Quit"} {
+	for _, fragment := range []string{"LATTICE", "Main Menu", "Audit", "History", "Quit"} {
 		if !strings.Contains(view, fragment) {
 			t.Fatalf("expected view to include %q", fragment)
 		}
@@ -0,0 +1,282 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"lattice/internal/config"
+	"lattice/internal/events"
+	"lattice/internal/executor"
+)
+
+// RunAction is a manual control applied to an epic or role of the active run.
+type RunAction string
+
+const (
+	// RunActionPause keeps the scheduler from launching pending roles.
+	RunActionPause RunAction = "pause"
+	// RunActionResume lifts a pause and requeues failed and cancelled roles.
+	RunActionResume RunAction = "resume"
+	// RunActionCancel interrupts running roles and marks unfinished roles cancelled.
+	RunActionCancel RunAction = "cancel"
+	// RunActionSkip marks unfinished roles skipped so the roles after them can start.
+	RunActionSkip RunAction = "skip"
+	// RunActionRerun puts a finished role back to pending for another attempt.
+	RunActionRerun RunAction = "rerun"
+)
+
+// RunActionTarget selects an epic, or one of its roles when RoleBeadID is set.
+type RunActionTarget struct {
+	EpicBeadID string
+	RoleBeadID string
+}
+
+// RunActionResult summarizes one applied action.
+type RunActionResult struct {
+	// Changed lists the bead IDs of the epic or roles the action changed.
+	Changed []string
+	// InterruptErr is set when a running role could not be interrupted,
+	// usually because it already exited.
+	InterruptErr error
+}
+
+// ApplyRunAction applies action to target in the active run, saves the config
+// and journals the action.
+// Running roles that are cancelled or skipped are interrupted first.
+func ApplyRunAction(cwd string, target RunActionTarget, action RunAction) (RunActionResult, error) {
+	return applyRunAction(cwd, target, action, defaultControlDeps())
+}
+
+func applyRunAction(cwd string, target RunActionTarget, action RunAction, deps controlDeps) (RunActionResult, error) {
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		return RunActionResult{}, fmt.Errorf("load lattice config: %w", err)
+	}
+
+	entry := events.Event{
+		Kind:   events.KindAction,
+		Source: "dashboard",
+		RunID:  cfg.Session.RunID,
+		EpicID: strings.TrimSpace(target.EpicBeadID),
+		RoleID: strings.TrimSpace(target.RoleBeadID),
+		Action: string(action),
+	}
+	changed, interrupts, err := applyRunActionToConfig(cfg, target, action, deps.now())
+	if err != nil {
+		entry.Kind, entry.Message = events.KindError, err.Error()
+		// The action error is what the user needs to see; a journal failure on
+		// top of it is dropped.
+		_ = recordEvents(deps.recordEvents, cwd, entry)
+		return RunActionResult{}, err
+	}
+
+	result := RunActionResult{Changed: changed}
+	if len(interrupts) > 0 {
+		result.InterruptErr = interruptRoles(cwd, cfg, interrupts, deps.newExecutor)
+		for _, roleKey := range changed {
+			if err := writeRoleLog(cwd, cfg.Session.RunID, cfg.Roles[roleKey]); err != nil {
+				return RunActionResult{}, fmt.Errorf("write transcript for %s: %w", roleKey, err)
+			}
+		}
+	}
+
+	if err := cfg.Save(); err != nil {
+		return RunActionResult{}, fmt.Errorf("save lattice config: %w", err)
+	}
+
+	entry.Message = "changed " + strings.Join(changed, ", ")
+	if result.InterruptErr != nil {
+		entry.Message += "; interrupt: " + result.InterruptErr.Error()
+	}
+	if err := recordEvents(deps.recordEvents, cwd, entry); err != nil {
+		return result, fmt.Errorf("record %s action: %w", action, err)
+	}
+
+	return result, nil
+}
+
+// applyRunActionToConfig updates cfg for action and returns the changed bead IDs
+// and the running roles that must be interrupted.
+func applyRunActionToConfig(cfg *config.Config, target RunActionTarget, action RunAction, now time.Time) ([]string, []string, error) {
+	epicKey := strings.TrimSpace(target.EpicBeadID)
+	epic, ok := cfg.Epics[epicKey]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown epic %q", target.EpicBeadID)
+	}
+
+	if roleKey := strings.TrimSpace(target.RoleBeadID); roleKey != "" {
+		state, ok := cfg.Roles[roleKey]
+		if !ok || state.EpicBeadID != epicKey {
+			return nil, nil, fmt.Errorf("unknown role %q in epic %s", target.RoleBeadID, epicKey)
+		}
+
+		interrupt, err := applyRoleAction(&state, roleKey, action, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg.Roles[roleKey] = state
+
+		var interrupts []string
+		if interrupt {
+			interrupts = append(interrupts, roleKey)
+		}
+		return []string{roleKey}, interrupts, nil
+	}
+
+	var changed, interrupts []string
+	switch action {
+	case RunActionPause:
+		epic.Paused = true
+		changed = append(changed, epicKey)
+	case RunActionResume:
+		epic.Paused = false
+		changed = append(changed, epicKey)
+	case RunActionCancel, RunActionSkip:
+		epic.Paused = false
+	default:
+		return nil, nil, fmt.Errorf("%s applies to a single role, not epic %s", action, epicKey)
+	}
+	cfg.Epics[epicKey] = epic
+
+	for _, roleKey := range epicRoleKeys(cfg, epicKey) {
+		state := cfg.Roles[roleKey]
+		status := normalizeRoleStatus(state.Status)
+		switch action {
+		case RunActionPause:
+			continue
+		case RunActionResume:
+			if !state.Paused && status != "failed" && status != "cancelled" {
+				continue
+			}
+		case RunActionCancel, RunActionSkip:
+			if roleStatusTerminal(status) {
+				continue
+			}
+		}
+
+		interrupt, err := applyRoleAction(&state, roleKey, action, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg.Roles[roleKey] = state
+		changed = append(changed, roleKey)
+		if interrupt {
+			interrupts = append(interrupts, roleKey)
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil, fmt.Errorf("epic %s has no unfinished roles to %s", epicKey, action)
+	}
+
+	return changed, interrupts, nil
+}
+
+// applyRoleAction updates one role for action and reports whether the role was
+// running and must be interrupted.
+func applyRoleAction(state *config.RoleState, roleKey string, action RunAction, now time.Time) (bool, error) {
+	status := normalizeRoleStatus(state.Status)
+	switch action {
+	case RunActionPause:
+		if status != "pending" {
+			return false, fmt.Errorf("role %s is %s; only pending roles can be paused", roleKey, status)
+		}
+		state.Paused = true
+		state.QueuePosition = 0
+		return false, nil
+
+	case RunActionResume:
+		state.Paused = false
+		if status == "failed" || status == "cancelled" {
+			requeueRole(state, now)
+		}
+		return false, nil
+
+	case RunActionCancel, RunActionSkip:
+		if roleStatusTerminal(status) {
+			return false, fmt.Errorf("role %s is already %s", roleKey, status)
+		}
+
+		interrupt := status == "running" || status == "stalled"
+		if interrupt {
+			if state.ExitedAt == "" {
+				state.ExitedAt = now.UTC().Format(time.RFC3339)
+			}
+			state.MarkStopped(now)
+		}
+		state.Status = "cancelled"
+		if action == RunActionSkip {
+			state.Status = "skipped"
+		}
+		state.Paused = false
+		state.QueuePosition = 0
+		state.RetryAt = ""
+		return interrupt, nil
+
+	case RunActionRerun:
+		if !roleStatusTerminal(status) {
+			return false, fmt.Errorf("role %s is %s; only finished roles can be re-run", roleKey, status)
+		}
+		requeueRole(state, now)
+		return false, nil
+
+	default:
+		return false, fmt.Errorf("unknown run action %q", action)
+	}
+}
+
+// requeueRole puts a finished role back to pending. A role that ran before has
+// its last launch recorded as an attempt so the relaunch archives its session
+// directory instead of overwriting it.
+func requeueRole(state *config.RoleState, now time.Time) {
+	if state.Attempt > 0 {
+		state.Attempts = append(state.Attempts, config.RoleAttempt{
+			Attempt:    state.Attempt,
+			TmuxWindow: state.TmuxWindow,
+			ExitCode:   state.ExitCode,
+			ExitedAt:   state.ExitedAt,
+			Reason:     "rerun",
+		})
+		state.RetryAt = now.UTC().Format(time.RFC3339)
+	}
+	state.Status = "pending"
+	state.TmuxWindow = ""
+	state.ExitCode = nil
+	state.ExitedAt = ""
+	state.Paused = false
+	state.QueuePosition = 0
+	state.ActivityMark = ""
+	state.LastActivityAt = ""
+	state.Timing = config.Timing{}
+}
+
+func epicRoleKeys(cfg *config.Config, epicKey string) []string {
+	var keys []string
+	for roleKey, state := range cfg.Roles {
+		if state.EpicBeadID == epicKey {
+			keys = append(keys, roleKey)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// interruptRoles sends Ctrl-C to the running attempts of roleKeys and returns
+// the first error.
+func interruptRoles(cwd string, cfg *config.Config, roleKeys []string, newExecutor func(kind, tmuxSocket string) (executor.Executor, error)) error {
+	runner, err := newExecutor(cfg.Session.Executor, cfg.Session.TmuxSocket)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, roleKey := range roleKeys {
+		if err := runner.Interrupt(roleTarget(cwd, cfg.Session.RunID, cfg.Session.Name, cfg.Roles[roleKey])); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
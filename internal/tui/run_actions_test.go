@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"lattice/internal/config"
+	"lattice/internal/events"
+	"lattice/internal/executor"
+)
+
+func TestApplyRunActionInterruptsCancelledRoles(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	cfg, err := config.Init(workDir)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	cfg.Session.Name = "lattice-20260213-010203"
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", Status: "running"}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Status: "complete", Attempt: 1}
+	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Status: "running", Attempt: 1, TmuxWindow: "lattice-20260213-010203:e1-bravo"}
+	cfg.Roles["r3"] = config.RoleState{BeadID: "r3", EpicBeadID: "e1", CodeName: "charlie", Status: "pending", QueuePosition: 1}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	var interrupted []string
+	result, err := applyRunAction(workDir, RunActionTarget{EpicBeadID: "e1"}, RunActionCancel, controlDeps{
+		newExecutor: func(string, string) (executor.Executor, error) {
+			return &fakeExecutor{interrupt: func(session, window string) error {
+				interrupted = append(interrupted, session+":"+window)
+				return nil
+			}}, nil
+		},
+		now: func() time.Time { return time.Date(2026, time.February, 13, 2, 0, 0, 0, time.UTC) },
+	})
+	if err != nil {
+		t.Fatalf("applyRunAction() returned error: %v", err)
+	}
+	if strings.Join(result.Changed, ",") != "r2,r3" {
+		t.Fatalf("unexpected changed roles: %v", result.Changed)
+	}
+	if strings.Join(interrupted, ",") != "lattice-20260213-010203:e1-bravo" {
+		t.Fatalf("expected only the running role to be interrupted, got %v", interrupted)
+	}
+
+	saved, err := config.Load(workDir)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if saved.Roles["r1"].Status != "complete" || saved.Roles["r2"].Status != "cancelled" || saved.Roles["r3"].Status != "cancelled" {
+		t.Fatalf("unexpected saved roles: %+v", saved.Roles)
+	}
+	if saved.Roles["r2"].ExitedAt != "2026-02-13T02:00:00Z" || saved.Roles["r3"].QueuePosition != 0 {
+		t.Fatalf("expected cancelled roles to record exit and leave the queue, got %+v", saved.Roles)
+	}
+
+	journal, err := events.Read(workDir, events.Filter{})
+	if err != nil {
+		t.Fatalf("events.Read() returned error: %v", err)
+	}
+	if len(journal) != 1 || journal[0].Kind != events.KindAction || journal[0].Action != "cancel" || journal[0].EpicID != "e1" || journal[0].Message != "changed r2, r3" {
+		t.Fatalf("expected cancel to be journaled, got %+v", journal)
+	}
+}
+
+func TestApplyRunActionToConfig(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.February, 13, 2, 0, 0, 0, time.UTC)
+	newConfig := func() *config.Config {
+		return &config.Config{
+			Session: config.SessionMetadata{Name: "lattice-1"},
+			Epics: map[string]config.EpicState{
+				"e1": {BeadID: "e1", Status: "blocked"},
+			},
+			Roles: map[string]config.RoleState{
+				"r1": {BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Status: "failed", Attempt: 2, TmuxWindow: "lattice-1:e1-alpha-attempt2"},
+				"r2": {BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Status: "running", Attempt: 1, TmuxWindow: "lattice-1:e1-bravo"},
+				"r3": {BeadID: "r3", EpicBeadID: "e1", CodeName: "charlie", Status: "pending"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		target         RunActionTarget
+		action         RunAction
+		wantChanged    string
+		wantInterrupts string
+		wantErr        string
+		check          func(t *testing.T, cfg *config.Config)
+	}{
+		{
+			name:        "pause epic",
+			target:      RunActionTarget{EpicBeadID: "e1"},
+			action:      RunActionPause,
+			wantChanged: "e1",
+			check: func(t *testing.T, cfg *config.Config) {
+				if !cfg.Epics["e1"].Paused {
+					t.Fatal("expected epic paused")
+				}
+			},
+		},
+		{
+			name:        "pause pending role",
+			target:      RunActionTarget{EpicBeadID: "e1", RoleBeadID: "r3"},
+			action:      RunActionPause,
+			wantChanged: "r3",
+			check: func(t *testing.T, cfg *config.Config) {
+				if !cfg.Roles["r3"].Paused {
+					t.Fatal("expected role paused")
+				}
+			},
+		},
+		{
+			name:    "pause running role",
+			target:  RunActionTarget{EpicBeadID: "e1", RoleBeadID: "r2"},
+			action:  RunActionPause,
+			wantErr: "only pending roles can be paused",
+		},
+		{
+			name:        "resume epic requeues failed roles",
+			target:      RunActionTarget{EpicBeadID: "e1"},
+			action:      RunActionResume,
+			wantChanged: "e1,r1",
+			check: func(t *testing.T, cfg *config.Config) {
+				role := cfg.Roles["r1"]
+				if role.Status != "pending" || role.RetryAt != "2026-02-13T02:00:00Z" || role.TmuxWindow != "" {
+					t.Fatalf("expected failed role requeued, got %+v", role)
+				}
+				if len(role.Attempts) != 1 || role.Attempts[0].Attempt != 2 || role.Attempts[0].Reason != "rerun" {
+					t.Fatalf("expected previous attempt recorded, got %+v", role.Attempts)
+				}
+			},
+		},
+		{
+			name:           "skip running role",
+			target:         RunActionTarget{EpicBeadID: "e1", RoleBeadID: "r2"},
+			action:         RunActionSkip,
+			wantChanged:    "r2",
+			wantInterrupts: "r2",
+			check: func(t *testing.T, cfg *config.Config) {
+				if cfg.Roles["r2"].Status != "skipped" {
+					t.Fatalf("expected role skipped, got %q", cfg.Roles["r2"].Status)
+				}
+			},
+		},
+		{
+			name:    "cancel finished role",
+			target:  RunActionTarget{EpicBeadID: "e1", RoleBeadID: "r1"},
+			action:  RunActionCancel,
+			wantErr: "already failed",
+		},
+		{
+			name:        "re-run failed role",
+			target:      RunActionTarget{EpicBeadID: "e1", RoleBeadID: "r1"},
+			action:      RunActionRerun,
+			wantChanged: "r1",
+			check: func(t *testing.T, cfg *config.Config) {
+				if cfg.Roles["r1"].Status != "pending" || cfg.Roles["r1"].Attempt != 2 {
+					t.Fatalf("expected role pending after attempt 2, got %+v", cfg.Roles["r1"])
+				}
+			},
+		},
+		{
+			name:    "re-run running role",
+			target:  RunActionTarget{EpicBeadID: "e1", RoleBeadID: "r2"},
+			action:  RunActionRerun,
+			wantErr: "only finished roles can be re-run",
+		},
+		{
+			name:    "re-run epic",
+			target:  RunActionTarget{EpicBeadID: "e1"},
+			action:  RunActionRerun,
+			wantErr: "applies to a single role",
+		},
+		{
+			name:    "unknown role",
+			target:  RunActionTarget{EpicBeadID: "e1", RoleBeadID: "r9"},
+			action:  RunActionSkip,
+			wantErr: `unknown role "r9"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newConfig()
+			changed, interrupts, err := applyRunActionToConfig(cfg, tt.target, tt.action, now)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyRunActionToConfig() returned error: %v", err)
+			}
+			if got := strings.Join(changed, ","); got != tt.wantChanged {
+				t.Fatalf("changed = %q, want %q", got, tt.wantChanged)
+			}
+			if got := strings.Join(interrupts, ","); got != tt.wantInterrupts {
+				t.Fatalf("interrupts = %q, want %q", got, tt.wantInterrupts)
+			}
+			if tt.check != nil {
+				tt.check(t, cfg)
+			}
+		})
+	}
+}
@@ -1,25 +1,37 @@
 package tui
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"lattice/internal/agent"
 	"lattice/internal/config"
+	"lattice/internal/events"
+	"lattice/internal/executor"
+	"lattice/internal/panelog"
 	"lattice/internal/teams"
-	"lattice/internal/tmux"
 )
 
 // SchedulerDeps defines all external dependencies for role advancement.
 type SchedulerDeps struct {
 	GenerateRoleSession func(params teams.RoleSessionParams) (string, error)
-	TranslatePath       func(path string) (string, error)
-	TmuxManager         launchTmuxManager
-	CheckTmuxWindow     func(sessionName, windowName string) bool
-	Now                 func() time.Time
+	// Executor starts roles and checks whether they are still running; nil uses
+	// the executor the run was launched with.
+	Executor executor.Executor
+	// RunHook runs one hook command after a pass; nil runs it through the shell
+	// and logs to .lattice/logs/hooks.log.
+	RunHook runHookFunc
+	// RecordEvents journals the pass's transitions and hook results; nil
+	// appends them to .lattice/events.jsonl.
+	RecordEvents recordEventsFunc
+	Now          func() time.Time
 }
 
 // ScheduledRole captures one role that was launched by the scheduler.
@@ -31,6 +43,17 @@ type ScheduledRole struct {
 	WindowName string
 	SessionDir string
 	LaunchedAt time.Time
+	// Attempt is 1 for a first launch and higher for retries.
+	Attempt int
+}
+
+// ScheduledRetry captures one failed or stalled role that will be relaunched.
+// MaxAttempts is 0 for stalled roles, which restart regardless of the retry policy.
+type ScheduledRetry struct {
+	RoleBeadID  string
+	NextAttempt int
+	MaxAttempts int
+	RetryAt     time.Time
 }
 
 // SchedulerResult reports all transitions performed in one scheduling pass.
@@ -39,6 +62,24 @@ type SchedulerResult struct {
 	Completed []string
 	Failed    []string
 	AllDone   bool
+	// Retried lists failed roles that went back to pending for another attempt.
+	Retried []ScheduledRetry
+	// Stalled lists roles that stopped showing activity in this pass.
+	Stalled []string
+	// ActivityChanged is set when a running role showed new activity, so the
+	// updated activity fingerprint must be saved even without a transition.
+	ActivityChanged bool
+	// Blocked is set when no role is running, no retry is due, nothing is paused
+	// and no pending role can start.
+	Blocked bool
+	// EpicsFinished lists epics whose roles all finished in this pass.
+	EpicsFinished []string
+	// RunCompleted is set on the pass in which every role of the run finished.
+	RunCompleted bool
+	// HookErrors collects hook commands that failed or timed out after the pass.
+	HookErrors []error
+	// Transitions journals every role and epic status change of the pass.
+	Transitions []events.Event
 }
 
 // CheckAndAdvanceRoles advances role state machines and launches next roles.
@@ -56,7 +97,7 @@ func CheckAndAdvanceRoles(cwd string, cfg *config.Config, sessionName string, pl
 		return SchedulerResult{}, fmt.Errorf("session name must not be empty")
 	}
 
-	resolvedDeps, err := resolveSchedulerDeps(deps)
+	resolvedDeps, err := resolveSchedulerDeps(deps, cfg.Session)
 	if err != nil {
 		return SchedulerResult{}, err
 	}
@@ -68,78 +109,142 @@ func CheckAndAdvanceRoles(cwd string, cfg *config.Config, sessionName string, pl
 		cfg.Roles = map[string]config.RoleState{}
 	}
 
+	stallTimeout, err := cfg.Session.Stall.TimeoutDuration()
+	if err != nil {
+		return SchedulerResult{}, err
+	}
+	stallAction := cfg.Session.Stall.ResolvedAction()
+	before := statusSnapshot(plan, cfg)
+
+	teamsDir := config.TeamsDir(cwd, cfg.Session.RunID)
+	now := resolvedDeps.Now()
 	result := SchedulerResult{}
-	for _, epic := range plan.Epics {
+	var scheduled []*scheduledEpic
+	for _, epic := range orderedEpics(plan) {
 		auditTypeID := strings.TrimSpace(epic.AuditType.ID)
-		if auditTypeID == "" {
+		epicKey := strings.TrimSpace(epic.BeadID)
+		if auditTypeID == "" || epicKey == "" {
 			continue
 		}
 
-		if _, ok := cfg.Epics[auditTypeID]; !ok {
-			cfg.Epics[auditTypeID] = config.EpicState{
+		if _, ok := cfg.Epics[epicKey]; !ok {
+			cfg.Epics[epicKey] = config.EpicState{
 				BeadID:     epic.BeadID,
 				AuditType:  auditTypeID,
 				AuditName:  epic.AuditType.Name,
+				Target:     epic.Target,
+				FocusAreas: append([]string(nil), epic.FocusAreas...),
+				DependsOn:  append([]string(nil), epic.DependsOn...),
 				AgentCount: len(epic.RoleBeads),
 				Status:     "running",
 			}
 		}
 
-		roleBeads := orderedRoleBeads(epic, cfg)
-		for idx, roleBead := range roleBeads {
+		entry := newScheduledEpic(epic, cfg)
+		entry.upstream = upstreamEpicStatus(epic.DependsOn, cfg)
+		scheduled = append(scheduled, entry)
+		for _, roleBead := range entry.roleBeads {
 			state := ensureRoleState(cfg.Roles[roleBead.BeadID], epic, roleBead)
+			state.QueuePosition = 0
 			status := normalizeRoleStatus(state.Status)
 
 			switch status {
-			case "running":
-				windowName := roleWindowName(auditTypeID, roleBead.CodeName)
-				if resolvedDeps.CheckTmuxWindow(sessionName, windowName) {
-					cfg.Roles[roleBead.BeadID] = state
-					continue
+			case "running", "stalled":
+				target := roleTarget(cwd, cfg.Session.RunID, sessionName, state)
+				exit, exited, err := readRoleExit(teamsDir, state, roleBead.BeadID)
+				if err != nil {
+					return result, fmt.Errorf("read role exit for %s/%s: %w", epicKey, roleBead.CodeName, err)
+				}
+				if !exited && resolvedDeps.Executor.Alive(target) {
+					code, dead := resolvedDeps.Executor.ExitStatus(target)
+					if !dead {
+						previousMark := state.ActivityMark
+						stalled, err := detectStall(&state, teamsDir, roleBead.BeadID, target, stallTimeout, now, resolvedDeps.Executor)
+						if err != nil {
+							return result, fmt.Errorf("check activity for %s/%s: %w", epicKey, roleBead.CodeName, err)
+						}
+						if state.ActivityMark != previousMark || (!stalled && status == "stalled") {
+							result.ActivityChanged = true
+						}
+						if stalled && status != "stalled" {
+							result.Stalled = append(result.Stalled, roleBead.BeadID)
+						}
+						if !stalled || stallAction == config.StallActionNotify {
+							state.Status = "running"
+							if stalled {
+								state.Status = "stalled"
+							}
+							cfg.Roles[roleBead.BeadID] = state
+							entry.running++
+							continue
+						}
+
+						if err := resolvedDeps.Executor.Stop(target); err != nil {
+							return result, fmt.Errorf("stop stalled role %s/%s: %w", epicKey, roleBead.CodeName, err)
+						}
+						if stallAction == config.StallActionRestart && stallRestartAllowed(state, cfg.Epics[epicKey].Retry) {
+							retry := restartStalledRole(&state, now)
+							retry.RoleBeadID = roleBead.BeadID
+							if err := writeRoleLog(cwd, cfg.Session.RunID, state); err != nil {
+								return result, fmt.Errorf("write transcript for %s/%s: %w", epicKey, roleBead.CodeName, err)
+							}
+							cfg.Roles[roleBead.BeadID] = state
+							result.Retried = append(result.Retried, retry)
+							continue
+						}
+						// The fail action, and a restart past the attempt limit, handle the
+						// role like a session that ended without completing.
+					} else {
+						exit = roleExit{Code: code, EndedAt: now.UTC().Format(time.RFC3339)}
+						exited = true
+					}
 				}
 
-				teamStatus, err := readRoleTeamStatus(cwd, state, roleBead.BeadID)
+				teamStatus, err := readRoleTeamStatus(teamsDir, state, roleBead.BeadID)
 				if err != nil {
-					return result, fmt.Errorf("read role status for %s/%s: %w", auditTypeID, roleBead.CodeName, err)
+					return result, fmt.Errorf("read role status for %s/%s: %w", epicKey, roleBead.CodeName, err)
 				}
 
+				if exited {
+					code := exit.Code
+					state.ExitCode = &code
+					state.ExitedAt = exit.EndedAt
+				}
 				if teamStatus == "complete" {
 					state.Status = "complete"
 					state.TmuxWindow = ""
+					state.MarkCompleted(now)
+					if err := writeRoleLog(cwd, cfg.Session.RunID, state); err != nil {
+						return result, fmt.Errorf("write transcript for %s/%s: %w", epicKey, roleBead.CodeName, err)
+					}
 					cfg.Roles[roleBead.BeadID] = state
 					result.Completed = append(result.Completed, roleBead.BeadID)
-				} else {
-					state.Status = "failed"
-					state.TmuxWindow = ""
-					cfg.Roles[roleBead.BeadID] = state
-					result.Failed = append(result.Failed, roleBead.BeadID)
+					continue
 				}
 
-			case "pending":
-				prevStatus := "complete"
-				if idx > 0 {
-					prevRole := cfg.Roles[roleBeads[idx-1].BeadID]
-					prevStatus = normalizeRoleStatus(prevRole.Status)
+				retry, ok, err := scheduleRoleRetry(&state, cfg.Epics[epicKey].Retry, now)
+				if err != nil {
+					return result, fmt.Errorf("schedule retry for %s/%s: %w", epicKey, roleBead.CodeName, err)
 				}
-
-				if prevStatus == "failed" {
-					cfg.Roles[roleBead.BeadID] = state
-					continue
+				state.TmuxWindow = ""
+				if err := writeRoleLog(cwd, cfg.Session.RunID, state); err != nil {
+					return result, fmt.Errorf("write transcript for %s/%s: %w", epicKey, roleBead.CodeName, err)
 				}
-				if prevStatus != "complete" {
-					cfg.Roles[roleBead.BeadID] = state
-					continue
+				cfg.Roles[roleBead.BeadID] = state
+				if ok {
+					retry.RoleBeadID = roleBead.BeadID
+					result.Retried = append(result.Retried, retry)
+				} else {
+					result.Failed = append(result.Failed, roleBead.BeadID)
 				}
 
-				launchedRole, updatedState, err := launchScheduledRole(cwd, sessionName, epic, state, roleBead, resolvedDeps)
-				if err != nil {
-					return result, err
+			case "pending":
+				cfg.Roles[roleBead.BeadID] = state
+				if entry.upstream == "complete" && !state.Paused && !cfg.Epics[epicKey].Paused && retryDue(state, now) && dependenciesComplete(entry.roleDeps[roleBead.CodeName], entry.roleByCode, cfg) {
+					entry.eligible = append(entry.eligible, roleBead)
 				}
 
-				cfg.Roles[roleBead.BeadID] = updatedState
-				result.Launched = append(result.Launched, launchedRole)
-
-			case "complete", "failed":
+			case "complete", "failed", "cancelled", "skipped":
 				cfg.Roles[roleBead.BeadID] = state
 			default:
 				state.Status = "pending"
@@ -147,65 +252,335 @@ func CheckAndAdvanceRoles(cwd string, cfg *config.Config, sessionName string, pl
 			}
 		}
 
-		epicState := cfg.Epics[auditTypeID]
-		epicState.BeadID = epic.BeadID
-		epicState.AuditType = auditTypeID
-		epicState.AuditName = epic.AuditType.Name
-		epicState.Status = deriveEpicStateStatus(roleBeads, cfg)
-		cfg.Epics[auditTypeID] = epicState
+		if entry.updateStatus(cfg, now) {
+			result.EpicsFinished = append(result.EpicsFinished, epicKey)
+		}
+	}
+
+	admitted, queued := admitRoles(scheduled, cfg.Session.MaxConcurrentRoles)
+	for _, candidate := range admitted {
+		entry, roleBead := candidate.epic, candidate.role
+		var priorWork []teams.PriorWork
+		for _, dep := range entry.roleDeps[roleBead.CodeName] {
+			depBead := entry.roleByCode[dep]
+			if normalizeRoleStatus(cfg.Roles[depBead.BeadID].Status) == "skipped" {
+				continue
+			}
+			work, err := teams.LoadPriorWork(teamsDir, cfg.Roles[depBead.BeadID], depBead.BeadID)
+			if err != nil {
+				return result, fmt.Errorf("load prior work for %s/%s: %w", entry.epic.BeadID, roleBead.CodeName, err)
+			}
+			priorWork = append(priorWork, work)
+		}
+
+		state, startLoop, err := prepareRoleRetry(teamsDir, cfg.Roles[roleBead.BeadID], roleBead.BeadID, cfg.Epics[entry.epic.BeadID].Retry)
+		if err != nil {
+			return result, fmt.Errorf("prepare retry for %s/%s: %w", entry.epic.BeadID, roleBead.CodeName, err)
+		}
+
+		runtime, err := agent.Resolve(cfg.Epics[entry.epic.BeadID].Agent, cfg.Session.Agents)
+		if err != nil {
+			return result, fmt.Errorf("resolve agent runtime for %s: %w", entry.epic.BeadID, err)
+		}
+
+		launchedRole, updatedState, err := launchScheduledRole(cwd, cfg.Session.RunID, sessionName, entry.epic, state, roleBead, priorWork, startLoop, runtime, resolvedDeps)
+		if err != nil {
+			return result, err
+		}
+
+		cfg.Roles[roleBead.BeadID] = updatedState
+		if epicState := cfg.Epics[entry.epic.BeadID]; epicState.LaunchedAt == "" {
+			epicState.MarkLaunched(launchedRole.LaunchedAt)
+			cfg.Epics[entry.epic.BeadID] = epicState
+		}
+		result.Launched = append(result.Launched, launchedRole)
+	}
+	for idx, candidate := range queued {
+		state := cfg.Roles[candidate.role.BeadID]
+		state.QueuePosition = idx + 1
+		cfg.Roles[candidate.role.BeadID] = state
+	}
+	for _, entry := range scheduled {
+		if entry.updateStatus(cfg, now) {
+			result.EpicsFinished = append(result.EpicsFinished, entry.epic.BeadID)
+		}
 	}
 
 	result.AllDone = allRolesTerminal(plan, cfg)
+	switch {
+	case result.AllDone && cfg.Session.CompletedAt == "":
+		cfg.Session.CompletedAt = now.UTC().Format(time.RFC3339)
+		result.RunCompleted = true
+	case !result.AllDone:
+		cfg.Session.CompletedAt = ""
+	}
+	result.Blocked = !result.AllDone && !anyRoleRunning(plan, cfg) && !anyRetryScheduled(plan, cfg) && !anyPaused(plan, cfg)
+	result.Transitions = statusTransitions(before, plan, cfg, now)
 	return result, nil
 }
 
-func resolveSchedulerDeps(deps SchedulerDeps) (SchedulerDeps, error) {
+// statusSnapshot records the status of every role and epic of plan that
+// already has state, keyed by bead ID.
+func statusSnapshot(plan *teams.AuditPlan, cfg *config.Config) map[string]string {
+	snapshot := map[string]string{}
+	for _, epic := range plan.Epics {
+		if state, ok := cfg.Epics[epic.BeadID]; ok {
+			snapshot[epic.BeadID] = state.Status
+		}
+		for _, role := range epic.RoleBeads {
+			if state, ok := cfg.Roles[role.BeadID]; ok {
+				snapshot[role.BeadID] = normalizeRoleStatus(state.Status)
+			}
+		}
+	}
+
+	return snapshot
+}
+
+// statusTransitions returns a journal event for each role and epic whose status
+// differs from before, roles first within each epic.
+func statusTransitions(before map[string]string, plan *teams.AuditPlan, cfg *config.Config, now time.Time) []events.Event {
+	var transitions []events.Event
+	for _, epic := range orderedEpics(plan) {
+		for _, role := range epic.RoleBeads {
+			from, ok := before[role.BeadID]
+			state := cfg.Roles[role.BeadID]
+			to := normalizeRoleStatus(state.Status)
+			if !ok || from == to {
+				continue
+			}
+
+			transitions = append(transitions, events.Event{
+				Time:    now.UTC(),
+				Kind:    events.KindTransition,
+				Source:  "scheduler",
+				RunID:   cfg.Session.RunID,
+				EpicID:  epic.BeadID,
+				RoleID:  role.BeadID,
+				From:    from,
+				To:      to,
+				Message: roleTransitionMessage(state, to),
+			})
+		}
+
+		from, ok := before[epic.BeadID]
+		to := cfg.Epics[epic.BeadID].Status
+		if ok && from != to {
+			transitions = append(transitions, events.Event{
+				Time:   now.UTC(),
+				Kind:   events.KindTransition,
+				Source: "scheduler",
+				RunID:  cfg.Session.RunID,
+				EpicID: epic.BeadID,
+				From:   from,
+				To:     to,
+			})
+		}
+	}
+
+	return transitions
+}
+
+func roleTransitionMessage(state config.RoleState, status string) string {
+	switch {
+	case status == "running" && state.TmuxWindow != "":
+		return fmt.Sprintf("attempt %d in %s", state.Attempt, state.TmuxWindow)
+	case status == "pending" && state.RetryAt != "":
+		return "retry at " + state.RetryAt
+	case state.ExitCode != nil:
+		return fmt.Sprintf("exit %d", *state.ExitCode)
+	}
+
+	return ""
+}
+
+// scheduledEpic carries one epic's role graph through a scheduling pass.
+type scheduledEpic struct {
+	epic       teams.EpicBead
+	roleBeads  []teams.RoleBead
+	roleDeps   map[string][]string
+	roleByCode map[string]teams.RoleBead
+	upstream   string
+	// running counts roles still running; eligible lists pending roles that may start.
+	running  int
+	eligible []teams.RoleBead
+}
+
+func newScheduledEpic(epic teams.EpicBead, cfg *config.Config) *scheduledEpic {
+	roleBeads := orderedRoleBeads(epic, cfg)
+	roleByCode := make(map[string]teams.RoleBead, len(roleBeads))
+	for _, roleBead := range roleBeads {
+		roleByCode[roleBead.CodeName] = roleBead
+	}
+
+	return &scheduledEpic{
+		epic:       epic,
+		roleBeads:  roleBeads,
+		roleDeps:   teams.RoleDependencies(roleBeads),
+		roleByCode: roleByCode,
+		upstream:   "complete",
+	}
+}
+
+// updateStatus derives the epic's status from its roles, times the epic's end at
+// now and reports whether the epic just reached a terminal status.
+func (e *scheduledEpic) updateStatus(cfg *config.Config, now time.Time) bool {
+	epicKey := strings.TrimSpace(e.epic.BeadID)
+	epicState := cfg.Epics[epicKey]
+	wasTerminal := epicStatusTerminal(epicState.Status)
+	epicState.BeadID = e.epic.BeadID
+	epicState.AuditType = strings.TrimSpace(e.epic.AuditType.ID)
+	epicState.AuditName = e.epic.AuditType.Name
+	epicState.Status = deriveEpicStateStatus(e.roleBeads, cfg)
+	if e.upstream != "complete" && !anyRoleStarted(e.roleBeads, cfg) {
+		epicState.Status = e.upstream
+	}
+	if epicState.Paused && !epicStatusTerminal(epicState.Status) {
+		epicState.Status = "paused"
+	}
+	finished := !wasTerminal && epicStatusTerminal(epicState.Status)
+	switch {
+	case finished && epicState.Status == "complete":
+		epicState.MarkCompleted(now)
+	case finished && epicState.Status == "failed":
+		epicState.MarkFailed(now)
+	case finished:
+		epicState.MarkStopped(now)
+	case wasTerminal && !epicStatusTerminal(epicState.Status):
+		// A re-run reopened the epic; it keeps its original launch time.
+		epicState.Timing = config.Timing{LaunchedAt: epicState.LaunchedAt}
+	}
+	cfg.Epics[epicKey] = epicState
+
+	return finished
+}
+
+type roleCandidate struct {
+	epic *scheduledEpic
+	role teams.RoleBead
+}
+
+// admitRoles splits eligible roles into those that start now and those that wait,
+// keeping the number of running roles within limit. A limit of 0 admits every role.
+func admitRoles(epics []*scheduledEpic, limit int) (admitted, queued []roleCandidate) {
+	eligible := make([][]roleCandidate, len(epics))
+	running := make([]int, len(epics))
+	totalRunning := 0
+	for idx, entry := range epics {
+		for _, role := range entry.eligible {
+			eligible[idx] = append(eligible[idx], roleCandidate{epic: entry, role: role})
+		}
+		running[idx] = entry.running
+		totalRunning += entry.running
+	}
+
+	queue := roundRobin(eligible, running)
+	slots := admissionSlots(limit, totalRunning, len(queue))
+	return queue[:slots], queue[slots:]
+}
+
+// admissionSlots returns how many of queued roles may start while running roles
+// are already active. A limit of 0 or less admits every queued role.
+func admissionSlots(limit, running, queued int) int {
+	if limit <= 0 {
+		return queued
+	}
+
+	slots := limit - running
+	switch {
+	case slots < 0:
+		return 0
+	case slots > queued:
+		return queued
+	default:
+		return slots
+	}
+}
+
+// roundRobin interleaves each epic's eligible roles so every epic gets a turn
+// before any epic gets another. Epics with fewer running roles go first.
+func roundRobin[T any](eligible [][]T, running []int) []T {
+	order := make([]int, len(eligible))
+	for idx := range order {
+		order[idx] = idx
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return running[order[i]] < running[order[j]]
+	})
+
+	var queue []T
+	for round := 0; ; round++ {
+		added := false
+		for _, idx := range order {
+			if round < len(eligible[idx]) {
+				queue = append(queue, eligible[idx][round])
+				added = true
+			}
+		}
+		if !added {
+			return queue
+		}
+	}
+}
+
+func resolveSchedulerDeps(deps SchedulerDeps, session config.SessionMetadata) (SchedulerDeps, error) {
 	resolved := deps
 	if resolved.GenerateRoleSession == nil {
 		resolved.GenerateRoleSession = teams.GenerateRoleSession
 	}
-	if resolved.TranslatePath == nil {
-		resolved.TranslatePath = tmux.TranslateToWSLPath
-	}
 	if resolved.Now == nil {
 		resolved.Now = time.Now
 	}
-	if resolved.TmuxManager == nil {
-		manager, err := newLaunchTmuxManager()
+	if resolved.Executor == nil {
+		runner, err := executor.New(session.Executor, session.TmuxSocket)
 		if err != nil {
-			return SchedulerDeps{}, fmt.Errorf("initialize tmux manager: %w", err)
+			return SchedulerDeps{}, fmt.Errorf("initialize executor: %w", err)
 		}
-		resolved.TmuxManager = manager
-	}
-	if resolved.CheckTmuxWindow == nil {
-		resolved.CheckTmuxWindow = tmuxWindowChecker(resolved.TmuxManager)
+		resolved.Executor = runner
 	}
 
 	return resolved, nil
 }
 
-func tmuxWindowChecker(manager launchTmuxManager) func(sessionName, windowName string) bool {
-	lister, ok := manager.(interface {
-		ListWindows(session string) ([]tmux.WindowInfo, error)
+// orderedEpics lists epics so each one comes after the epics it depends on,
+// letting a downstream epic start in the same pass its upstream completes.
+func orderedEpics(plan *teams.AuditPlan) []teams.EpicBead {
+	epics := append([]teams.EpicBead(nil), plan.Epics...)
+	stages := teams.EpicStages(epics)
+	sort.SliceStable(epics, func(i, j int) bool {
+		return stages[epics[i].BeadID] < stages[epics[j].BeadID]
 	})
-	if !ok {
-		return func(_, _ string) bool { return false }
-	}
 
-	return func(sessionName, windowName string) bool {
-		windows, err := lister.ListWindows(sessionName)
-		if err != nil {
-			return false
-		}
+	return epics
+}
 
-		for _, window := range windows {
-			if window.Name == windowName {
-				return true
-			}
+// upstreamEpicStatus summarizes the epics an epic depends on: "complete" when all
+// of them completed, "blocked" when any failed, was cancelled or is blocked, and
+// "pending" otherwise.
+func upstreamEpicStatus(dependsOn []string, cfg *config.Config) string {
+	status := "complete"
+	for _, beadID := range dependsOn {
+		switch strings.ToLower(strings.TrimSpace(cfg.Epics[beadID].Status)) {
+		case "complete":
+			continue
+		case "failed", "cancelled", "blocked":
+			return "blocked"
+		default:
+			status = "pending"
 		}
+	}
 
-		return false
+	return status
+}
+
+func anyRoleStarted(roleBeads []teams.RoleBead, cfg *config.Config) bool {
+	for _, role := range roleBeads {
+		if normalizeRoleStatus(cfg.Roles[role.BeadID].Status) != "pending" {
+			return true
+		}
 	}
+
+	return false
 }
 
 func orderedRoleBeads(epic teams.EpicBead, cfg *config.Config) []teams.RoleBead {
@@ -228,9 +603,32 @@ func orderedRoleBeads(epic teams.EpicBead, cfg *config.Config) []teams.RoleBead
 		return leftOrder < rightOrder
 	})
 
+	stages := teams.RoleStages(roleBeads)
+	sort.SliceStable(roleBeads, func(i, j int) bool {
+		return stages[roleBeads[i].CodeName] < stages[roleBeads[j].CodeName]
+	})
+
 	return roleBeads
 }
 
+// dependenciesComplete reports whether every dependency of a role has completed
+// or was skipped. Dependencies that are not part of the epic never complete.
+func dependenciesComplete(deps []string, roleByCode map[string]teams.RoleBead, cfg *config.Config) bool {
+	for _, dep := range deps {
+		depBead, ok := roleByCode[dep]
+		if !ok {
+			return false
+		}
+		switch normalizeRoleStatus(cfg.Roles[depBead.BeadID].Status) {
+		case "complete", "skipped":
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
 func ensureRoleState(state config.RoleState, epic teams.EpicBead, role teams.RoleBead) config.RoleState {
 	state.BeadID = role.BeadID
 	state.EpicBeadID = epic.BeadID
@@ -241,6 +639,9 @@ func ensureRoleState(state config.RoleState, epic teams.EpicBead, role teams.Rol
 	if state.Order == 0 {
 		state.Order = role.Order
 	}
+	if len(state.After) == 0 {
+		state.After = append([]string(nil), role.After...)
+	}
 	if strings.TrimSpace(state.Status) == "" {
 		state.Status = "pending"
 	}
@@ -248,17 +649,24 @@ func ensureRoleState(state config.RoleState, epic teams.EpicBead, role teams.Rol
 	return state
 }
 
-func launchScheduledRole(cwd string, sessionName string, epic teams.EpicBead, state config.RoleState, role teams.RoleBead, deps SchedulerDeps) (ScheduledRole, config.RoleState, error) {
+func launchScheduledRole(cwd string, runID string, sessionName string, epic teams.EpicBead, state config.RoleState, role teams.RoleBead, priorWork []teams.PriorWork, startLoop int, runtime agent.Runtime, deps SchedulerDeps) (ScheduledRole, config.RoleState, error) {
 	params := teams.RoleSessionParams{
 		Cwd:          cwd,
+		RunID:        runID,
 		EpicBeadID:   epic.BeadID,
 		RoleBeadID:   role.BeadID,
 		RoleTitle:    state.Title,
 		RoleGuidance: state.Guidance,
 		Intensity:    state.Intensity,
 		BeadPrefix:   state.BeadPrefix,
+		Target:       epic.Target,
+		FocusAreas:   append([]string(nil), epic.FocusAreas...),
 		AuditTypeID:  epic.AuditType.ID,
 		CodeName:     state.CodeName,
+		PriorWork:    priorWork,
+		StartLoop:    startLoop,
+		Runtime:      runtime,
+		AgentParams:  state.AgentParams,
 	}
 
 	roleDir, err := deps.GenerateRoleSession(params)
@@ -266,25 +674,29 @@ func launchScheduledRole(cwd string, sessionName string, epic teams.EpicBead, st
 		return ScheduledRole{}, state, fmt.Errorf("generate role session for %s/%s: %w", epic.AuditType.ID, role.CodeName, err)
 	}
 
-	windowName := roleWindowName(epic.AuditType.ID, role.CodeName)
-	if err := deps.TmuxManager.CreateWindow(sessionName, windowName); err != nil {
-		return ScheduledRole{}, state, fmt.Errorf("create tmux window for %s/%s: %w", epic.AuditType.ID, role.CodeName, err)
-	}
-
-	wslRoleDir, err := deps.TranslatePath(roleDir)
+	attempt := state.Attempt + 1
+	windowName := roleAttemptWindowName(epic.BeadID, role.CodeName, attempt)
+	_, rawLogPath := panelog.Paths(cwd, runID, windowName)
+	pid, err := deps.Executor.StartRole(executor.Target{Session: sessionName, Name: windowName, LogPath: rawLogPath}, roleDir)
 	if err != nil {
-		return ScheduledRole{}, state, fmt.Errorf("translate role session path for %s/%s: %w", epic.AuditType.ID, role.CodeName, err)
+		return ScheduledRole{}, state, fmt.Errorf("start %s/%s: %w", epic.BeadID, role.CodeName, err)
 	}
 
-	command := fmt.Sprintf("cd %s && opencode run auditor", shellQuote(wslRoleDir))
-	if err := deps.TmuxManager.SendKeys(sessionName, windowName, command); err != nil {
-		return ScheduledRole{}, state, fmt.Errorf("launch auditor for %s/%s: %w", epic.AuditType.ID, role.CodeName, err)
-	}
+	now := deps.Now().UTC()
 
 	state.Status = "running"
 	state.TmuxWindow = fmt.Sprintf("%s:%s", sessionName, windowName)
+	state.PID = pid
+	state.ExitCode = nil
+	state.ExitedAt = ""
+	state.Attempt = attempt
+	state.RetryAt = ""
+	state.ActivityMark = ""
+	state.LastActivityAt = ""
+	state.Log = windowName
+	state.StartLoop = startLoop
+	state.MarkLaunched(now)
 
-	now := deps.Now().UTC()
 	return ScheduledRole{
 		RoleBeadID: role.BeadID,
 		EpicBeadID: epic.BeadID,
@@ -293,24 +705,247 @@ func launchScheduledRole(cwd string, sessionName string, epic teams.EpicBead, st
 		WindowName: windowName,
 		SessionDir: roleDir,
 		LaunchedAt: now,
+		Attempt:    attempt,
 	}, state, nil
 }
 
-func readRoleTeamStatus(cwd string, role config.RoleState, roleKey string) (string, error) {
-	for _, dir := range dashboardRoleDirectories(role, roleKey) {
-		teamFile := filepath.Join(cwd, config.DirName, "teams", dir, ".team")
-		teamData, err := readTeamFile(teamFile)
+// writeRoleLog refreshes the stripped transcript of a role's latest attempt from
+// its raw pane stream.
+func writeRoleLog(cwd, runID string, state config.RoleState) error {
+	if state.Log == "" {
+		return nil
+	}
+
+	logPath, rawPath := panelog.Paths(cwd, runID, state.Log)
+	return panelog.WriteStripped(rawPath, logPath)
+}
+
+// roleTarget identifies the latest attempt of a role for the run's executor.
+func roleTarget(cwd, runID, sessionName string, state config.RoleState) executor.Target {
+	target := executor.Target{
+		Session: sessionName,
+		Name:    roleStateWindowName(state, state.EpicBeadID),
+		PID:     state.PID,
+	}
+	if state.Log != "" {
+		_, target.LogPath = panelog.Paths(cwd, runID, state.Log)
+	}
+
+	return target
+}
+
+// scheduleRoleRetry records a failed attempt and, when the policy allows another
+// one, puts the role back to pending until its backoff has passed. Otherwise the
+// role is marked failed.
+func scheduleRoleRetry(state *config.RoleState, policy config.RetryPolicy, now time.Time) (ScheduledRetry, bool, error) {
+	attempt := state.Attempt
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt >= policy.MaxAttempts {
+		state.Status = "failed"
+		state.MarkFailed(now)
+		return ScheduledRetry{}, false, nil
+	}
+
+	backoff, err := policy.BackoffDuration()
+	if err != nil {
+		return ScheduledRetry{}, false, err
+	}
+
+	retryAt := now.UTC().Add(backoff)
+	state.Attempts = append(state.Attempts, config.RoleAttempt{
+		Attempt:    attempt,
+		TmuxWindow: state.TmuxWindow,
+		ExitCode:   state.ExitCode,
+		ExitedAt:   state.ExitedAt,
+	})
+	state.Attempt = attempt
+	state.Status = "pending"
+	state.RetryAt = retryAt.Format(time.RFC3339)
+	state.MarkStopped(now)
+
+	return ScheduledRetry{NextAttempt: attempt + 1, MaxAttempts: policy.MaxAttempts, RetryAt: retryAt}, true, nil
+}
+
+// stallRestartAllowed reports whether a stalled role may be relaunched: its
+// launches so far stay below the epic's retry max_attempts, or
+// config.DefaultStallRestartAttempts when the epic sets none.
+func stallRestartAllowed(state config.RoleState, policy config.RetryPolicy) bool {
+	limit := policy.MaxAttempts
+	if limit <= 0 {
+		limit = config.DefaultStallRestartAttempts
+	}
+
+	return state.Attempt < limit
+}
+
+// restartStalledRole records a stalled attempt and puts the role back to pending
+// so the next pass relaunches it from its current loop.
+func restartStalledRole(state *config.RoleState, now time.Time) ScheduledRetry {
+	attempt := state.Attempt
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	state.Attempts = append(state.Attempts, config.RoleAttempt{
+		Attempt:    attempt,
+		TmuxWindow: state.TmuxWindow,
+		ExitedAt:   now.UTC().Format(time.RFC3339),
+		Reason:     "stalled",
+	})
+	state.Attempt = attempt
+	state.Status = "pending"
+	state.TmuxWindow = ""
+	state.RetryAt = now.UTC().Format(time.RFC3339)
+	state.ActivityMark = ""
+	state.LastActivityAt = ""
+	state.MarkStopped(now)
+
+	return ScheduledRetry{NextAttempt: attempt + 1, RetryAt: now.UTC()}
+}
+
+// detectStall refreshes a live role's activity fingerprint and reports whether it
+// has not changed for timeout. A timeout of 0 disables detection.
+func detectStall(state *config.RoleState, teamsDir, roleKey string, target executor.Target, timeout time.Duration, now time.Time, runner executor.Executor) (bool, error) {
+	if timeout <= 0 {
+		return false, nil
+	}
+
+	pane, _ := runner.Output(target)
+	mark, err := roleActivityMark(teamsDir, *state, roleKey, pane)
+	if err != nil {
+		return false, err
+	}
+
+	lastActivity, err := time.Parse(time.RFC3339, state.LastActivityAt)
+	if mark != state.ActivityMark || err != nil {
+		state.ActivityMark = mark
+		state.LastActivityAt = now.UTC().Format(time.RFC3339)
+		return false, nil
+	}
+
+	return now.Sub(lastActivity) >= timeout, nil
+}
+
+// roleActivityMark fingerprints the signals that show a role is making progress:
+// the last_activity heartbeat and mtime of its .team file and its pane content.
+func roleActivityMark(teamsDir string, state config.RoleState, roleKey, pane string) (string, error) {
+	hash := sha256.New()
+	for _, dir := range teams.RoleDirNames(state, roleKey) {
+		teamPath := filepath.Join(teamsDir, dir, ".team")
+		info, err := os.Stat(teamPath)
 		if err != nil {
 			if os.IsNotExist(err) {
 				continue
 			}
 			return "", err
 		}
+		teamData, err := teams.ReadTeamFile(teamPath)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(hash, "%s\n%d\n", teamData["last_activity"], info.ModTime().UnixNano())
+		break
+	}
+	fmt.Fprint(hash, pane)
+
+	return hex.EncodeToString(hash.Sum(nil))[:16], nil
+}
+
+// prepareRoleRetry moves a failed attempt's team directory aside before the role
+// relaunches and returns the loop to resume from when the policy resumes.
+// Roles that never ran are returned unchanged.
+func prepareRoleRetry(teamsDir string, state config.RoleState, roleKey string, policy config.RetryPolicy) (config.RoleState, int, error) {
+	if state.Attempt < 1 || len(state.Attempts) == 0 {
+		return state, 0, nil
+	}
+
+	startLoop := 0
+	if policy.Resume || state.Attempts[len(state.Attempts)-1].Reason == "stalled" {
+		teamData, _, err := readRoleFile(teamsDir, state, roleKey, ".team")
+		if err != nil {
+			return state, 0, err
+		}
+		if loop, err := strconv.Atoi(teamData["current_loop"]); err == nil && loop > 0 {
+			startLoop = loop
+		}
+	}
+
+	dirName := teams.RoleDirName(state.EpicBeadID, "", fallbackText(state.CodeName, roleKey))
+	archived, err := teams.ArchiveRoleAttempt(teamsDir, dirName, state.Attempt)
+	if err != nil {
+		return state, 0, err
+	}
+
+	attempts := append([]config.RoleAttempt(nil), state.Attempts...)
+	attempts[len(attempts)-1].SessionDir = archived
+	state.Attempts = attempts
+
+	return state, startLoop, nil
+}
+
+// retryDue reports whether a pending role may launch now. Roles without a retry
+// time are always due.
+func retryDue(state config.RoleState, now time.Time) bool {
+	retryAt := strings.TrimSpace(state.RetryAt)
+	if retryAt == "" {
+		return true
+	}
+
+	at, err := time.Parse(time.RFC3339, retryAt)
+	if err != nil {
+		return true
+	}
+
+	return !now.Before(at)
+}
+
+type roleExit struct {
+	Code    int
+	EndedAt string
+}
+
+func readRoleTeamStatus(teamsDir string, role config.RoleState, roleKey string) (string, error) {
+	teamData, _, err := readRoleFile(teamsDir, role, roleKey, ".team")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(strings.TrimSpace(teamData["status"])), nil
+}
+
+// readRoleExit reads the exit record written by the role session wrapper.
+func readRoleExit(teamsDir string, role config.RoleState, roleKey string) (roleExit, bool, error) {
+	exitData, ok, err := readRoleFile(teamsDir, role, roleKey, teams.RoleExitFile)
+	if err != nil || !ok {
+		return roleExit{}, false, err
+	}
+
+	code, err := strconv.Atoi(strings.TrimSpace(exitData["exit_code"]))
+	if err != nil {
+		return roleExit{}, false, fmt.Errorf("parse exit code %q: %w", exitData["exit_code"], err)
+	}
+
+	return roleExit{Code: code, EndedAt: strings.TrimSpace(exitData["ended_at"])}, true, nil
+}
+
+// readRoleFile reads a key=value file from the first existing role directory.
+func readRoleFile(teamsDir string, role config.RoleState, roleKey string, fileName string) (map[string]string, bool, error) {
+	for _, dir := range teams.RoleDirNames(role, roleKey) {
+		data, err := teams.ReadTeamFile(filepath.Join(teamsDir, dir, fileName))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, false, err
+		}
 
-		return strings.ToLower(strings.TrimSpace(teamData["status"])), nil
+		return data, true, nil
 	}
 
-	return "", nil
+	return map[string]string{}, false, nil
 }
 
 func deriveEpicStateStatus(roleBeads []teams.RoleBead, cfg *config.Config) string {
@@ -320,19 +955,23 @@ func deriveEpicStateStatus(roleBeads []teams.RoleBead, cfg *config.Config) strin
 
 	hasRunningOrPending := false
 	hasFailed := false
+	hasCancelled := false
 	allComplete := true
 
 	for _, role := range roleBeads {
 		status := normalizeRoleStatus(cfg.Roles[role.BeadID].Status)
 		switch status {
-		case "running", "pending":
+		case "running", "stalled", "pending":
 			hasRunningOrPending = true
 			allComplete = false
-		case "complete":
+		case "complete", "skipped":
 			continue
 		case "failed":
 			hasFailed = true
 			allComplete = false
+		case "cancelled":
+			hasCancelled = true
+			allComplete = false
 		default:
 			hasRunningOrPending = true
 			allComplete = false
@@ -340,7 +979,7 @@ func deriveEpicStateStatus(roleBeads []teams.RoleBead, cfg *config.Config) strin
 	}
 
 	if hasRunningOrPending {
-		if hasFailed {
+		if hasFailed || hasCancelled {
 			return "blocked"
 		}
 		return "running"
@@ -348,6 +987,9 @@ func deriveEpicStateStatus(roleBeads []teams.RoleBead, cfg *config.Config) strin
 	if hasFailed {
 		return "failed"
 	}
+	if hasCancelled {
+		return "cancelled"
+	}
 	if allComplete {
 		return "complete"
 	}
@@ -364,10 +1006,7 @@ func allRolesTerminal(plan *teams.AuditPlan, cfg *config.Config) bool {
 	for _, epic := range plan.Epics {
 		for _, role := range epic.RoleBeads {
 			hasRoles = true
-			switch normalizeRoleStatus(cfg.Roles[role.BeadID].Status) {
-			case "complete", "failed":
-				continue
-			default:
+			if !roleStatusTerminal(normalizeRoleStatus(cfg.Roles[role.BeadID].Status)) {
 				return false
 			}
 		}
@@ -376,6 +1015,90 @@ func allRolesTerminal(plan *teams.AuditPlan, cfg *config.Config) bool {
 	return hasRoles
 }
 
-func roleWindowName(auditTypeID, codeName string) string {
-	return "audit-" + strings.TrimSpace(auditTypeID) + "-" + strings.TrimSpace(codeName)
+// anyRetryScheduled reports whether a failed role is waiting out its retry backoff.
+func anyRetryScheduled(plan *teams.AuditPlan, cfg *config.Config) bool {
+	for _, epic := range plan.Epics {
+		for _, role := range epic.RoleBeads {
+			state := cfg.Roles[role.BeadID]
+			if normalizeRoleStatus(state.Status) == "pending" && strings.TrimSpace(state.RetryAt) != "" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// anyPaused reports whether a paused epic or role is holding back pending work.
+func anyPaused(plan *teams.AuditPlan, cfg *config.Config) bool {
+	for _, epic := range plan.Epics {
+		epicPaused := cfg.Epics[strings.TrimSpace(epic.BeadID)].Paused
+		for _, role := range epic.RoleBeads {
+			state := cfg.Roles[role.BeadID]
+			if normalizeRoleStatus(state.Status) == "pending" && (epicPaused || state.Paused) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// roleStatusTerminal reports whether a role status can no longer change on its own.
+func roleStatusTerminal(status string) bool {
+	switch status {
+	case "complete", "failed", "cancelled", "skipped":
+		return true
+	default:
+		return false
+	}
+}
+
+// epicStatusTerminal reports whether an epic status can no longer change on its own.
+func epicStatusTerminal(status string) bool {
+	switch status {
+	case "complete", "failed", "cancelled":
+		return true
+	default:
+		return false
+	}
+}
+
+func anyRoleRunning(plan *teams.AuditPlan, cfg *config.Config) bool {
+	for _, epic := range plan.Epics {
+		for _, role := range epic.RoleBeads {
+			switch normalizeRoleStatus(cfg.Roles[role.BeadID].Status) {
+			case "running", "stalled":
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// roleWindowName names a role's tmux window after its epic bead, e.g. audit-plan-001-alpha.
+func roleWindowName(epicBeadID, codeName string) string {
+	return strings.TrimSpace(epicBeadID) + "-" + strings.TrimSpace(codeName)
+}
+
+// roleAttemptWindowName names the window for one attempt of a role. The first
+// attempt uses roleWindowName; retries add a suffix so earlier windows stay open,
+// e.g. audit-plan-001-alpha-attempt2.
+func roleAttemptWindowName(epicBeadID, codeName string, attempt int) string {
+	if attempt <= 1 {
+		return roleWindowName(epicBeadID, codeName)
+	}
+
+	return fmt.Sprintf("%s-attempt%d", roleWindowName(epicBeadID, codeName), attempt)
+}
+
+// roleStateWindowName returns the window recorded for a running role, falling back
+// to the epic-based name. Roles launched by older versions keep their recorded window.
+func roleStateWindowName(state config.RoleState, epicBeadID string) string {
+	if _, window, ok := strings.Cut(strings.TrimSpace(state.TmuxWindow), ":"); ok && window != "" {
+		return window
+	}
+
+	return roleWindowName(epicBeadID, state.CodeName)
 }
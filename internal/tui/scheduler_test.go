@@ -1,12 +1,16 @@
 package tui
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"lattice/internal/config"
+	"lattice/internal/panelog"
 	"lattice/internal/teams"
 )
 
@@ -17,21 +21,32 @@ func TestCheckAndAdvanceRolesRunningCompletesThenNextLaunches(t *testing.T) {
 	cfg := baseSchedulerConfig()
 	plan := twoRolePlan("perf", "perf-alpha", "perf-bravo")
 
-	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Title: "Alpha", Guidance: "A", BeadPrefix: "perf-alpha", Order: 1, Status: "running", TmuxWindow: "sess:audit-perf-alpha", Intensity: 2}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Title: "Alpha", Guidance: "A", BeadPrefix: "perf-alpha", Order: 1, Status: "running", TmuxWindow: "sess:e1-alpha", Intensity: 2, Log: "e1-alpha", Timing: config.Timing{LaunchedAt: "2026-02-13T00:32:03Z"}}
 	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Title: "Bravo", Guidance: "B", BeadPrefix: "perf-bravo", Order: 2, Status: "pending", Intensity: 2}
-	cfg.Epics["perf"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running", Agent: "claude", Timing: config.Timing{LaunchedAt: "2026-02-13T00:32:03Z"}}
+	cfg.Session.Agents = map[string]config.AgentRuntime{"claude": {Command: "claude -p {{.Prompt}}"}}
 
 	writeRoleTeamStatus(t, cwd, "perf-alpha", "complete")
+	logPath, rawPath := panelog.Paths(cwd, cfg.Session.RunID, "e1-alpha")
+	if err := os.MkdirAll(filepath.Dir(rawPath), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(rawPath, []byte("\x1b[1mdone\x1b[0m\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
 
 	manager := &fakeLaunchTmuxManager{}
+	var runtimes []string
 	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, SchedulerDeps{
 		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) {
+			runtimes = append(runtimes, params.Runtime.Name())
 			return filepath.Join(params.Cwd, config.DirName, "teams", params.AuditTypeID+"-"+params.CodeName), nil
 		},
-		TranslatePath: func(path string) (string, error) { return path, nil },
-		TmuxManager:   manager,
-		CheckTmuxWindow: func(sessionName, windowName string) bool {
-			return false
+		Executor: &fakeExecutor{
+			manager: manager,
+			alive: func(sessionName, windowName string) bool {
+				return false
+			},
 		},
 		Now: func() time.Time { return time.Date(2026, time.February, 13, 1, 2, 3, 0, time.UTC) },
 	})
@@ -45,15 +60,39 @@ func TestCheckAndAdvanceRolesRunningCompletesThenNextLaunches(t *testing.T) {
 	if len(res.Launched) != 1 || res.Launched[0].RoleBeadID != "r2" {
 		t.Fatalf("unexpected launched roles: %#v", res.Launched)
 	}
+	if strings.Join(runtimes, ",") != "claude" {
+		t.Fatalf("expected r2 to launch with the epic's runtime, got %v", runtimes)
+	}
 	if cfg.Roles["r1"].Status != "complete" {
 		t.Fatalf("expected r1 complete, got %q", cfg.Roles["r1"].Status)
 	}
 	if cfg.Roles["r2"].Status != "running" {
 		t.Fatalf("expected r2 running, got %q", cfg.Roles["r2"].Status)
 	}
-	if len(manager.windowCalls) != 1 || manager.windowCalls[0] != "sess:audit-perf-bravo" {
+	if got := cfg.Roles["r1"].Timing; got.CompletedAt != "2026-02-13T01:02:03Z" || got.Duration != "30m0s" {
+		t.Fatalf("expected r1 completion timing, got %+v", got)
+	}
+	if transcript, err := os.ReadFile(logPath); err != nil || string(transcript) != "done\n" {
+		t.Fatalf("expected r1's stripped transcript on completion, got %q, %v", transcript, err)
+	}
+	if got := cfg.Roles["r2"].Timing; got.LaunchedAt != "2026-02-13T01:02:03Z" || got.Duration != "" {
+		t.Fatalf("expected r2 launch timing, got %+v", got)
+	}
+	if got := cfg.Epics["e1"].Timing; got.LaunchedAt != "2026-02-13T00:32:03Z" || got.CompletedAt != "" {
+		t.Fatalf("expected epic to keep its launch time, got %+v", got)
+	}
+	if len(manager.windowCalls) != 1 || manager.windowCalls[0] != "sess:e1-bravo" {
 		t.Fatalf("unexpected window calls: %#v", manager.windowCalls)
 	}
+
+	var transitions []string
+	for _, event := range res.Transitions {
+		transitions = append(transitions, fmt.Sprintf("%s %s->%s %s", event.RoleID, event.From, event.To, event.Message))
+	}
+	want := "r1 running->complete |r2 pending->running attempt 1 in sess:e1-bravo"
+	if got := strings.Join(transitions, "|"); got != want {
+		t.Fatalf("unexpected transitions:\n got %q\nwant %q", got, want)
+	}
 }
 
 func TestCheckAndAdvanceRolesWindowGoneWithoutCompleteMarksFailed(t *testing.T) {
@@ -64,14 +103,14 @@ func TestCheckAndAdvanceRolesWindowGoneWithoutCompleteMarksFailed(t *testing.T)
 	plan := oneRolePlan("perf", "perf-alpha")
 
 	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Title: "Alpha", Guidance: "A", BeadPrefix: "perf-alpha", Order: 1, Status: "running"}
-	cfg.Epics["perf"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
 
 	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, SchedulerDeps{
 		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) { return "", nil },
-		TranslatePath:       func(path string) (string, error) { return path, nil },
-		TmuxManager:         &fakeLaunchTmuxManager{},
-		CheckTmuxWindow:     func(sessionName, windowName string) bool { return false },
-		Now:                 time.Now,
+		Executor: &fakeExecutor{
+			alive: func(sessionName, windowName string) bool { return false },
+		},
+		Now: time.Now,
 	})
 	if err != nil {
 		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
@@ -83,8 +122,8 @@ func TestCheckAndAdvanceRolesWindowGoneWithoutCompleteMarksFailed(t *testing.T)
 	if cfg.Roles["r1"].Status != "failed" {
 		t.Fatalf("expected r1 failed, got %q", cfg.Roles["r1"].Status)
 	}
-	if cfg.Epics["perf"].Status != "failed" {
-		t.Fatalf("expected epic failed, got %q", cfg.Epics["perf"].Status)
+	if cfg.Epics["e1"].Status != "failed" {
+		t.Fatalf("expected epic failed, got %q", cfg.Epics["e1"].Status)
 	}
 }
 
@@ -96,15 +135,15 @@ func TestCheckAndAdvanceRolesLastRoleCompleteSetsAllDone(t *testing.T) {
 	plan := oneRolePlan("perf", "perf-alpha")
 
 	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Title: "Alpha", Guidance: "A", BeadPrefix: "perf-alpha", Order: 1, Status: "running"}
-	cfg.Epics["perf"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
 	writeRoleTeamStatus(t, cwd, "perf-alpha", "complete")
 
 	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, SchedulerDeps{
 		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) { return "", nil },
-		TranslatePath:       func(path string) (string, error) { return path, nil },
-		TmuxManager:         &fakeLaunchTmuxManager{},
-		CheckTmuxWindow:     func(sessionName, windowName string) bool { return false },
-		Now:                 time.Now,
+		Executor: &fakeExecutor{
+			alive: func(sessionName, windowName string) bool { return false },
+		},
+		Now: time.Now,
 	})
 	if err != nil {
 		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
@@ -113,8 +152,24 @@ func TestCheckAndAdvanceRolesLastRoleCompleteSetsAllDone(t *testing.T) {
 	if !res.AllDone {
 		t.Fatalf("expected AllDone=true")
 	}
-	if cfg.Epics["perf"].Status != "complete" {
-		t.Fatalf("expected epic complete, got %q", cfg.Epics["perf"].Status)
+	if cfg.Epics["e1"].Status != "complete" {
+		t.Fatalf("expected epic complete, got %q", cfg.Epics["e1"].Status)
+	}
+	if len(res.EpicsFinished) != 1 || res.EpicsFinished[0] != "e1" || !res.RunCompleted || cfg.Session.CompletedAt == "" {
+		t.Fatalf("expected epic and run completion to be reported, got %+v", res)
+	}
+
+	res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, SchedulerDeps{
+		Executor: &fakeExecutor{
+			alive: func(sessionName, windowName string) bool { return false },
+		},
+		Now: time.Now,
+	})
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.EpicsFinished) != 0 || res.RunCompleted {
+		t.Fatalf("expected completion to be reported once, got %+v", res)
 	}
 }
 
@@ -127,17 +182,18 @@ func TestCheckAndAdvanceRolesFailureBlocksSubsequentPending(t *testing.T) {
 
 	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Title: "Alpha", Guidance: "A", BeadPrefix: "perf-alpha", Order: 1, Status: "running"}
 	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Title: "Bravo", Guidance: "B", BeadPrefix: "perf-bravo", Order: 2, Status: "pending"}
-	cfg.Epics["perf"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
 
 	manager := &fakeLaunchTmuxManager{}
 	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, SchedulerDeps{
 		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) {
 			return filepath.Join(params.Cwd, config.DirName, "teams", params.AuditTypeID+"-"+params.CodeName), nil
 		},
-		TranslatePath:   func(path string) (string, error) { return path, nil },
-		TmuxManager:     manager,
-		CheckTmuxWindow: func(sessionName, windowName string) bool { return false },
-		Now:             time.Now,
+		Executor: &fakeExecutor{
+			manager: manager,
+			alive:   func(sessionName, windowName string) bool { return false },
+		},
+		Now: time.Now,
 	})
 	if err != nil {
 		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
@@ -166,17 +222,18 @@ func TestCheckAndAdvanceRolesIdempotentNoDuplicateLaunch(t *testing.T) {
 
 	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Title: "Alpha", Guidance: "A", BeadPrefix: "perf-alpha", Order: 1, Status: "complete"}
 	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Title: "Bravo", Guidance: "B", BeadPrefix: "perf-bravo", Order: 2, Status: "pending"}
-	cfg.Epics["perf"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
 
 	manager := &fakeLaunchTmuxManager{}
 	deps := SchedulerDeps{
 		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) {
 			return filepath.Join(params.Cwd, config.DirName, "teams", params.AuditTypeID+"-"+params.CodeName), nil
 		},
-		TranslatePath:   func(path string) (string, error) { return path, nil },
-		TmuxManager:     manager,
-		CheckTmuxWindow: func(sessionName, windowName string) bool { return windowName == "audit-perf-bravo" },
-		Now:             time.Now,
+		Executor: &fakeExecutor{
+			manager: manager,
+			alive:   func(sessionName, windowName string) bool { return windowName == "e1-bravo" },
+		},
+		Now: time.Now,
 	}
 
 	first, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
@@ -207,14 +264,14 @@ func TestCheckAndAdvanceRolesCrashRecoveryMarksFailed(t *testing.T) {
 	plan := oneRolePlan("perf", "perf-alpha")
 
 	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Title: "Alpha", Guidance: "A", BeadPrefix: "perf-alpha", Order: 1, Status: "running"}
-	cfg.Epics["perf"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
 
 	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, SchedulerDeps{
 		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) { return "", nil },
-		TranslatePath:       func(path string) (string, error) { return path, nil },
-		TmuxManager:         &fakeLaunchTmuxManager{},
-		CheckTmuxWindow:     func(sessionName, windowName string) bool { return false },
-		Now:                 time.Now,
+		Executor: &fakeExecutor{
+			alive: func(sessionName, windowName string) bool { return false },
+		},
+		Now: time.Now,
 	})
 	if err != nil {
 		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
@@ -242,8 +299,8 @@ func TestCheckAndAdvanceRolesMultipleEpicsAdvanceIndependently(t *testing.T) {
 	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Title: "Bravo", Guidance: "B", BeadPrefix: "perf-bravo", Order: 2, Status: "pending"}
 	cfg.Roles["r3"] = config.RoleState{BeadID: "r3", EpicBeadID: "e2", CodeName: "alpha", Title: "Alpha", Guidance: "A", BeadPrefix: "mem-alpha", Order: 1, Status: "running"}
 	cfg.Roles["r4"] = config.RoleState{BeadID: "r4", EpicBeadID: "e2", CodeName: "bravo", Title: "Bravo", Guidance: "B", BeadPrefix: "mem-bravo", Order: 2, Status: "pending"}
-	cfg.Epics["perf"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
-	cfg.Epics["memleak"] = config.EpicState{BeadID: "e2", AuditType: "memleak", AuditName: "Memory", Status: "running"}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
+	cfg.Epics["e2"] = config.EpicState{BeadID: "e2", AuditType: "memleak", AuditName: "Memory", Status: "running"}
 
 	writeRoleTeamStatus(t, cwd, "perf-alpha", "complete")
 
@@ -252,10 +309,11 @@ func TestCheckAndAdvanceRolesMultipleEpicsAdvanceIndependently(t *testing.T) {
 		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) {
 			return filepath.Join(params.Cwd, config.DirName, "teams", params.AuditTypeID+"-"+params.CodeName), nil
 		},
-		TranslatePath: func(path string) (string, error) { return path, nil },
-		TmuxManager:   manager,
-		CheckTmuxWindow: func(sessionName, windowName string) bool {
-			return windowName == "audit-memleak-alpha"
+		Executor: &fakeExecutor{
+			manager: manager,
+			alive: func(sessionName, windowName string) bool {
+				return windowName == "e2-alpha"
+			},
 		},
 		Now: time.Now,
 	})
@@ -272,9 +330,239 @@ func TestCheckAndAdvanceRolesMultipleEpicsAdvanceIndependently(t *testing.T) {
 	if cfg.Roles["r4"].Status != "pending" {
 		t.Fatalf("expected memleak r4 pending, got %q", cfg.Roles["r4"].Status)
 	}
-	if len(manager.windowCalls) != 1 || manager.windowCalls[0] != "sess:audit-perf-bravo" {
+	if len(manager.windowCalls) != 1 || manager.windowCalls[0] != "sess:e1-bravo" {
+		t.Fatalf("unexpected windows: %#v", manager.windowCalls)
+	}
+}
+
+func TestCheckAndAdvanceRolesDeadPaneCompletesWithExitCode(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	cfg := baseSchedulerConfig()
+	plan := oneRolePlan("perf", "perf-alpha")
+
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Title: "Alpha", Guidance: "A", BeadPrefix: "perf-alpha", Order: 1, Status: "running", TmuxWindow: "sess:e1-alpha"}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
+	writeRoleTeamStatus(t, cwd, "perf-alpha", "complete")
+
+	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, SchedulerDeps{
+		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) { return "", nil },
+		Executor: &fakeExecutor{
+			alive:      func(sessionName, windowName string) bool { return true },
+			exitStatus: func(sessionName, windowName string) (int, bool) { return 0, true },
+		},
+		Now: func() time.Time { return time.Date(2026, time.February, 13, 1, 2, 3, 0, time.UTC) },
+	})
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+
+	if len(res.Completed) != 1 || res.Completed[0] != "r1" {
+		t.Fatalf("unexpected completed roles: %#v", res.Completed)
+	}
+	role := cfg.Roles["r1"]
+	if role.Status != "complete" || role.ExitCode == nil || *role.ExitCode != 0 {
+		t.Fatalf("expected complete role with exit code 0, got %+v", role)
+	}
+	if role.ExitedAt != "2026-02-13T01:02:03Z" {
+		t.Fatalf("unexpected exited_at: %q", role.ExitedAt)
+	}
+}
+
+func TestCheckAndAdvanceRolesExitFileMarksFailedWhileWindowOpen(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	cfg := baseSchedulerConfig()
+	plan := oneRolePlan("perf", "perf-alpha")
+
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Title: "Alpha", Guidance: "A", BeadPrefix: "perf-alpha", Order: 1, Status: "running", TmuxWindow: "sess:e1-alpha"}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
+	writeRoleTeamStatus(t, cwd, "perf-alpha", "active")
+	exitPath := filepath.Join(cwd, config.DirName, "teams", "perf-alpha", teams.RoleExitFile)
+	if err := os.WriteFile(exitPath, []byte("exit_code=1\nended_at=2026-02-13T00:59:00Z\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, SchedulerDeps{
+		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) { return "", nil },
+		Executor: &fakeExecutor{
+			alive:      func(sessionName, windowName string) bool { return true },
+			exitStatus: func(sessionName, windowName string) (int, bool) { return 0, false },
+		},
+		Now: time.Now,
+	})
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+
+	if len(res.Failed) != 1 || res.Failed[0] != "r1" {
+		t.Fatalf("unexpected failed roles: %#v", res.Failed)
+	}
+	role := cfg.Roles["r1"]
+	if role.ExitCode == nil || *role.ExitCode != 1 || role.ExitedAt != "2026-02-13T00:59:00Z" {
+		t.Fatalf("expected exit record from wrapper, got %+v", role)
+	}
+}
+
+func TestCheckAndAdvanceRolesLivePaneKeepsRunning(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	cfg := baseSchedulerConfig()
+	plan := oneRolePlan("perf", "perf-alpha")
+
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Title: "Alpha", Guidance: "A", BeadPrefix: "perf-alpha", Order: 1, Status: "running", TmuxWindow: "sess:e1-alpha"}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", AuditName: "Performance", Status: "running"}
+
+	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, SchedulerDeps{
+		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) { return "", nil },
+		Executor: &fakeExecutor{
+			alive:      func(sessionName, windowName string) bool { return true },
+			exitStatus: func(sessionName, windowName string) (int, bool) { return 0, false },
+		},
+		Now: time.Now,
+	})
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+
+	if len(res.Completed) != 0 || len(res.Failed) != 0 || res.Blocked {
+		t.Fatalf("expected no transitions, got %+v", res)
+	}
+	if cfg.Roles["r1"].Status != "running" {
+		t.Fatalf("expected r1 running, got %q", cfg.Roles["r1"].Status)
+	}
+}
+
+func TestCheckAndAdvanceRolesKeepsSameTypeEpicsApart(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	cfg := baseSchedulerConfig()
+	plan := &teams.AuditPlan{}
+	for _, epic := range []struct{ id, target string }{{"e1", "internal/api"}, {"e2", "internal/auth"}} {
+		plan.Epics = append(plan.Epics, teams.EpicBead{
+			BeadID:    epic.id,
+			AuditType: teams.AuditType{ID: "security", Name: "Security"},
+			Target:    epic.target,
+			RoleBeads: []teams.RoleBead{
+				{BeadID: epic.id + "-r1", CodeName: "alpha", Title: "Alpha", BeadPrefix: "sec-" + epic.id + "-alpha", Order: 1},
+				{BeadID: epic.id + "-r2", CodeName: "bravo", Title: "Bravo", BeadPrefix: "sec-" + epic.id + "-bravo", Order: 2},
+			},
+		})
+		cfg.Epics[epic.id] = config.EpicState{BeadID: epic.id, AuditType: "security", Target: epic.target, Status: "running"}
+		cfg.Roles[epic.id+"-r1"] = config.RoleState{BeadID: epic.id + "-r1", EpicBeadID: epic.id, CodeName: "alpha", BeadPrefix: "sec-" + epic.id + "-alpha", Order: 1, Status: "running", TmuxWindow: "sess:" + epic.id + "-alpha"}
+		cfg.Roles[epic.id+"-r2"] = config.RoleState{BeadID: epic.id + "-r2", EpicBeadID: epic.id, CodeName: "bravo", BeadPrefix: "sec-" + epic.id + "-bravo", Order: 2, Status: "pending", Intensity: 1}
+		writeRoleTeamStatus(t, cwd, epic.id+"-alpha", "complete")
+	}
+
+	manager := &fakeLaunchTmuxManager{}
+	var targets []string
+	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, SchedulerDeps{
+		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) {
+			targets = append(targets, params.Target)
+			return filepath.Join(params.Cwd, config.DirName, "teams", params.EpicBeadID+"-"+params.CodeName), nil
+		},
+		Executor: &fakeExecutor{
+			manager: manager,
+			alive:   func(sessionName, windowName string) bool { return false },
+		},
+		Now: time.Now,
+	})
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+
+	if len(res.Completed) != 2 || len(res.Launched) != 2 {
+		t.Fatalf("expected both epics to advance, got completed=%#v launched=%#v", res.Completed, res.Launched)
+	}
+	wantWindows := []string{"sess:e1-bravo", "sess:e2-bravo"}
+	if strings.Join(manager.windowCalls, ",") != strings.Join(wantWindows, ",") {
 		t.Fatalf("unexpected windows: %#v", manager.windowCalls)
 	}
+	if strings.Join(targets, ",") != "internal/api,internal/auth" {
+		t.Fatalf("expected later roles to keep their epic target, got %#v", targets)
+	}
+	if len(cfg.Epics) != 2 {
+		t.Fatalf("expected epics to stay keyed by bead id, got %#v", cfg.Epics)
+	}
+}
+
+func TestCheckAndAdvanceRolesUsesRecordedWindowForRunningRole(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	cfg := baseSchedulerConfig()
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", BeadPrefix: "perf-alpha", Order: 1, Status: "running", TmuxWindow: "sess:audit-perf-alpha"}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", Status: "running"}
+
+	var checked []string
+	_, err := CheckAndAdvanceRoles(cwd, cfg, "sess", oneRolePlan("perf", "perf-alpha"), SchedulerDeps{
+		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) { return "", nil },
+		Executor: &fakeExecutor{
+			alive: func(sessionName, windowName string) bool {
+				checked = append(checked, windowName)
+				return true
+			},
+			exitStatus: func(sessionName, windowName string) (int, bool) { return 0, false },
+		},
+		Now: time.Now,
+	})
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+
+	if len(checked) != 1 || checked[0] != "audit-perf-alpha" {
+		t.Fatalf("expected recorded window to be checked, got %#v", checked)
+	}
+	if cfg.Roles["r1"].Status != "running" {
+		t.Fatalf("expected role to stay running, got %q", cfg.Roles["r1"].Status)
+	}
+}
+
+func TestCheckAndAdvanceRolesHandsPriorReportToNextRole(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	cfg := baseSchedulerConfig()
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Title: "Alpha", BeadPrefix: "perf-alpha", Order: 1, Status: "complete"}
+	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Title: "Bravo", BeadPrefix: "perf-bravo", Order: 2, Status: "pending", Intensity: 1}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", Status: "running"}
+
+	reportDir := filepath.Join(cwd, config.DirName, "teams", "e1-alpha", "context")
+	if err := os.MkdirAll(reportDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "REPORT.md"), []byte("Opened perf-alpha-x1 for the hot loop.\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	var got []teams.PriorWork
+	_, err := CheckAndAdvanceRoles(cwd, cfg, "sess", twoRolePlan("perf", "perf-alpha", "perf-bravo"), SchedulerDeps{
+		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) {
+			got = params.PriorWork
+			return filepath.Join(params.Cwd, config.DirName, "teams", params.EpicBeadID+"-"+params.CodeName), nil
+		},
+		Executor: &fakeExecutor{
+			alive: func(sessionName, windowName string) bool { return false },
+		},
+		Now: time.Now,
+	})
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("expected prior work from one role, got %#v", got)
+	}
+	if got[0].CodeName != "alpha" || !strings.Contains(got[0].Report, "hot loop") {
+		t.Fatalf("unexpected prior work: %+v", got[0])
+	}
+	if len(got[0].BeadIDs) != 1 || got[0].BeadIDs[0] != "perf-alpha-x1" {
+		t.Fatalf("unexpected prior bead ids: %#v", got[0].BeadIDs)
+	}
 }
 
 func baseSchedulerConfig() *config.Config {
@@ -319,3 +607,555 @@ func writeRoleTeamStatus(t *testing.T, cwd, dirName, status string) {
 		t.Fatalf("WriteFile() error = %v", err)
 	}
 }
+
+func TestCheckAndAdvanceRolesLaunchesIndependentRolesTogether(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	cfg := baseSchedulerConfig()
+	plan := &teams.AuditPlan{Epics: []teams.EpicBead{
+		{
+			BeadID:    "e1",
+			AuditType: teams.AuditType{ID: "sec", Name: "Security"},
+			RoleBeads: []teams.RoleBead{
+				{BeadID: "r1", CodeName: "modeler", Title: "Modeler", BeadPrefix: "sec-modeler", Order: 1},
+				{BeadID: "r2", CodeName: "alpha", Title: "Alpha", BeadPrefix: "sec-alpha", Order: 2, After: []string{"modeler"}},
+				{BeadID: "r3", CodeName: "bravo", Title: "Bravo", BeadPrefix: "sec-bravo", Order: 3, After: []string{"modeler"}},
+				{BeadID: "r4", CodeName: "reviewer", Title: "Reviewer", BeadPrefix: "sec-reviewer", Order: 4, After: []string{"alpha", "bravo"}},
+			},
+		},
+	}}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "modeler", Order: 1, Status: "complete"}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "sec", Status: "running"}
+
+	priorWork := map[string][]teams.PriorWork{}
+	deps := SchedulerDeps{
+		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) {
+			priorWork[params.CodeName] = params.PriorWork
+			return filepath.Join(params.Cwd, config.DirName, "teams", params.EpicBeadID+"-"+params.CodeName), nil
+		},
+		Executor: &fakeExecutor{
+			alive:      func(sessionName, windowName string) bool { return true },
+			exitStatus: func(sessionName, windowName string) (int, bool) { return 0, false },
+		},
+		Now: time.Now,
+	}
+
+	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Launched) != 2 || res.Launched[0].RoleBeadID != "r2" || res.Launched[1].RoleBeadID != "r3" {
+		t.Fatalf("expected alpha and bravo to launch together, got %#v", res.Launched)
+	}
+	if cfg.Roles["r4"].Status != "pending" {
+		t.Fatalf("expected reviewer to wait, got %q", cfg.Roles["r4"].Status)
+	}
+
+	alpha := cfg.Roles["r2"]
+	alpha.Status = "complete"
+	cfg.Roles["r2"] = alpha
+	if res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps); err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Launched) != 0 {
+		t.Fatalf("expected reviewer to wait for bravo, got %#v", res.Launched)
+	}
+
+	bravo := cfg.Roles["r3"]
+	bravo.Status = "complete"
+	cfg.Roles["r3"] = bravo
+	if res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps); err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Launched) != 1 || res.Launched[0].RoleBeadID != "r4" {
+		t.Fatalf("expected reviewer to launch, got %#v", res.Launched)
+	}
+	if got := priorWork["reviewer"]; len(got) != 2 || got[0].CodeName != "alpha" || got[1].CodeName != "bravo" {
+		t.Fatalf("expected prior work from alpha and bravo, got %#v", got)
+	}
+	if got := strings.Join(cfg.Roles["r4"].After, ","); got != "alpha,bravo" {
+		t.Fatalf("expected reviewer dependencies recorded, got %q", got)
+	}
+}
+
+func TestCheckAndAdvanceRolesHoldsEpicUntilUpstreamCompletes(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	cfg := baseSchedulerConfig()
+	plan := &teams.AuditPlan{Epics: []teams.EpicBead{
+		{
+			BeadID:    "e2",
+			AuditType: teams.AuditType{ID: "errors", Name: "Error Handling"},
+			RoleBeads: []teams.RoleBead{{BeadID: "r2", CodeName: "alpha", BeadPrefix: "err-alpha", Order: 1}},
+			DependsOn: []string{"e1"},
+		},
+		{
+			BeadID:    "e1",
+			AuditType: teams.AuditType{ID: "maint", Name: "Maintainability"},
+			RoleBeads: []teams.RoleBead{{BeadID: "r1", CodeName: "alpha", BeadPrefix: "maint-alpha", Order: 1}},
+		},
+	}}
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "maint", Status: "running"}
+	cfg.Epics["e2"] = config.EpicState{BeadID: "e2", AuditType: "errors", Status: "pending", DependsOn: []string{"e1"}}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Order: 1, Status: "running", TmuxWindow: "sess:e1-alpha"}
+	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e2", CodeName: "alpha", Order: 1, Status: "pending"}
+
+	paneDead := false
+	deps := SchedulerDeps{
+		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) {
+			return filepath.Join(params.Cwd, config.DirName, "teams", params.EpicBeadID+"-"+params.CodeName), nil
+		},
+		Executor: &fakeExecutor{
+			alive:      func(sessionName, windowName string) bool { return true },
+			exitStatus: func(sessionName, windowName string) (int, bool) { return 0, paneDead },
+		},
+		Now: time.Now,
+	}
+
+	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Launched) != 0 || cfg.Epics["e2"].Status != "pending" {
+		t.Fatalf("expected downstream epic to wait, launched %#v, status %q", res.Launched, cfg.Epics["e2"].Status)
+	}
+
+	writeRoleTeamStatus(t, cwd, "e1-alpha", "complete")
+	paneDead = true
+	res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Launched) != 1 || res.Launched[0].RoleBeadID != "r2" || res.Blocked {
+		t.Fatalf("expected downstream epic to start in the same pass, got %+v", res)
+	}
+	if cfg.Epics["e2"].Status != "running" {
+		t.Fatalf("expected downstream epic running, got %q", cfg.Epics["e2"].Status)
+	}
+}
+
+func TestCheckAndAdvanceRolesBlocksEpicWhenUpstreamFails(t *testing.T) {
+	t.Parallel()
+
+	cfg := baseSchedulerConfig()
+	plan := &teams.AuditPlan{Epics: []teams.EpicBead{
+		{
+			BeadID:    "e1",
+			AuditType: teams.AuditType{ID: "maint", Name: "Maintainability"},
+			RoleBeads: []teams.RoleBead{{BeadID: "r1", CodeName: "alpha", BeadPrefix: "maint-alpha", Order: 1}},
+		},
+		{
+			BeadID:    "e2",
+			AuditType: teams.AuditType{ID: "errors", Name: "Error Handling"},
+			RoleBeads: []teams.RoleBead{{BeadID: "r2", CodeName: "alpha", BeadPrefix: "err-alpha", Order: 1}},
+			DependsOn: []string{"e1"},
+		},
+	}}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Order: 1, Status: "failed"}
+	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e2", CodeName: "alpha", Order: 1, Status: "pending"}
+
+	res, err := CheckAndAdvanceRoles(t.TempDir(), cfg, "sess", plan, SchedulerDeps{
+		Executor: &fakeExecutor{
+			alive: func(sessionName, windowName string) bool { return false },
+		},
+		Now: time.Now,
+	})
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Launched) != 0 || !res.Blocked {
+		t.Fatalf("expected no launch and a blocked run, got %+v", res)
+	}
+	if cfg.Epics["e2"].Status != "blocked" || cfg.Roles["r2"].Status != "pending" {
+		t.Fatalf("expected blocked epic with pending role, got epic %q role %q", cfg.Epics["e2"].Status, cfg.Roles["r2"].Status)
+	}
+}
+
+func TestCheckAndAdvanceRolesQueuesRolesBeyondConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	cfg := baseSchedulerConfig()
+	cfg.Session.MaxConcurrentRoles = 2
+	plan := &teams.AuditPlan{}
+	for _, epicID := range []string{"e1", "e2", "e3"} {
+		plan.Epics = append(plan.Epics, teams.EpicBead{
+			BeadID:    epicID,
+			AuditType: teams.AuditType{ID: "type-" + epicID},
+			RoleBeads: []teams.RoleBead{
+				{BeadID: epicID + "-r1", CodeName: "alpha", Order: 1},
+				{BeadID: epicID + "-r2", CodeName: "bravo", Order: 2},
+			},
+		})
+	}
+	cfg.Roles["e1-r1"] = config.RoleState{BeadID: "e1-r1", EpicBeadID: "e1", CodeName: "alpha", Order: 1, Status: "running", TmuxWindow: "sess:e1-alpha"}
+
+	running := map[string]bool{"e1-alpha": true}
+	deps := SchedulerDeps{
+		GenerateRoleSession: func(params teams.RoleSessionParams) (string, error) {
+			return filepath.Join(params.Cwd, config.DirName, "teams", params.EpicBeadID+"-"+params.CodeName), nil
+		},
+		Executor: &fakeExecutor{
+			alive:      func(sessionName, windowName string) bool { return running[windowName] },
+			exitStatus: func(sessionName, windowName string) (int, bool) { return 0, false },
+		},
+		Now: time.Now,
+	}
+
+	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Launched) != 1 || res.Launched[0].RoleBeadID != "e2-r1" {
+		t.Fatalf("expected only e2 alpha to fill the free slot, got %#v", res.Launched)
+	}
+	if got := cfg.Roles["e3-r1"]; got.Status != "pending" || got.QueuePosition != 1 {
+		t.Fatalf("expected e3 alpha queued first, got %+v", got)
+	}
+	if res.Blocked {
+		t.Fatal("expected a run with running roles not to be blocked")
+	}
+
+	running["e2-alpha"] = true
+	delete(running, "e1-alpha")
+	writeRoleTeamStatus(t, cwd, "e1-alpha", "complete")
+	res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Launched) != 1 || res.Launched[0].RoleBeadID != "e1-r2" {
+		t.Fatalf("expected e1 bravo to take the freed slot, got %#v", res.Launched)
+	}
+	if got := cfg.Roles["e3-r1"]; got.Status != "pending" || got.QueuePosition != 1 {
+		t.Fatalf("expected e3 alpha to stay at the head of the queue, got %+v", got)
+	}
+	if got := cfg.Roles["e1-r2"].QueuePosition; got != 0 {
+		t.Fatalf("expected launched role to leave the queue, got position %d", got)
+	}
+}
+
+func TestRoundRobinInterleavesEpics(t *testing.T) {
+	t.Parallel()
+
+	queue := roundRobin([][]string{{"a1", "a2", "a3"}, {"b1"}, {"c1", "c2"}}, []int{1, 0, 0})
+	if got := strings.Join(queue, " "); got != "b1 c1 a1 c2 a2 a3" {
+		t.Fatalf("unexpected queue order: %s", got)
+	}
+}
+
+func TestCheckAndAdvanceRolesRetriesFailedRoleAfterBackoff(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	cfg := baseSchedulerConfig()
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", Status: "running", Retry: config.RetryPolicy{MaxAttempts: 2, Backoff: "1m", Resume: true}}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Order: 1, Intensity: 3, Status: "running", TmuxWindow: "sess:e1-alpha", Attempt: 1}
+	plan := oneRolePlan("perf", "perf-r1")
+	writeRoleTeamStatus(t, cwd, "e1-alpha", "active\ncurrent_loop=2")
+
+	start := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+	now := start
+	var params []teams.RoleSessionParams
+	deps := SchedulerDeps{
+		GenerateRoleSession: func(p teams.RoleSessionParams) (string, error) {
+			params = append(params, p)
+			return filepath.Join(p.Cwd, config.DirName, "teams", p.EpicBeadID+"-"+p.CodeName), nil
+		},
+		Executor: &fakeExecutor{
+			alive:      func(_, _ string) bool { return false },
+			exitStatus: func(_, _ string) (int, bool) { return 0, false },
+		},
+		Now: func() time.Time { return now },
+	}
+
+	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Failed) != 0 || len(res.Retried) != 1 || res.Retried[0].NextAttempt != 2 || !res.Retried[0].RetryAt.Equal(start.Add(time.Minute)) {
+		t.Fatalf("expected one retry scheduled a minute out, got %+v", res)
+	}
+	if res.Blocked || res.AllDone {
+		t.Fatalf("expected run waiting on a retry to be neither blocked nor done, got %+v", res)
+	}
+	if got := cfg.Roles["r1"]; got.Status != "pending" || len(got.Attempts) != 1 || got.Attempts[0].TmuxWindow != "sess:e1-alpha" {
+		t.Fatalf("expected role pending with its first attempt recorded, got %+v", got)
+	}
+
+	now = start.Add(30 * time.Second)
+	if res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps); err != nil || len(res.Launched) != 0 {
+		t.Fatalf("expected no launch before the backoff passes, got %+v, %v", res.Launched, err)
+	}
+
+	now = start.Add(time.Minute)
+	res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Launched) != 1 || res.Launched[0].WindowName != "e1-alpha-attempt2" || res.Launched[0].Attempt != 2 {
+		t.Fatalf("expected second attempt in its own window, got %+v", res.Launched)
+	}
+	if len(params) != 1 || params[0].StartLoop != 2 {
+		t.Fatalf("expected resumed attempt to start at loop 2, got %+v", params)
+	}
+	got := cfg.Roles["r1"]
+	if got.Attempt != 2 || got.RetryAt != "" || got.StartLoop != 2 || got.Attempts[0].SessionDir != "e1-alpha.attempt-1" {
+		t.Fatalf("unexpected role state after retry launch: %+v", got)
+	}
+	if _, err := os.Stat(filepath.Join(cwd, config.DirName, "teams", "e1-alpha.attempt-1", ".team")); err != nil {
+		t.Fatalf("expected first attempt's files to be archived: %v", err)
+	}
+
+	res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Failed) != 1 || len(res.Retried) != 0 || cfg.Roles["r1"].Status != "failed" {
+		t.Fatalf("expected role to fail after its last attempt, got %+v", res)
+	}
+}
+
+func TestCheckAndAdvanceRolesDetectsStalledRoles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		action     string
+		wantStatus string
+		wantKilled bool
+	}{
+		{action: config.StallActionNotify, wantStatus: "stalled"},
+		{action: config.StallActionRestart, wantStatus: "pending", wantKilled: true},
+		{action: config.StallActionFail, wantStatus: "failed", wantKilled: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.action, func(t *testing.T) {
+			t.Parallel()
+
+			cwd := t.TempDir()
+			cfg := baseSchedulerConfig()
+			cfg.Session.Stall = config.StallPolicy{Timeout: "10m", Action: tt.action}
+			cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Order: 1, Intensity: 3, Status: "running", TmuxWindow: "sess:e1-alpha", Attempt: 1}
+			plan := oneRolePlan("perf", "perf-r1")
+			writeRoleTeamStatus(t, cwd, "e1-alpha", "active\ncurrent_loop=1\nlast_activity=2026-03-01T03:00:00Z")
+
+			start := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+			now := start
+			pane := "loop 1"
+			var killed []string
+			var params []teams.RoleSessionParams
+			deps := SchedulerDeps{
+				GenerateRoleSession: func(p teams.RoleSessionParams) (string, error) {
+					params = append(params, p)
+					return filepath.Join(p.Cwd, config.DirName, "teams", p.EpicBeadID+"-"+p.CodeName), nil
+				},
+				Executor: &fakeExecutor{
+					alive:      func(_, windowName string) bool { return !slices.Contains(killed, windowName) },
+					exitStatus: func(_, _ string) (int, bool) { return 0, false },
+					output:     func(_, _ string) (string, bool) { return pane, true },
+					stop: func(_, windowName string) error {
+						killed = append(killed, windowName)
+						return nil
+					},
+				},
+				Now: func() time.Time { return now },
+			}
+
+			res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+			if err != nil {
+				t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+			}
+			if !res.ActivityChanged || cfg.Roles["r1"].LastActivityAt != "2026-03-01T03:00:00Z" {
+				t.Fatalf("expected first pass to record activity, got %+v / %+v", res, cfg.Roles["r1"])
+			}
+
+			now = start.Add(9 * time.Minute)
+			if res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps); err != nil || len(res.Stalled) != 0 || res.ActivityChanged {
+				t.Fatalf("expected no stall before the timeout, got %+v, %v", res, err)
+			}
+
+			now = start.Add(10 * time.Minute)
+			res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+			if err != nil {
+				t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+			}
+			if len(res.Stalled) != 1 || res.Stalled[0] != "r1" {
+				t.Fatalf("expected r1 reported stalled, got %+v", res)
+			}
+			if got := cfg.Roles["r1"].Status; got != tt.wantStatus {
+				t.Fatalf("expected status %q, got %q", tt.wantStatus, got)
+			}
+			if gotKilled := len(killed) == 1 && killed[0] == "e1-alpha"; gotKilled != tt.wantKilled {
+				t.Fatalf("unexpected killed windows %v", killed)
+			}
+			if res.Blocked {
+				t.Fatalf("expected stalled run not to be blocked, got %+v", res)
+			}
+
+			switch tt.action {
+			case config.StallActionNotify:
+				pane = "loop 2"
+				now = start.Add(11 * time.Minute)
+				if res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps); err != nil || !res.ActivityChanged {
+					t.Fatalf("expected new output to be recorded, got %+v, %v", res, err)
+				}
+				if got := cfg.Roles["r1"].Status; got != "running" {
+					t.Fatalf("expected role to recover from stalled, got %q", got)
+				}
+			case config.StallActionRestart:
+				if len(res.Retried) != 1 || res.Retried[0].NextAttempt != 2 {
+					t.Fatalf("expected a restart as attempt 2, got %+v", res.Retried)
+				}
+				if _, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps); err != nil {
+					t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+				}
+				if len(params) != 1 || params[0].StartLoop != 1 {
+					t.Fatalf("expected restart to resume from loop 1, got %+v", params)
+				}
+				if got := cfg.Roles["r1"]; got.Status != "running" || got.Attempts[0].Reason != "stalled" {
+					t.Fatalf("unexpected restarted role %+v", got)
+				}
+			case config.StallActionFail:
+				if len(res.Failed) != 1 {
+					t.Fatalf("expected stalled role to fail, got %+v", res)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckAndAdvanceRolesFailsRoleThatKeepsStalling(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		retry        config.RetryPolicy
+		wantLaunches int
+	}{
+		{name: "retry policy", retry: config.RetryPolicy{MaxAttempts: 2}, wantLaunches: 2},
+		{name: "default cap", wantLaunches: config.DefaultStallRestartAttempts},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cwd := t.TempDir()
+			cfg := baseSchedulerConfig()
+			cfg.Session.Stall = config.StallPolicy{Timeout: "10m", Action: config.StallActionRestart}
+			cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", Status: "running", Retry: tt.retry}
+			cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Order: 1, Intensity: 3, Status: "running", TmuxWindow: "sess:e1-alpha", Attempt: 1}
+			plan := oneRolePlan("perf", "perf-r1")
+			writeRoleTeamStatus(t, cwd, "e1-alpha", "active\ncurrent_loop=1")
+
+			now := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+			launches := 1
+			var killed []string
+			deps := SchedulerDeps{
+				GenerateRoleSession: func(p teams.RoleSessionParams) (string, error) {
+					launches++
+					return filepath.Join(p.Cwd, config.DirName, "teams", p.EpicBeadID+"-"+p.CodeName), nil
+				},
+				Executor: &fakeExecutor{
+					alive:      func(_, windowName string) bool { return !slices.Contains(killed, windowName) },
+					exitStatus: func(_, _ string) (int, bool) { return 0, false },
+					output:     func(_, _ string) (string, bool) { return "hung", true },
+					stop: func(_, windowName string) error {
+						killed = append(killed, windowName)
+						return nil
+					},
+				},
+				Now: func() time.Time { return now },
+			}
+
+			var failed []string
+			for pass := 0; pass < 20 && cfg.Roles["r1"].Status != "failed"; pass++ {
+				res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+				if err != nil {
+					t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+				}
+				failed = append(failed, res.Failed...)
+				now = now.Add(10 * time.Minute)
+			}
+
+			if got := cfg.Roles["r1"]; got.Status != "failed" || got.Attempt != tt.wantLaunches {
+				t.Fatalf("expected role to fail after %d launches, got %+v", tt.wantLaunches, got)
+			}
+			if launches != tt.wantLaunches || len(killed) != tt.wantLaunches {
+				t.Fatalf("expected %d launches and kills, got %d launches and kills %v", tt.wantLaunches, launches, killed)
+			}
+			if len(failed) != 1 || failed[0] != "r1" {
+				t.Fatalf("expected r1 reported failed once, got %v", failed)
+			}
+		})
+	}
+}
+
+func TestCheckAndAdvanceRolesHonorsPausedSkippedAndCancelledRoles(t *testing.T) {
+	t.Parallel()
+
+	cwd := t.TempDir()
+	cfg := baseSchedulerConfig()
+	cfg.Epics["e1"] = config.EpicState{BeadID: "e1", AuditType: "perf", Status: "running", Paused: true}
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Order: 1, Status: "skipped"}
+	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Order: 2, Status: "pending"}
+	plan := twoRolePlan("perf", "perf-r1", "perf-r2")
+
+	var params []teams.RoleSessionParams
+	deps := SchedulerDeps{
+		GenerateRoleSession: func(p teams.RoleSessionParams) (string, error) {
+			params = append(params, p)
+			return filepath.Join(p.Cwd, config.DirName, "teams", p.EpicBeadID+"-"+p.CodeName), nil
+		},
+		Executor: &fakeExecutor{
+			alive:      func(_, _ string) bool { return false },
+			exitStatus: func(_, _ string) (int, bool) { return 0, false },
+		},
+		Now: func() time.Time { return time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC) },
+	}
+
+	res, err := CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Launched) != 0 || res.Blocked || cfg.Epics["e1"].Status != "paused" {
+		t.Fatalf("expected paused epic to hold its roles without blocking, got %+v status %q", res, cfg.Epics["e1"].Status)
+	}
+
+	epic := cfg.Epics["e1"]
+	epic.Paused = false
+	cfg.Epics["e1"] = epic
+	res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Launched) != 1 || res.Launched[0].RoleBeadID != "r2" {
+		t.Fatalf("expected role after a skipped role to launch, got %+v", res.Launched)
+	}
+	if len(params) != 1 || len(params[0].PriorWork) != 0 {
+		t.Fatalf("expected no prior work from a skipped role, got %+v", params)
+	}
+
+	cfg.Roles["r1"] = config.RoleState{BeadID: "r1", EpicBeadID: "e1", CodeName: "alpha", Order: 1, Status: "cancelled"}
+	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Order: 2, Status: "pending"}
+	res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if len(res.Launched) != 0 || !res.Blocked || cfg.Epics["e1"].Status != "blocked" {
+		t.Fatalf("expected cancelled role to block the roles after it, got %+v status %q", res, cfg.Epics["e1"].Status)
+	}
+
+	cfg.Roles["r2"] = config.RoleState{BeadID: "r2", EpicBeadID: "e1", CodeName: "bravo", Order: 2, Status: "skipped"}
+	res, err = CheckAndAdvanceRoles(cwd, cfg, "sess", plan, deps)
+	if err != nil {
+		t.Fatalf("CheckAndAdvanceRoles() error = %v", err)
+	}
+	if !res.AllDone || cfg.Epics["e1"].Status != "cancelled" {
+		t.Fatalf("expected cancelled and skipped roles to finish the epic as cancelled, got %+v status %q", res, cfg.Epics["e1"].Status)
+	}
+}
@@ -8,6 +8,7 @@ const (
 	colorMuted     = lipgloss.Color("241")
 	colorAccent    = lipgloss.Color("212")
 	colorSuccess   = lipgloss.Color("42")
+	colorWarning   = lipgloss.Color("214")
 	colorDanger    = lipgloss.Color("203")
 )
 
@@ -21,6 +22,7 @@ type Styles struct {
 	Selected    lipgloss.Style
 	Help        lipgloss.Style
 	Success     lipgloss.Style
+	Warning     lipgloss.Style
 	Error       lipgloss.Style
 	FocusedMark lipgloss.Style
 }
@@ -49,6 +51,9 @@ func DefaultStyles() Styles {
 			Foreground(colorMuted),
 		Success: lipgloss.NewStyle().
 			Foreground(colorSuccess),
+		Warning: lipgloss.NewStyle().
+			Foreground(colorWarning).
+			Bold(true),
 		Error: lipgloss.NewStyle().
 			Foreground(colorDanger),
 		FocusedMark: lipgloss.NewStyle().
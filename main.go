@@ -1,12 +1,12 @@
 package main
 
 import (
-	"fmt"
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
-	tea "github.com/charmbracelet/bubbletea"
-
-	"lattice/internal/tui"
+	"lattice/internal/cli"
 )
 
 func main() {
@@ -15,9 +15,8 @@ func main() {
 		cwd = "unknown"
 	}
 
-	p := tea.NewProgram(tui.NewApp(cwd), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "error running app: %v\n", err)
-		os.Exit(1)
-	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := cli.Run(ctx, os.Args[1:], cwd, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
 }
@@ -32,6 +32,10 @@ func TestAuditTemplateIncludesStaticAgentAndSkillFiles(t *testing.T) {
 		t.Fatalf("missing static agent file: %v", err)
 	}
 
+	if _, err := fs.ReadFile(AuditTemplate, "audit/.opencode/agents/investigator.md"); err != nil {
+		t.Fatalf("missing investigator agent template: %v", err)
+	}
+
 	if _, err := fs.ReadFile(AuditTemplate, "audit/.opencode/skills/compile-report/SKILL.md"); err != nil {
 		t.Fatalf("missing static skill file: %v", err)
 	}
@@ -40,12 +44,17 @@ func TestAuditTemplateIncludesStaticAgentAndSkillFiles(t *testing.T) {
 func TestAuditTemplateFilesRenderWithTestData(t *testing.T) {
 	t.Parallel()
 
+	type testRole struct {
+		CodeName string
+		Title    string
+	}
+
 	type testData struct {
 		TeamName   string
 		Intensity  int
 		BeadPrefix string
 		Target     string
-		Roles      []string
+		Roles      []testRole
 		FocusAreas []string
 	}
 
@@ -54,7 +63,7 @@ func TestAuditTemplateFilesRenderWithTestData(t *testing.T) {
 		Intensity:  3,
 		BeadPrefix: "perf-12",
 		Target:     "Checkout flow",
-		Roles:      []string{"Senior engineer", "Staff engineer"},
+		Roles:      []testRole{{CodeName: "alpha", Title: "Senior engineer"}, {CodeName: "threat-modeler", Title: "Threat modeler"}},
 		FocusAreas: []string{"render performance", "network waterfalls"},
 	}
 
@@ -62,7 +71,8 @@ func TestAuditTemplateFilesRenderWithTestData(t *testing.T) {
 	assertRenderedContains(t, "audit/.team.tmpl", data, "intensity=3")
 	assertRenderedContains(t, "audit/INSTRUCTIONS.md.tmpl", data, "Use the team bead prefix `perf-12`")
 	assertRenderedContains(t, "audit/context/TASK.md.tmpl", data, "Checkout flow")
-	assertRenderedContains(t, "audit/context/TASK.md.tmpl", data, "- Senior engineer")
+	assertRenderedContains(t, "audit/context/TASK.md.tmpl", data, "- Senior engineer (investigator-alpha)")
+	assertRenderedContains(t, "audit/context/TASK.md.tmpl", data, "- Threat modeler (investigator-threat-modeler)")
 	assertRenderedContains(t, "audit/context/TASK.md.tmpl", data, "- network waterfalls")
 }
 
@@ -98,6 +108,14 @@ func TestRoleSessionTemplateIncludesStaticAgentAndSkillFiles(t *testing.T) {
 func TestRoleSessionTemplateFilesRenderWithTestData(t *testing.T) {
 	t.Parallel()
 
+	type priorWork struct {
+		RoleTitle  string
+		CodeName   string
+		BeadPrefix string
+		Report     string
+		BeadIDs    []string
+	}
+
 	type testData struct {
 		TeamName     string
 		EpicBeadID   string
@@ -108,6 +126,9 @@ func TestRoleSessionTemplateFilesRenderWithTestData(t *testing.T) {
 		BeadPrefix   string
 		Target       string
 		FocusAreas   []string
+		PriorWork    []priorWork
+		StartLoop    int
+		AgentCommand string
 	}
 
 	data := testData{
@@ -120,14 +141,29 @@ func TestRoleSessionTemplateFilesRenderWithTestData(t *testing.T) {
 		BeadPrefix:   "sec-88",
 		Target:       "Authentication middleware",
 		FocusAreas:   []string{"token validation", "authorization checks"},
+		PriorWork: []priorWork{{
+			RoleTitle:  "Senior security specialist",
+			CodeName:   "alpha",
+			BeadPrefix: "sec-87",
+			Report:     "Found a token replay issue in sec-87-a1.",
+			BeadIDs:    []string{"sec-87-a1"},
+		}},
+		StartLoop:    1,
+		AgentCommand: "opencode run auditor",
 	}
 
 	assertRenderedContainsFromFS(t, RoleSessionTemplate, "role-session/.team.tmpl", data, "team=audit-role-security")
 	assertRenderedContainsFromFS(t, RoleSessionTemplate, "role-session/.team.tmpl", data, "epic_bead_id=epic-101")
 	assertRenderedContainsFromFS(t, RoleSessionTemplate, "role-session/.team.tmpl", data, "role=Security specialist")
+	assertRenderedContainsFromFS(t, RoleSessionTemplate, "role-session/.team.tmpl", data, "current_loop=1")
+	assertRenderedContainsFromFS(t, RoleSessionTemplate, "role-session/.team.tmpl", data, "last_activity=")
 	assertRenderedContainsFromFS(t, RoleSessionTemplate, "role-session/INSTRUCTIONS.md.tmpl", data, "Use the role bead prefix `sec-88`")
 	assertRenderedContainsFromFS(t, RoleSessionTemplate, "role-session/context/TASK.md.tmpl", data, "- Epic bead: `epic-101`")
 	assertRenderedContainsFromFS(t, RoleSessionTemplate, "role-session/context/TASK.md.tmpl", data, "- authorization checks")
+	assertRenderedContainsFromFS(t, RoleSessionTemplate, "role-session/context/TASK.md.tmpl", data, "### Senior security specialist (alpha)")
+	assertRenderedContainsFromFS(t, RoleSessionTemplate, "role-session/context/TASK.md.tmpl", data, "  - `sec-87-a1`")
+	assertRenderedContainsFromFS(t, RoleSessionTemplate, "role-session/context/TASK.md.tmpl", data, "Found a token replay issue")
+	assertRenderedContainsFromFS(t, RoleSessionTemplate, "role-session/run-agent.sh.tmpl", data, "\nopencode run auditor\ncode=$?")
 }
 
 func assertRenderedContains(t *testing.T, filePath string, data any, want string) {